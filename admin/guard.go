@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"net/netip"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/pkg/errors"
+)
+
+// parseAllowList 解析IP或CIDR形式的白名单，单个IP视为只包含该地址的前缀
+func parseAllowList(entries []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if strings.Contains(entry, "/") {
+			p, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, errors.Wrapf(err, "管理端口IP白名单配置错误: %s", entry)
+			}
+			prefixes = append(prefixes, p.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, errors.Wrapf(err, "管理端口IP白名单配置错误: %s", entry)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// guard 管理端口的访问控制中间件，先校验来源IP再校验访问令牌
+// allow 为空时不限制IP，token 为空时不校验令牌；Unix域套接字的连接由文件权限控制，不校验IP
+func guard(allow []netip.Prefix, token string) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if len(allow) > 0 && c.RequestCtx().RemoteAddr().Network() != "unix" && !allowed(allow, c.IP()) {
+			return fiber.NewError(fiber.StatusForbidden, "来源地址不在管理端口白名单中")
+		}
+
+		if token != "" {
+			got, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				c.Set(fiber.HeaderWWWAuthenticate, `Bearer realm="doghole-admin"`)
+				return fiber.NewError(fiber.StatusUnauthorized, "管理端口访问令牌无效")
+			}
+		}
+		return c.Next()
+	}
+}
+
+// allowed 判断IP是否在白名单中
+func allowed(allow []netip.Prefix, ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range allow {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
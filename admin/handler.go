@@ -0,0 +1,123 @@
+package admin
+
+import (
+	"encoding/json"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"doghole/config"
+	"doghole/health"
+	"doghole/logger"
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+// redacted 替换敏感配置项的值
+const redacted = "******"
+
+// sensitiveKeys 在 /config 中隐藏值的配置项
+var sensitiveKeys = map[string]bool{
+	"password":    true,
+	"token":       true,
+	"access_key":  true,
+	"secret_key":  true,
+	"signing_key": true,
+}
+
+// sensitiveMaps 在 /config 中隐藏所有值的配置项，按完整路径匹配，如请求头中可能包含任意名称的认证令牌
+var sensitiveMaps = map[string]bool{
+	"tracing.headers": true,
+}
+
+// registerRoutes 注册管理端口的路由
+func (s *Server) registerRoutes() {
+	health.RegisterRoutes(s.app, s.health, true)
+	s.app.Get("/buildinfo", s.buildInfo)
+	s.app.Get("/config", s.configDump)
+	s.app.Get("/log/level", s.getLogLevel)
+	s.app.Put("/log/level", s.setLogLevel)
+}
+
+// buildInfo 返回版本、Go运行时和依赖模块信息
+func (s *Server) buildInfo(c fiber.Ctx) error {
+	resp := fiber.Map{
+		"version":    s.build.Version,
+		"commit":     s.build.Commit,
+		"build_time": s.build.BuildTime,
+		"go_version": runtime.Version(),
+		"platform":   runtime.GOOS + "/" + runtime.GOARCH,
+		"started_at": s.started.Format(time.RFC3339),
+		"uptime":     time.Since(s.started).Round(time.Second).String(),
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		deps := make(map[string]string, len(info.Deps))
+		for _, dep := range info.Deps {
+			deps[dep.Path] = dep.Version
+		}
+		resp["module"] = info.Main.Path
+		resp["deps"] = deps
+	}
+	return c.JSON(resp)
+}
+
+// configDump 返回当前生效的配置（包括热更新后的值），密码、令牌等敏感项被隐藏
+func (s *Server) configDump(c fiber.Ctx) error {
+	data, err := json.Marshal(config.GetGlobalConfig())
+	if err != nil {
+		return err
+	}
+	var tree any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return err
+	}
+	return c.JSON(redact("", tree))
+}
+
+// redact 递归隐藏敏感配置项的非空值，path 为当前值的配置路径
+func redact(path string, v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			child := key
+			if path != "" {
+				child = path + "." + key
+			}
+			if s, ok := value.(string); ok && s != "" && (sensitiveKeys[key] || sensitiveMaps[path]) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redact(child, value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redact(path, value)
+		}
+	}
+	return v
+}
+
+// logLevelRequest 修改日志级别的请求
+type logLevelRequest struct {
+	Level string `json:"level"`
+}
+
+// getLogLevel 返回当前的日志级别
+func (s *Server) getLogLevel(c fiber.Ctx) error {
+	return c.JSON(fiber.Map{"level": logger.Level()})
+}
+
+// setLogLevel 在运行时修改日志级别，进程重启后恢复为配置的级别
+func (s *Server) setLogLevel(c fiber.Ctx) error {
+	var req logLevelRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "请求体格式错误")
+	}
+
+	previous := logger.Level()
+	if err := logger.SetLevel(req.Level); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	s.logger.Info("日志级别已修改", zap.String("from", previous), zap.String("to", logger.Level()), zap.String("ip", c.IP()))
+	return c.JSON(fiber.Map{"level": logger.Level()})
+}
@@ -0,0 +1,136 @@
+package admin
+
+import (
+	"context"
+	"expvar"
+	"runtime"
+	"sync"
+	"time"
+
+	"doghole/config"
+	"doghole/health"
+	"doghole/listener"
+	"github.com/gofiber/fiber/v3"
+	fiberexpvar "github.com/gofiber/fiber/v3/middleware/expvar"
+	"github.com/gofiber/fiber/v3/middleware/pprof"
+	"github.com/gofiber/fiber/v3/middleware/recover"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// BuildInfo 构建信息，由构建时注入的版本变量填充
+type BuildInfo struct {
+	Version   string `json:"version"`    // 版本号
+	Commit    string `json:"commit"`     // Git提交哈希
+	BuildTime string `json:"build_time"` // 构建时间
+}
+
+// Server 管理端口的HTTP服务器，与公开API使用不同的Fiber应用和监听地址
+type Server struct {
+	app     *fiber.App
+	conf    config.AdminConfig
+	build   BuildInfo
+	health  *health.Registry
+	started time.Time
+	logger  *zap.Logger
+}
+
+// New 创建管理端口服务器，allow_ips 中有无效的地址时返回错误
+func New(conf config.AdminConfig, options ...func(*Server)) (*Server, error) {
+	s := &Server{
+		conf:    conf,
+		health:  health.Default(),
+		started: time.Now(),
+		logger:  zap.L(),
+	}
+	for _, option := range options {
+		option(s)
+	}
+
+	allow, err := parseAllowList(conf.AllowIPs)
+	if err != nil {
+		return nil, err
+	}
+	if conf.Token == "" && len(allow) == 0 {
+		s.logger.Warn("管理端口未配置访问令牌和IP白名单，请确保监听地址不对外开放", zap.String("addr", conf.Addr))
+	}
+
+	s.app = fiber.New(fiber.Config{
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 60 * time.Second, // pprof 默认采样30秒
+	})
+	s.app.Use(recover.New())
+	s.app.Use(guard(allow, conf.Token))
+	s.app.Use(fiberexpvar.New()) // /debug/vars
+	if conf.Pprof {
+		s.app.Use(pprof.New()) // /debug/pprof
+	}
+
+	publishVars(s)
+	s.registerRoutes()
+	return s, nil
+}
+
+// WithLogger 设置日志记录器
+func WithLogger(logger *zap.Logger) func(*Server) {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+// WithBuildInfo 设置 /buildinfo 返回的构建信息
+func WithBuildInfo(build BuildInfo) func(*Server) {
+	return func(s *Server) {
+		s.build = build
+	}
+}
+
+// WithHealth 设置探针使用的健康检查注册表，默认使用全局注册表
+func WithHealth(registry *health.Registry) func(*Server) {
+	return func(s *Server) {
+		s.health = registry
+	}
+}
+
+// App 返回底层的Fiber应用实例，用于挂载其他模块的运维接口
+func (s *Server) App() *fiber.App {
+	return s.app
+}
+
+// Start 在配置的地址上启动管理端口，阻塞直到 Shutdown 被调用或监听出错
+// Unix域套接字只允许所属用户访问
+func (s *Server) Start() error {
+	lns, err := listener.Listen(s.conf.Addr, listener.UnixOptions{Mode: 0o600})
+	if err != nil {
+		return errors.Wrap(err, "监听管理端口失败")
+	}
+	ln := listener.Multi(lns...)
+
+	s.logger.Info("管理端口启动", zap.String("地址", ln.Addr().String()), zap.Bool("pprof", s.conf.Pprof))
+	return s.app.Listener(ln, fiber.ListenConfig{DisableStartupMessage: true})
+}
+
+// Shutdown 停止管理端口，等待处理中的请求完成
+func (s *Server) Shutdown(ctx context.Context) error {
+	if err := s.app.ShutdownWithContext(ctx); err != nil {
+		return errors.Wrap(err, "管理端口强制关闭")
+	}
+	return nil
+}
+
+var publishOnce sync.Once
+
+// publishVars 在 /debug/vars 中发布运行时和构建信息，expvar 的变量全局唯一，只发布一次
+func publishVars(s *Server) {
+	publishOnce.Do(func() {
+		expvar.Publish("goroutines", expvar.Func(func() any {
+			return runtime.NumGoroutine()
+		}))
+		expvar.Publish("uptime_seconds", expvar.Func(func() any {
+			return int64(time.Since(s.started).Seconds())
+		}))
+		expvar.Publish("build", expvar.Func(func() any {
+			return s.build
+		}))
+	})
+}
@@ -0,0 +1,77 @@
+// Package auth 定义认证中间件与其他模块之间约定的调用方身份
+// 认证中间件（由部署方根据自身的登录方式实现，通过 server.WithAuth 挂载）将用户ID、租户ID和角色写入 Locals，
+// 限流、功能开关、事件上下文和各业务接口从这里读取
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// 认证中间件写入的 Locals 键，角色为 []string 或逗号分隔的字符串
+const (
+	LocalsUserID   = "userid"
+	LocalsTenantID = "tenantid"
+	LocalsRoles    = "roles"
+)
+
+// HeaderTenantID 未经认证的租户ID请求头，只能用于限流等不涉及权限的场景，不能用于功能开关定向
+const HeaderTenantID = "X-Tenant-ID"
+
+// UserID 返回认证中间件设置的当前用户ID，未认证时返回空字符串
+func UserID(c fiber.Ctx) string {
+	return localsString(c, LocalsUserID)
+}
+
+// TenantID 返回认证中间件设置的当前租户ID，未设置时返回空字符串
+func TenantID(c fiber.Ctx) string {
+	return localsString(c, LocalsTenantID)
+}
+
+// Roles 返回认证中间件设置的当前用户角色，未设置时返回nil
+func Roles(c fiber.Ctx) []string {
+	switch v := c.Locals(LocalsRoles).(type) {
+	case []string:
+		return v
+	case string:
+		var roles []string
+		for _, role := range strings.Split(v, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				roles = append(roles, role)
+			}
+		}
+		return roles
+	default:
+		return nil
+	}
+}
+
+// HasRole 判断当前用户是否拥有指定角色，未认证的请求始终返回false
+func HasRole(c fiber.Ctx, role string) bool {
+	if role == "" || UserID(c) == "" {
+		return false
+	}
+	return slices.Contains(Roles(c), role)
+}
+
+// Required 要求请求已认证，Locals 中没有用户ID时返回401
+func Required() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if UserID(c) == "" {
+			return fiber.ErrUnauthorized
+		}
+		return c.Next()
+	}
+}
+
+// localsString 读取 Locals 中的值并转为字符串
+func localsString(c fiber.Ctx, key string) string {
+	v := c.Locals(key)
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,143 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"doghole/config"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// ALPNProto TLS-ALPN-01 验证使用的应用层协议，HTTPS监听的 NextProtos 中必须包含
+const ALPNProto = acme.ALPNProto
+
+// ACME 通过ACME协议自动申请证书，并在到期前续期
+// TLS-ALPN-01 验证在HTTPS监听上完成，配置了 http_addr 时同时支持 HTTP-01
+type ACME struct {
+	manager *autocert.Manager
+	conf    config.ACMEConfig
+	logger  *zap.Logger
+}
+
+// NewACME 创建ACME证书管理器
+func NewACME(conf config.ACMEConfig, cache autocert.Cache, logger *zap.Logger) (*ACME, error) {
+	if !conf.AcceptTOS {
+		return nil, errors.New("启用ACME时必须设置 accept_tos: true 同意证书颁发机构的服务条款")
+	}
+	if len(conf.Domains) == 0 {
+		return nil, errors.New("启用ACME时必须配置 domains")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if conf.CAFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := os.ReadFile(conf.CAFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "读取ACME服务器CA文件失败: %s", conf.CAFile)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("ACME服务器CA文件中没有有效的证书: %s", conf.CAFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	client := &acme.Client{
+		DirectoryURL: conf.DirectoryURL,
+		UserAgent:    "doghole",
+		HTTPClient:   &http.Client{Transport: newOrderLocation(transport)},
+	}
+
+	return &ACME{
+		manager: &autocert.Manager{
+			Prompt:      autocert.AcceptTOS,
+			Cache:       cache,
+			HostPolicy:  autocert.HostWhitelist(conf.Domains...),
+			RenewBefore: conf.RenewBefore,
+			Client:      client,
+			Email:       conf.Email,
+		},
+		conf:   conf,
+		logger: logger,
+	}, nil
+}
+
+// GetCertificate 返回域名的证书，首次访问或缓存中没有时向ACME服务器申请
+func (a *ACME) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return a.manager.GetCertificate(hello)
+}
+
+// Start 启动 HTTP-01 验证服务并在后台为所有域名预先申请证书，直到ctx取消
+// 证书加载后由 autocert 在到期前 renew_before 自动续期
+func (a *ACME) Start(ctx context.Context) error {
+	if a.conf.HTTPAddr != "" {
+		ln, err := net.Listen("tcp", a.conf.HTTPAddr)
+		if err != nil {
+			return errors.Wrapf(err, "监听HTTP-01验证地址失败: %s", a.conf.HTTPAddr)
+		}
+
+		// 验证请求以外的请求重定向到HTTPS
+		srv := &http.Server{
+			Handler:           stripPort(a.manager.HTTPHandler(nil)),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				a.logger.Error("HTTP-01验证服务异常退出", zap.Error(err))
+			}
+		}()
+		go func() {
+			<-ctx.Done()
+			srv.Close()
+		}()
+		a.logger.Info("HTTP-01验证服务启动", zap.String("地址", ln.Addr().String()))
+	}
+
+	go a.prefetch(ctx)
+	return nil
+}
+
+// prefetch 为所有域名加载或申请证书，避免首个请求等待签发，同时启动续期计时
+func (a *ACME) prefetch(ctx context.Context) {
+	for _, domain := range a.conf.Domains {
+		if ctx.Err() != nil {
+			return
+		}
+
+		cert, err := a.manager.GetCertificate(&tls.ClientHelloInfo{
+			ServerName:        domain,
+			SignatureSchemes:  []tls.SignatureScheme{tls.ECDSAWithP256AndSHA256},
+			SupportedCurves:   []tls.CurveID{tls.CurveP256},
+			CipherSuites:      []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+			SupportedVersions: []uint16{tls.VersionTLS13, tls.VersionTLS12},
+		})
+		if err != nil {
+			a.logger.Error("申请证书失败", zap.String("domain", domain), zap.Error(err))
+			continue
+		}
+		if cert.Leaf != nil {
+			a.logger.Info("证书已就绪", zap.String("domain", domain), zap.Time("not_after", cert.Leaf.NotAfter))
+		}
+	}
+}
+
+// stripPort 去掉 Host 中的端口，autocert 按 Host 匹配域名白名单，
+// 验证服务不在80端口时（如使用 Pebble 测试）验证请求的 Host 会带有端口
+func stripPort(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if host, _, err := net.SplitHostPort(r.Host); err == nil {
+			r.Host = host
+		}
+		next.ServeHTTP(w, r)
+	})
+}
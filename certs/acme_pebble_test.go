@@ -0,0 +1,134 @@
+//go:build pebble
+
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"doghole/config"
+	"doghole/ent/enttest"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap/zaptest"
+)
+
+// 针对 Pebble 的端到端测试，Pebble 的启动方式见 README：
+//
+//	PEBBLE_VA_NOSLEEP=1 pebble -config test/config/pebble-config.json
+//	PEBBLE_DIRECTORY_URL=https://localhost:14000/dir \
+//	PEBBLE_CA_FILE=/path/to/pebble/test/certs/pebble.minica.pem \
+//	go test -tags pebble ./certs -run Pebble -v
+//
+// Pebble 默认向域名解析出的地址的 5002 端口发起 HTTP-01 验证，PEBBLE_DOMAIN 需解析到本机（如写入 /etc/hosts），
+// 也可以启动 Pebble 时设置 PEBBLE_VA_ALWAYS_VALID=1 跳过验证
+const (
+	envDirectoryURL = "PEBBLE_DIRECTORY_URL" // ACME目录地址，未设置时跳过测试
+	envCAFile       = "PEBBLE_CA_FILE"       // Pebble HTTPS证书的CA文件
+	envDomain       = "PEBBLE_DOMAIN"        // 申请证书的域名，默认 doghole.test
+	envHTTPAddr     = "PEBBLE_HTTP_ADDR"     // HTTP-01验证的监听地址，默认 :5002
+)
+
+// pebbleConfig 从环境变量读取 Pebble 测试的ACME配置
+func pebbleConfig(t *testing.T) config.ACMEConfig {
+	t.Helper()
+
+	directoryURL := os.Getenv(envDirectoryURL)
+	if directoryURL == "" {
+		t.Skipf("未设置 %s，跳过 Pebble 测试", envDirectoryURL)
+	}
+
+	conf := config.ACMEConfig{
+		Enabled:      true,
+		Domains:      []string{os.Getenv(envDomain)},
+		Email:        "admin@example.com",
+		AcceptTOS:    true,
+		DirectoryURL: directoryURL,
+		CAFile:       os.Getenv(envCAFile),
+		Cache:        "db",
+		RenewBefore:  24 * time.Hour,
+		HTTPAddr:     os.Getenv(envHTTPAddr),
+	}
+	if conf.Domains[0] == "" {
+		conf.Domains[0] = "doghole.test"
+	}
+	if conf.HTTPAddr == "" {
+		conf.HTTPAddr = ":5002"
+	}
+	return conf
+}
+
+// hello 模拟客户端对域名的TLS握手
+func hello(domain string) *tls.ClientHelloInfo {
+	return &tls.ClientHelloInfo{
+		ServerName:        domain,
+		SignatureSchemes:  []tls.SignatureScheme{tls.ECDSAWithP256AndSHA256},
+		SupportedCurves:   []tls.CurveID{tls.CurveP256},
+		CipherSuites:      []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+		SupportedVersions: []uint16{tls.VersionTLS13, tls.VersionTLS12},
+	}
+}
+
+func TestPebbleIssueAndCache(t *testing.T) {
+	conf := pebbleConfig(t)
+	domain := conf.Domains[0]
+
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	t.Cleanup(func() { client.Close() })
+	cache, err := NewCache(conf, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	a, err := NewACME(conf, cache, zaptest.NewLogger(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := a.GetCertificate(hello(domain))
+	if err != nil {
+		t.Fatalf("申请证书失败: %v", err)
+	}
+	if cert.Leaf == nil || !slices.Contains(cert.Leaf.DNSNames, domain) {
+		t.Fatalf("证书不包含域名 %s", domain)
+	}
+	if time.Until(cert.Leaf.NotAfter) <= conf.RenewBefore {
+		t.Fatalf("证书有效期过短: %s", cert.Leaf.NotAfter)
+	}
+
+	// 证书保存在数据库中，新的实例直接从缓存加载，不再重新申请
+	n, err := client.CertCache.Query().Count(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n < 2 {
+		t.Fatalf("数据库中应保存账号私钥和证书，实际 %d 条", n)
+	}
+
+	other, err := NewACME(conf, cache, zaptest.NewLogger(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached, err := other.GetCertificate(hello(domain))
+	if err != nil {
+		t.Fatalf("从缓存加载证书失败: %v", err)
+	}
+	if cached.Leaf.SerialNumber.Cmp(cert.Leaf.SerialNumber) != 0 {
+		t.Fatal("新实例应从缓存加载同一张证书")
+	}
+
+	// 不在白名单中的域名不会申请证书
+	if _, err := a.GetCertificate(hello("other." + domain)); err == nil {
+		t.Fatal("白名单以外的域名不应签发证书")
+	}
+}
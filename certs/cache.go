@@ -0,0 +1,84 @@
+package certs
+
+import (
+	"context"
+
+	"doghole/config"
+	"doghole/ent"
+	"doghole/ent/certcache"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// NewCache 根据配置创建ACME证书缓存
+func NewCache(conf config.ACMEConfig, client *ent.Client) (autocert.Cache, error) {
+	switch conf.Cache {
+	case "", "dir":
+		if conf.CacheDir == "" {
+			return nil, errors.New("ACME证书缓存目录不能为空")
+		}
+		return autocert.DirCache(conf.CacheDir), nil
+	case "db":
+		return NewDBCache(client), nil
+	default:
+		return nil, errors.Errorf("不支持的ACME证书缓存: %s，可选 dir、db", conf.Cache)
+	}
+}
+
+// DBCache 将ACME账号私钥、证书和证书私钥保存在数据库中，多个实例共享同一份证书
+type DBCache struct {
+	client *ent.Client
+}
+
+// NewDBCache 创建数据库证书缓存
+func NewDBCache(client *ent.Client) *DBCache {
+	return &DBCache{client: client}
+}
+
+// Get 读取缓存，不存在时返回 autocert.ErrCacheMiss
+func (c *DBCache) Get(ctx context.Context, key string) ([]byte, error) {
+	entry, err := c.client.CertCache.Query().
+		Where(certcache.Key(key)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, autocert.ErrCacheMiss
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "读取证书缓存失败")
+	}
+	return entry.Data, nil
+}
+
+// Put 写入缓存，已存在时覆盖
+func (c *DBCache) Put(ctx context.Context, key string, data []byte) error {
+	for range 2 {
+		n, err := c.client.CertCache.Update().
+			Where(certcache.Key(key)).
+			SetData(data).
+			Save(ctx)
+		if err != nil {
+			return errors.Wrap(err, "更新证书缓存失败")
+		}
+		if n > 0 {
+			return nil
+		}
+
+		err = c.client.CertCache.Create().
+			SetKey(key).
+			SetData(data).
+			Exec(ctx)
+		// 其他实例同时写入时重新尝试更新
+		if !ent.IsConstraintError(err) {
+			return errors.Wrap(err, "写入证书缓存失败")
+		}
+	}
+	return errors.Errorf("写入证书缓存失败: %s", key)
+}
+
+// Delete 删除缓存
+func (c *DBCache) Delete(ctx context.Context, key string) error {
+	_, err := c.client.CertCache.Delete().
+		Where(certcache.Key(key)).
+		Exec(ctx)
+	return errors.Wrap(err, "删除证书缓存失败")
+}
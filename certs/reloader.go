@@ -0,0 +1,180 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Reloader 从磁盘加载证书和客户端CA，并在文件变更后重新加载
+// 重新加载失败时继续使用之前的证书，已建立的连接不受影响
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	interval     time.Duration
+	logger       *zap.Logger
+
+	cert      atomic.Pointer[tls.Certificate]
+	clientCAs atomic.Pointer[x509.CertPool]
+	mu        sync.Mutex
+	stamp     string // 上次加载时各文件的修改时间和大小
+}
+
+// NewReloader 创建证书加载器并立即加载一次，certFile 或 clientCAFile 为空时不加载对应的内容
+func NewReloader(certFile, keyFile, clientCAFile string, options ...func(*Reloader)) (*Reloader, error) {
+	r := &Reloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+		interval:     time.Minute,
+		logger:       zap.NewNop(),
+	}
+	for _, option := range options {
+		option(r)
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// WithInterval 设置检查文件变更的间隔
+func WithInterval(interval time.Duration) func(*Reloader) {
+	return func(r *Reloader) {
+		if interval > 0 {
+			r.interval = interval
+		}
+	}
+}
+
+// WithLogger 设置日志记录器
+func WithLogger(logger *zap.Logger) func(*Reloader) {
+	return func(r *Reloader) {
+		r.logger = logger
+	}
+}
+
+// Reload 重新读取证书、私钥和客户端CA文件，任一文件无效时不替换当前内容
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reload()
+}
+
+// reload 重新加载文件，调用方需持有锁
+func (r *Reloader) reload() error {
+	stamp := r.fileStamp()
+
+	var cert *tls.Certificate
+	if r.certFile != "" {
+		c, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			return errors.Wrapf(err, "加载证书失败: %s", r.certFile)
+		}
+		if c.Leaf == nil && len(c.Certificate) > 0 {
+			if c.Leaf, err = x509.ParseCertificate(c.Certificate[0]); err != nil {
+				return errors.Wrapf(err, "解析证书失败: %s", r.certFile)
+			}
+		}
+		cert = &c
+	}
+
+	var pool *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return errors.Wrapf(err, "读取客户端CA文件失败: %s", r.clientCAFile)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.Errorf("客户端CA文件中没有有效的证书: %s", r.clientCAFile)
+		}
+	}
+
+	if cert != nil {
+		r.cert.Store(cert)
+	}
+	if pool != nil {
+		r.clientCAs.Store(pool)
+	}
+	r.stamp = stamp
+	return nil
+}
+
+// Run 定期检查文件是否变更，变更后重新加载，直到ctx取消
+func (r *Reloader) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reloadIfChanged()
+		}
+	}
+}
+
+// reloadIfChanged 文件变更时重新加载
+func (r *Reloader) reloadIfChanged() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.fileStamp() == r.stamp {
+		return
+	}
+	if err := r.reload(); err != nil {
+		r.logger.Error("重新加载证书失败，继续使用当前证书", zap.Error(err))
+		return
+	}
+	r.logger.Info("证书已重新加载", zap.Time("not_after", r.NotAfter()))
+}
+
+// GetCertificate 返回当前证书，用于 tls.Config.GetCertificate
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert := r.cert.Load()
+	if cert == nil {
+		return nil, errors.New("未配置证书")
+	}
+	return cert, nil
+}
+
+// ClientCAs 返回当前的客户端CA，未配置时为nil
+func (r *Reloader) ClientCAs() *x509.CertPool {
+	return r.clientCAs.Load()
+}
+
+// NotAfter 返回当前证书的过期时间
+func (r *Reloader) NotAfter() time.Time {
+	if cert := r.cert.Load(); cert != nil && cert.Leaf != nil {
+		return cert.Leaf.NotAfter
+	}
+	return time.Time{}
+}
+
+// fileStamp 汇总各文件的修改时间和大小，用于判断文件是否变更
+func (r *Reloader) fileStamp() string {
+	var stamp []byte
+	for _, name := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if name == "" {
+			continue
+		}
+		if info, err := os.Stat(name); err == nil {
+			stamp = info.ModTime().AppendFormat(stamp, time.RFC3339Nano)
+			stamp = strconv.AppendInt(append(stamp, '/'), info.Size(), 10)
+		}
+		stamp = append(stamp, '|')
+	}
+	return string(stamp)
+}
@@ -0,0 +1,105 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"strings"
+
+	"doghole/config"
+	"github.com/pkg/errors"
+)
+
+// 客户端证书校验方式
+const (
+	ClientAuthNone     = "none"     // 不请求客户端证书
+	ClientAuthOptional = "optional" // 客户端提供证书时校验
+	ClientAuthRequired = "required" // 必须提供有效的客户端证书
+)
+
+// ServerConfig 根据配置创建服务端 tls.Config
+// getCertificate 提供服务端证书，clientCAs 提供校验客户端证书的CA，每次握手时调用，因此重新加载后立即生效
+func ServerConfig(conf config.TLSConfig, getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error), clientCAs func() *x509.CertPool) (*tls.Config, error) {
+	minVersion, err := ParseVersion(conf.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+	suites, err := ParseCipherSuites(conf.CipherSuites)
+	if err != nil {
+		return nil, err
+	}
+	clientAuth, err := ParseClientAuth(conf.ClientAuth)
+	if err != nil {
+		return nil, err
+	}
+	if clientAuth != tls.NoClientCert && (clientCAs == nil || clientCAs() == nil) {
+		return nil, errors.New("校验客户端证书时必须配置 client_ca_file")
+	}
+
+	base := &tls.Config{
+		MinVersion:     minVersion,
+		CipherSuites:   suites,
+		ClientAuth:     clientAuth,
+		GetCertificate: getCertificate,
+		NextProtos:     []string{"http/1.1"},
+	}
+	if clientAuth == tls.NoClientCert {
+		return base, nil
+	}
+
+	// 每次握手使用最新的客户端CA
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		c := base.Clone()
+		c.GetConfigForClient = nil
+		c.ClientCAs = clientCAs()
+		return c, nil
+	}
+	return base, nil
+}
+
+// ParseVersion 解析TLS版本，为空时使用 TLS 1.2
+func ParseVersion(version string) (uint16, error) {
+	switch strings.TrimPrefix(strings.ToLower(version), "tls") {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, errors.Errorf("不支持的TLS最低版本: %s，可选 1.2、1.3", version)
+	}
+}
+
+// ParseCipherSuites 按名称解析TLS 1.2加密套件，不接受Go标记为不安全的套件
+func ParseCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	available := make(map[string]uint16)
+	for _, s := range tls.CipherSuites() {
+		available[s.Name] = s.ID
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := available[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			return nil, errors.Errorf("不支持或不安全的加密套件: %s", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ParseClientAuth 解析客户端证书校验方式，为空时不校验
+func ParseClientAuth(mode string) (tls.ClientAuthType, error) {
+	switch strings.ToLower(mode) {
+	case "", ClientAuthNone:
+		return tls.NoClientCert, nil
+	case ClientAuthOptional:
+		return tls.VerifyClientCertIfGiven, nil
+	case ClientAuthRequired:
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return 0, errors.Errorf("不支持的客户端证书校验方式: %s，可选 none、optional、required", mode)
+	}
+}
@@ -0,0 +1,65 @@
+package certs
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// orderLocation 为未返回 Location 的完成订单响应补充订单地址
+// RFC 8555 没有要求完成订单（finalize）的响应携带 Location，Pebble 等异步签发的服务器不会返回，
+// 而 acme 客户端在订单仍在处理中时按 Location 轮询订单，缺少时请求空地址导致申请失败。
+// 创建订单时记录 finalize 地址到订单地址的映射，完成订单的响应缺少 Location 时按映射补充
+type orderLocation struct {
+	next   http.RoundTripper
+	mu     sync.Mutex
+	orders map[string]string // finalize 地址到订单地址的映射
+}
+
+// newOrderLocation 包装ACME客户端的传输层
+func newOrderLocation(next http.RoundTripper) *orderLocation {
+	return &orderLocation{
+		next:   next,
+		orders: make(map[string]string),
+	}
+}
+
+// RoundTrip 实现 http.RoundTripper 接口
+func (t *orderLocation) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || req.Method != http.MethodPost {
+		return resp, err
+	}
+
+	location := resp.Header.Get("Location")
+	switch {
+	case resp.StatusCode == http.StatusCreated && location != "":
+		// 创建订单的响应体中带有 finalize 地址，创建账号等其他响应没有
+		data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+
+		var order struct {
+			Finalize string `json:"finalize"`
+		}
+		if json.Unmarshal(data, &order) == nil && order.Finalize != "" {
+			t.mu.Lock()
+			t.orders[order.Finalize] = location
+			t.mu.Unlock()
+		}
+	case resp.StatusCode == http.StatusOK && location == "":
+		t.mu.Lock()
+		order, ok := t.orders[req.URL.String()]
+		delete(t.orders, req.URL.String())
+		t.mu.Unlock()
+		if ok {
+			resp.Header.Set("Location", order)
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,65 @@
+package changefeed
+
+import (
+	"fmt"
+	"sync"
+
+	"doghole/auth"
+	"github.com/gofiber/fiber/v3"
+)
+
+// Ownership 解析事件所属的用户和租户，无法确定时返回空字符串
+type Ownership func(e Event) (userID, tenantID string)
+
+// _ownerships 按实体类型注册的归属解析函数
+var _ownerships sync.Map // map[string]Ownership
+
+// RegisterOwnership 注册实体的归属解析函数，同一实体重复注册时覆盖
+func RegisterOwnership(entity string, fn Ownership) {
+	_ownerships.Store(entity, fn)
+}
+
+// SelfOwnership 实体ID即用户ID的归属解析函数，用于 User 实体：用户只能收到自己的变更
+func SelfOwnership(e Event) (string, string) {
+	if e.EntityID == nil {
+		return "", ""
+	}
+	return fmt.Sprint(e.EntityID), ""
+}
+
+// Owner 通过实体注册的归属解析函数返回事件所属的用户和租户，未注册或无法确定归属时返回空字符串
+func Owner(e Event) (userID, tenantID string) {
+	fn, ok := _ownerships.Load(e.Entity)
+	if !ok {
+		return "", ""
+	}
+	return fn.(Ownership)(e)
+}
+
+// OwnerAuthorizer 按实体归属授权，是订阅处理器的默认授权方式
+// 未认证的请求拒绝订阅；事件只推送给所属用户或所属租户下的用户，
+// 未注册归属解析函数的实体、无法确定归属的事件（如批量更新）一律不推送
+func OwnerAuthorizer() Authorizer {
+	return func(c fiber.Ctx) EventAuthorizer {
+		userID, tenantID := auth.UserID(c), auth.TenantID(c)
+		if userID == "" {
+			return nil
+		}
+
+		return func(e Event) bool {
+			owner, tenant := Owner(e)
+			return (owner != "" && owner == userID) || (tenant != "" && tenant == tenantID)
+		}
+	}
+}
+
+// RoleAuthorizer 拥有指定角色的调用方（如管理后台）可以收到所有事件，包括无法确定归属的事件，
+// 其他调用方使用 fallback 授权
+func RoleAuthorizer(role string, fallback Authorizer) Authorizer {
+	return func(c fiber.Ctx) EventAuthorizer {
+		if auth.HasRole(c, role) {
+			return func(Event) bool { return true }
+		}
+		return fallback(c)
+	}
+}
@@ -0,0 +1,241 @@
+package changefeed
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// 变更操作类型
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+// Event 实体变更事件
+type Event struct {
+	ID       uint64    `json:"id"`        // 单调递增的事件ID，用于断线续传
+	Type     string    `json:"type"`      // 事件类型，如 user.created
+	Entity   string    `json:"entity"`    // 实体类型，如 User
+	Op       string    `json:"op"`        // 操作类型: create, update, delete
+	EntityID any       `json:"entity_id"` // 实体ID
+	Data     any       `json:"data"`      // 变更后的实体数据，删除事件为空
+	Time     time.Time `json:"time"`      // 事件发生时间
+}
+
+// Filter 订阅过滤条件
+type Filter struct {
+	Entities []string // 关注的实体类型，空表示全部
+}
+
+// Match 判断事件是否满足过滤条件
+func (f Filter) Match(e Event) bool {
+	if len(f.Entities) == 0 {
+		return true
+	}
+	for _, entity := range f.Entities {
+		if strings.EqualFold(entity, e.Entity) {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscription 事件订阅
+type Subscription struct {
+	C      <-chan Event // 事件通道，订阅被关闭或因消费过慢被丢弃时关闭
+	ch     chan Event
+	filter Filter
+	broker *Broker
+	once   sync.Once
+}
+
+// Close 取消订阅
+func (s *Subscription) Close() {
+	s.broker.unsubscribe(s)
+}
+
+// Broker 进程内事件代理，保存有界事件日志并向订阅者广播
+type Broker struct {
+	mu         sync.RWMutex
+	subs       map[*Subscription]struct{}
+	log        []Event // 环形缓冲区
+	head       int     // 下一次写入的位置
+	size       int     // 已保存的事件数量
+	nextID     uint64
+	bufferSize int
+	done       chan struct{} // Shutdown 后关闭
+	doneOnce   sync.Once
+}
+
+// NewBroker 创建事件代理
+// logSize 为事件日志容量，bufferSize 为每个订阅者的通道缓冲大小
+func NewBroker(logSize, bufferSize int) *Broker {
+	if logSize <= 0 {
+		logSize = 1000
+	}
+	if bufferSize <= 0 {
+		bufferSize = 64
+	}
+	return &Broker{
+		subs:       make(map[*Subscription]struct{}),
+		log:        make([]Event, logSize),
+		nextID:     1,
+		bufferSize: bufferSize,
+		done:       make(chan struct{}),
+	}
+}
+
+// Publish 发布事件，返回分配了ID的事件
+// 缓冲区已满的订阅者会被断开，由客户端通过 Last-Event-ID 续传
+func (b *Broker) Publish(e Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e.ID = b.nextID
+	b.nextID++
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.log[b.head] = e
+	b.head = (b.head + 1) % len(b.log)
+	if b.size < len(b.log) {
+		b.size++
+	}
+
+	for sub := range b.subs {
+		if !sub.filter.Match(e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			b.closeLocked(sub)
+		}
+	}
+
+	return e
+}
+
+// Subscribe 订阅事件
+// lastID 大于0时先返回日志中ID大于lastID的事件；complete 为false表示日志已不包含lastID之后的全部事件
+func (b *Broker) Subscribe(filter Filter, lastID uint64) (sub *Subscription, backlog []Event, complete bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan Event, b.bufferSize)
+	sub = &Subscription{
+		C:      ch,
+		ch:     ch,
+		filter: filter,
+		broker: b,
+	}
+	b.subs[sub] = struct{}{}
+
+	if lastID == 0 {
+		return sub, nil, true
+	}
+
+	backlog, complete = b.sinceLocked(lastID)
+	filtered := backlog[:0]
+	for _, e := range backlog {
+		if filter.Match(e) {
+			filtered = append(filtered, e)
+		}
+	}
+
+	return sub, filtered, complete
+}
+
+// Since 返回日志中ID大于lastID的事件
+func (b *Broker) Since(lastID uint64) ([]Event, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.sinceLocked(lastID)
+}
+
+// sinceLocked 返回日志中ID大于lastID的事件，调用方需持有锁
+func (b *Broker) sinceLocked(lastID uint64) ([]Event, bool) {
+	events := make([]Event, 0)
+	if lastID >= b.nextID {
+		// 客户端持有的ID来自之前的进程，无法确定遗漏了哪些事件
+		return events, false
+	}
+	if b.size == 0 {
+		return events, lastID+1 >= b.nextID
+	}
+
+	start := (b.head - b.size + len(b.log)) % len(b.log)
+	oldest := b.log[start].ID
+	for i := 0; i < b.size; i++ {
+		e := b.log[(start+i)%len(b.log)]
+		if e.ID > lastID {
+			events = append(events, e)
+		}
+	}
+
+	return events, lastID+1 >= oldest
+}
+
+// unsubscribe 取消订阅
+func (b *Broker) unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closeLocked(sub)
+}
+
+// closeLocked 移除并关闭订阅，调用方需持有锁
+func (b *Broker) closeLocked(sub *Subscription) {
+	delete(b.subs, sub)
+	sub.once.Do(func() {
+		close(sub.ch)
+	})
+}
+
+// Shutdown 通知推送处理器断开连接，开始停止后新的推送请求返回503
+// 在HTTP服务器停止前调用，避免SSE和WebSocket长连接阻塞HTTP服务器等待处理中的请求；
+// 不关闭订阅，Webhook投递器等内部订阅者在 Close 前仍能收到停止过程中产生的事件
+func (b *Broker) Shutdown() {
+	b.doneOnce.Do(func() {
+		close(b.done)
+	})
+}
+
+// Done 返回 Shutdown 后关闭的通道
+func (b *Broker) Done() <-chan struct{} {
+	return b.done
+}
+
+// Close 关闭所有订阅
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs {
+		b.closeLocked(sub)
+	}
+}
+
+var (
+	_defaultBroker     *Broker
+	_defaultBrokerOnce sync.Once
+)
+
+// Default 返回全局事件代理
+func Default() *Broker {
+	_defaultBrokerOnce.Do(func() {
+		if _defaultBroker == nil {
+			_defaultBroker = NewBroker(0, 0)
+		}
+	})
+	return _defaultBroker
+}
+
+// SetDefault 设置全局事件代理，需在首次调用 Default 之前设置
+func SetDefault(b *Broker) {
+	if b == nil {
+		panic("无法设置全局事件代理为nil")
+	}
+	_defaultBroker = b
+}
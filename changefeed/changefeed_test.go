@@ -0,0 +1,174 @@
+package changefeed
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"doghole/auth"
+	"doghole/ent"
+	"doghole/ent/enttest"
+	"doghole/ent/user"
+	"github.com/gofiber/fiber/v3"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openClient 打开挂载变更事件钩子的内存数据库
+func openClient(t *testing.T, broker *Broker) *ent.Client {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", strings.ReplaceAll(t.Name(), "/", "_")))
+	t.Cleanup(func() { client.Close() })
+	Register(client, broker, "User")
+	return client
+}
+
+// eventTypes 返回事件日志中 lastID 之后的事件类型和实体ID
+func eventTypes(t *testing.T, broker *Broker, lastID uint64) []string {
+	t.Helper()
+	events, _ := broker.Since(lastID)
+	types := make([]string, 0, len(events))
+	for _, e := range events {
+		types = append(types, fmt.Sprintf("%s:%v", e.Type, e.EntityID))
+	}
+	return types
+}
+
+func TestHook(t *testing.T) {
+	ctx := context.Background()
+	broker := NewBroker(100, 10)
+	client := openClient(t, broker)
+
+	u1 := client.User.Create().SaveX(ctx)
+	u2 := client.User.Create().SaveX(ctx)
+	if got := eventTypes(t, broker, 0); strings.Join(got, ",") != fmt.Sprintf("user.created:%d,user.created:%d", u1.ID, u2.ID) {
+		t.Fatalf("创建事件 = %v", got)
+	}
+
+	// 没有影响任何行的批量更新和删除不发布事件
+	if n := client.User.Update().Where(user.ID(-1)).SaveX(ctx); n != 0 {
+		t.Fatalf("更新了 %d 行", n)
+	}
+	if n := client.User.Delete().Where(user.ID(-1)).ExecX(ctx); n != 0 {
+		t.Fatalf("删除了 %d 行", n)
+	}
+	if got := eventTypes(t, broker, 2); len(got) != 0 {
+		t.Fatalf("没有影响任何行的变更发布了事件 %v", got)
+	}
+
+	// 批量删除为每个受影响的实体发布事件
+	client.User.Delete().Where(user.IDIn(u1.ID, u2.ID)).ExecX(ctx)
+	if got := eventTypes(t, broker, 2); strings.Join(got, ",") != fmt.Sprintf("user.deleted:%d,user.deleted:%d", u1.ID, u2.ID) {
+		t.Fatalf("批量删除事件 = %v", got)
+	}
+}
+
+func TestHookTx(t *testing.T) {
+	ctx := context.Background()
+	broker := NewBroker(100, 10)
+	client := openClient(t, broker)
+
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.User.Create().SaveX(ctx)
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if got := eventTypes(t, broker, 0); len(got) != 0 {
+		t.Fatalf("回滚的变更发布了事件 %v", got)
+	}
+
+	tx, err = client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := tx.User.Create().SaveX(ctx)
+	if got := eventTypes(t, broker, 0); len(got) != 0 {
+		t.Fatalf("事务提交前发布了事件 %v", got)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if got := eventTypes(t, broker, 0); len(got) != 1 || got[0] != fmt.Sprintf("user.created:%d", u.ID) {
+		t.Fatalf("提交后的事件 = %v", got)
+	}
+}
+
+func TestAuthorizer(t *testing.T) {
+	RegisterOwnership("User", SelfOwnership)
+	t.Cleanup(func() { _ownerships.Delete("User") })
+
+	authorizer := RoleAuthorizer("admin", OwnerAuthorizer())
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error {
+		if id := c.Get("X-Test-User"); id != "" {
+			c.Locals(auth.LocalsUserID, id)
+		}
+		if roles := c.Get("X-Test-Roles"); roles != "" {
+			c.Locals(auth.LocalsRoles, roles)
+		}
+		return c.Next()
+	})
+
+	events := []Event{
+		{Entity: "User", EntityID: 1},
+		{Entity: "User", EntityID: 2},
+		{Entity: "User"},               // 无法确定归属
+		{Entity: "Order", EntityID: 1}, // 未注册归属解析函数
+	}
+	app.Get("/", func(c fiber.Ctx) error {
+		allow := authorizer(c)
+		if allow == nil {
+			return fiber.ErrForbidden
+		}
+		var visible []string
+		for _, e := range events {
+			if allow(e) {
+				visible = append(visible, fmt.Sprintf("%s:%v", e.Entity, e.EntityID))
+			}
+		}
+		return c.SendString(strings.Join(visible, ","))
+	})
+
+	tests := []struct {
+		name    string
+		user    string
+		roles   string
+		status  int
+		visible string
+	}{
+		{"匿名请求", "", "", fiber.StatusForbidden, ""},
+		{"匿名请求冒充管理员", "", "admin", fiber.StatusForbidden, ""},
+		{"普通用户只能收到自己的变更", "1", "", fiber.StatusOK, "User:1"},
+		{"其他角色", "2", "editor", fiber.StatusOK, "User:2"},
+		{"管理员收到所有变更", "3", "editor,admin", fiber.StatusOK, "User:1,User:2,User:<nil>,Order:1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			req.Header.Set("X-Test-User", tt.user)
+			req.Header.Set("X-Test-Roles", tt.roles)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d，期望 %d", resp.StatusCode, tt.status)
+			}
+			if tt.status != fiber.StatusOK {
+				return
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(body); got != tt.visible {
+				t.Fatalf("可见事件 = %q，期望 %q", got, tt.visible)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package changefeed
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"doghole/logger"
+	"github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v3"
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+)
+
+// HeaderLastEventID SSE断线续传请求头
+const HeaderLastEventID = "Last-Event-ID"
+
+// EventAuthorizer 判断当前订阅者是否有权接收某个事件
+type EventAuthorizer func(e Event) bool
+
+// Authorizer 在建立订阅时根据请求生成事件授权函数
+// 返回nil表示拒绝订阅；订阅建立后请求上下文不再可用，需要的身份信息应在此时捕获
+type Authorizer func(c fiber.Ctx) EventAuthorizer
+
+// handlerOptions 订阅处理器选项
+type handlerOptions struct {
+	authorizer Authorizer
+	heartbeat  time.Duration
+	upgrader   websocket.FastHTTPUpgrader
+}
+
+// HandlerOption 订阅处理器选项函数
+type HandlerOption func(*handlerOptions)
+
+// WithAuthorizer 设置事件授权函数，默认为 OwnerAuthorizer
+func WithAuthorizer(authorizer Authorizer) HandlerOption {
+	return func(o *handlerOptions) {
+		o.authorizer = authorizer
+	}
+}
+
+// WithHeartbeat 设置心跳间隔
+func WithHeartbeat(heartbeat time.Duration) HandlerOption {
+	return func(o *handlerOptions) {
+		if heartbeat > 0 {
+			o.heartbeat = heartbeat
+		}
+	}
+}
+
+// WithCheckOrigin 设置WebSocket握手时的来源校验函数，默认只允许同源
+func WithCheckOrigin(check func(ctx *fasthttp.RequestCtx) bool) HandlerOption {
+	return func(o *handlerOptions) {
+		o.upgrader.CheckOrigin = check
+	}
+}
+
+// newHandlerOptions 应用选项
+func newHandlerOptions(opts []HandlerOption) *handlerOptions {
+	o := &handlerOptions{
+		authorizer: OwnerAuthorizer(),
+		heartbeat:  15 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// subscribe 解析请求参数并建立订阅
+func subscribe(c fiber.Ctx, broker *Broker, o *handlerOptions, lastID uint64) (*Subscription, []Event, bool, EventAuthorizer, error) {
+	select {
+	case <-broker.Done():
+		return nil, nil, false, nil, fiber.NewError(fiber.StatusServiceUnavailable, "服务正在停止")
+	default:
+	}
+
+	allow := o.authorizer(c)
+	if allow == nil {
+		return nil, nil, false, nil, fiber.ErrForbidden
+	}
+
+	filter := Filter{}
+	if types := c.Query("types"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				filter.Entities = append(filter.Entities, t)
+			}
+		}
+	}
+
+	sub, backlog, complete := broker.Subscribe(filter, lastID)
+	return sub, backlog, complete, allow, nil
+}
+
+// parseLastEventID 解析续传的事件ID
+func parseLastEventID(values ...string) uint64 {
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		if id, err := strconv.ParseUint(v, 10, 64); err == nil {
+			return id
+		}
+	}
+	return 0
+}
+
+// SSEHandler 返回以 Server-Sent Events 推送变更事件的处理器
+// 支持 types 查询参数按实体类型过滤，支持 Last-Event-ID 请求头断线续传；
+// 事件日志已无法覆盖续传位置时先发送 reset 事件，客户端应重新拉取全量数据
+func SSEHandler(broker *Broker, opts ...HandlerOption) fiber.Handler {
+	o := newHandlerOptions(opts)
+
+	return func(c fiber.Ctx) error {
+		lastID := parseLastEventID(c.Get(HeaderLastEventID), c.Query("last_event_id"))
+		sub, backlog, complete, allow, err := subscribe(c, broker, o, lastID)
+		if err != nil {
+			return err
+		}
+
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+		c.Set(fiber.HeaderConnection, "keep-alive")
+		c.Set("X-Accel-Buffering", "no")
+
+		// 服务端写超时会中断长连接，每次写入前按心跳间隔延长写超时
+		netConn := c.RequestCtx().Conn()
+		log := logger.Ctx(c.Context())
+		heartbeat := o.heartbeat
+
+		return c.SendStreamWriter(func(w *bufio.Writer) {
+			defer sub.Close()
+
+			flush := func() bool {
+				_ = netConn.SetWriteDeadline(time.Now().Add(2 * heartbeat))
+				return w.Flush() == nil
+			}
+
+			fmt.Fprintf(w, "retry: %d\n\n", (3 * time.Second).Milliseconds())
+			if !complete {
+				fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+			}
+			for _, e := range backlog {
+				if allow(e) {
+					writeSSEEvent(w, log, e)
+				}
+			}
+			if !flush() {
+				return
+			}
+
+			ticker := time.NewTicker(heartbeat)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-broker.Done():
+					// 客户端按 retry 间隔重连到其他实例并通过 Last-Event-ID 续传
+					return
+				case e, ok := <-sub.C:
+					if !ok {
+						return
+					}
+					if !allow(e) {
+						continue
+					}
+					writeSSEEvent(w, log, e)
+				case <-ticker.C:
+					fmt.Fprint(w, ": ping\n\n")
+				}
+				if !flush() {
+					return
+				}
+			}
+		})
+	}
+}
+
+// writeSSEEvent 按SSE格式写入事件
+func writeSSEEvent(w *bufio.Writer, log *zap.Logger, e Event) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		log.Error("序列化变更事件失败", zap.Uint64("id", e.ID), zap.Error(err))
+		return
+	}
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+}
+
+// WebSocketHandler 返回以 WebSocket 推送变更事件的处理器
+// 浏览器无法为WebSocket设置请求头，续传位置通过 last_event_id 查询参数传递
+func WebSocketHandler(broker *Broker, opts ...HandlerOption) fiber.Handler {
+	o := newHandlerOptions(opts)
+
+	return func(c fiber.Ctx) error {
+		if !websocket.FastHTTPIsWebSocketUpgrade(c.RequestCtx()) {
+			return fiber.ErrUpgradeRequired
+		}
+
+		lastID := parseLastEventID(c.Query("last_event_id"))
+		sub, backlog, complete, allow, err := subscribe(c, broker, o, lastID)
+		if err != nil {
+			return err
+		}
+
+		heartbeat := o.heartbeat
+		err = o.upgrader.Upgrade(c.RequestCtx(), func(ws *websocket.Conn) {
+			defer sub.Close()
+			defer ws.Close()
+
+			// 读取循环用于处理控制帧并感知客户端断开
+			closed := make(chan struct{})
+			go func() {
+				defer close(closed)
+				for {
+					if _, _, err := ws.ReadMessage(); err != nil {
+						return
+					}
+				}
+			}()
+
+			send := func(v any) bool {
+				_ = ws.SetWriteDeadline(time.Now().Add(2 * heartbeat))
+				return ws.WriteJSON(v) == nil
+			}
+
+			if !complete && !send(fiber.Map{"type": "reset"}) {
+				return
+			}
+			for _, e := range backlog {
+				if allow(e) && !send(e) {
+					return
+				}
+			}
+
+			ticker := time.NewTicker(heartbeat)
+			defer ticker.Stop()
+
+			goingAway := func() {
+				_ = ws.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(time.Second))
+			}
+
+			for {
+				select {
+				case <-closed:
+					return
+				case <-broker.Done():
+					goingAway()
+					return
+				case e, ok := <-sub.C:
+					if !ok {
+						goingAway()
+						return
+					}
+					if allow(e) && !send(e) {
+						return
+					}
+				case <-ticker.C:
+					if err := ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(heartbeat)); err != nil {
+						return
+					}
+				}
+			}
+		})
+		if err != nil {
+			sub.Close()
+			logger.Ctx(c.Context()).Warn("WebSocket握手失败", zap.Error(err))
+		}
+
+		return nil
+	}
+}
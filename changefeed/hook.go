@@ -0,0 +1,152 @@
+package changefeed
+
+import (
+	"context"
+	"strings"
+
+	"doghole/ent"
+	"doghole/logger"
+	"go.uber.org/zap"
+)
+
+// Hook 返回发布实体变更事件的ent钩子
+// 在事务中执行的变更会在事务提交后才发布，回滚的变更不会被发布
+func Hook(broker *Broker) ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			op := mutationOp(m.Op())
+			if op == "" {
+				return next.Mutate(ctx, m)
+			}
+
+			// 批量更新和删除执行后无法获取受影响的ID，需要提前查询
+			var ids []int
+			if m.Op().Is(ent.OpUpdate | ent.OpDelete | ent.OpDeleteOne) {
+				if idm, ok := m.(interface {
+					IDs(context.Context) ([]int, error)
+				}); ok {
+					var err error
+					if ids, err = idm.IDs(ctx); err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			value, err := next.Mutate(ctx, m)
+			if err != nil {
+				return value, err
+			}
+			// 批量更新和删除返回受影响的行数，没有影响任何行时不发布事件
+			if n, ok := value.(int); ok && n == 0 {
+				return value, nil
+			}
+
+			events := buildEvents(ctx, m, op, value, ids)
+			publish := func() {
+				for _, e := range events {
+					broker.Publish(e)
+				}
+			}
+
+			if txm, ok := m.(interface{ Tx() (*ent.Tx, error) }); ok {
+				if tx, err := txm.Tx(); err == nil {
+					tx.OnCommit(func(next ent.Committer) ent.Committer {
+						return ent.CommitFunc(func(ctx context.Context, tx *ent.Tx) error {
+							if err := next.Commit(ctx, tx); err != nil {
+								return err
+							}
+							publish()
+							return nil
+						})
+					})
+					return value, nil
+				}
+			}
+
+			publish()
+			return value, nil
+		})
+	}
+}
+
+// internalEntities 框架内部使用的实体，其变更始终不发布事件
+// Webhook投递记录、任务、发件箱等在处理变更事件的过程中被写入，发布它们的变更会形成无限循环，
+// 证书、限流和幂等键等记录也不应推送给订阅者
+var internalEntities = Filter{Entities: []string{
+	"WebhookEndpoint",
+	"WebhookDelivery",
+	"Job",
+	"Outbox",
+	"IdempotencyKey",
+	"RateLimitBucket",
+	"ScheduledRun",
+	"CertCache",
+}}
+
+// Register 为客户端注册变更事件钩子
+// entities 为需要发布变更事件的实体类型，为空时发布除内部实体外所有实体的变更
+func Register(client *ent.Client, broker *Broker, entities ...string) {
+	filter := Filter{Entities: entities}
+	hook := Hook(broker)
+	client.Use(func(next ent.Mutator) ent.Mutator {
+		hooked := hook(next)
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			e := Event{Entity: m.Type()}
+			if internalEntities.Match(e) || !filter.Match(e) {
+				return next.Mutate(ctx, m)
+			}
+			return hooked.Mutate(ctx, m)
+		})
+	})
+}
+
+// buildEvents 根据变更结果构造事件
+func buildEvents(ctx context.Context, m ent.Mutation, op string, value ent.Value, ids []int) []Event {
+	eventType := strings.ToLower(m.Type()) + "." + op + "d"
+
+	if len(ids) > 0 {
+		events := make([]Event, 0, len(ids))
+		for _, id := range ids {
+			events = append(events, Event{
+				Type:     eventType,
+				Entity:   m.Type(),
+				Op:       op,
+				EntityID: id,
+			})
+		}
+		return events
+	}
+
+	e := Event{
+		Type:   eventType,
+		Entity: m.Type(),
+		Op:     op,
+	}
+	if op != OpDelete {
+		e.Data = value
+	}
+	if idm, ok := m.(interface{ ID() (int, bool) }); ok {
+		if id, exists := idm.ID(); exists {
+			e.EntityID = id
+		}
+	}
+	if e.EntityID == nil {
+		logger.Ctx(ctx).Debug("变更事件缺少实体ID", zap.String("entity", m.Type()), zap.String("op", op))
+	}
+
+	return []Event{e}
+}
+
+// mutationOp 将ent操作转换为事件操作类型
+func mutationOp(op ent.Op) string {
+	switch {
+	case op.Is(ent.OpCreate):
+		return OpCreate
+	case op.Is(ent.OpUpdate | ent.OpUpdateOne):
+		return OpUpdate
+	case op.Is(ent.OpDelete | ent.OpDeleteOne):
+		return OpDelete
+	default:
+		return ""
+	}
+}
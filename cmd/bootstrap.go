@@ -0,0 +1,406 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"doghole/admin"
+	"doghole/config"
+	"doghole/domain/avatar"
+	"doghole/domain/conn"
+	"doghole/domain/file"
+	"doghole/domain/notification"
+	"doghole/domain/webhook"
+	"doghole/eventbus"
+	"doghole/featureflag"
+	"doghole/health"
+	"doghole/lifecycle"
+	"doghole/logger"
+	"doghole/metrics"
+	"doghole/prefork"
+	"doghole/querycache"
+	"doghole/storage"
+	"doghole/tracing"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+// loadConfig 加载配置文件并设置为全局配置，失败时退出进程
+func loadConfig(filename string) *config.Config {
+	conf := config.NewConfig()
+	config.SetGlobalConfig(conf)
+
+	if err := conf.LoadSingleConfigFile(filename); err != nil {
+		fmt.Printf("加载配置文件失败: %s\n", err)
+		os.Exit(1)
+	}
+
+	return conf
+}
+
+// overrideConfig 返回应用了覆盖项的配置副本，用于命令行参数和预分叉子进程的覆盖
+// 已发布的全局配置会被其他goroutine并发读取（如管理端口的配置接口），不能直接修改；
+// 副本与全局配置共享切片和映射，fn 只能整体替换字段，不能原地修改其中的元素
+func overrideConfig(conf *config.Config, fn func(c *config.Config)) *config.Config {
+	copied := *conf
+	fn(&copied)
+	return &copied
+}
+
+// initLogger 初始化日志系统，失败时退出进程，需在创建应用前调用
+// 预分叉的子进程将日志写到标准输出，由主进程按行写入日志文件，避免多个进程同时写入和轮转同一个文件
+func initLogger(conf *config.Config) {
+	opts := []logger.Option{
+		logger.WithLevel(conf.Logger.Level),
+		logger.WithFormat(conf.Logger.Format),
+		logger.WithOutputPath(conf.Logger.Outfile),
+		logger.WithRotation(conf.Logger.ChuckSize, 3, 7, true),
+		logger.WithDevelopment(conf.Logger.Level == "debug"),
+		logger.WithField("app", "doghole"),
+	}
+	if prefork.IsChild() {
+		opts = append(opts,
+			logger.WithOutputPath("stdout"),
+			logger.WithField("pid", strconv.Itoa(os.Getpid())),
+		)
+	}
+
+	err := logger.Init(opts...)
+	if err != nil {
+		fmt.Printf("初始化日志系统失败: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// initDatabase 初始化数据库连接并创建数据库架构
+func initDatabase(ctx context.Context, conf *config.Config) error {
+	if conf.DB.DB != nil {
+		// 单一数据库配置
+		if conf.DB.DB.ToDialect() == "" || conf.DB.DB.ToDNS() == "" {
+			return errors.New("数据库配置错误: 数据库配置不能为空")
+		}
+
+		db, err := conn.Open(conf.DB.DB.ToDialect(), conf.DB.DB.ToDNS())
+		if err != nil {
+			return errors.Wrap(err, "连接数据库失败")
+		}
+
+		// 设置共享连接
+		if err := conn.InitializeDB(ctx, conf.DB.DB.ToDialect(), db, db, driverWrappers(conf)...); err != nil {
+			db.Close()
+			return errors.Wrap(err, "初始化数据库连接失败")
+		}
+	} else {
+		// 读写分离配置
+		if conf.DB.WriteDB == nil || conf.DB.ReadDB == nil {
+			return errors.New("数据库配置错误: 必须至少提供一个读或写数据库配置")
+		}
+
+		// 初始化读取连接
+		reader, err := conn.Open(conf.DB.ReadDB.ToDialect(), conf.DB.ReadDB.ToDNS())
+		if err != nil {
+			return errors.Wrap(err, "连接读取数据库失败")
+		}
+
+		// 初始化写入连接
+		writer, err := conn.Open(conf.DB.WriteDB.ToDialect(), conf.DB.WriteDB.ToDNS())
+		if err != nil {
+			reader.Close()
+			return errors.Wrap(err, "连接写入数据库失败")
+		}
+
+		// 初始化连接管理器
+		if err := conn.InitializeDB(ctx, conf.DB.WriteDB.ToDialect(), writer, reader, driverWrappers(conf)...); err != nil {
+			writer.Close()
+			reader.Close()
+			return errors.Wrap(err, "初始化数据库连接失败")
+		}
+	}
+
+	if err := registerDBMetrics(conf); err != nil {
+		return err
+	}
+
+	// 预分叉时由主进程在启动子进程前迁移，子进程不再重复修改数据库架构
+	if prefork.IsChild() {
+		return nil
+	}
+
+	// 创建数据库架构
+	return conn.Migrate(ctx)
+}
+
+// driverWrappers 返回按配置启用的ent驱动包装
+func driverWrappers(conf *config.Config) []conn.DriverWrapper {
+	var wrappers []conn.DriverWrapper
+	if conf.Metrics.Enabled {
+		wrappers = append(wrappers, metrics.Driver)
+	}
+	if conf.Tracing.Enabled {
+		wrappers = append(wrappers, tracing.Driver)
+	}
+	return wrappers
+}
+
+// registerDBMetrics 注册读写连接池的统计指标，读写共用连接时只注册一次
+func registerDBMetrics(conf *config.Config) error {
+	if !conf.Metrics.Enabled {
+		return nil
+	}
+	if err := metrics.RegisterDB("writer", conn.WriteDB()); err != nil {
+		return errors.Wrap(err, "注册写入连接池指标失败")
+	}
+	if conn.ReadDB() != conn.WriteDB() {
+		if err := metrics.RegisterDB("reader", conn.ReadDB()); err != nil {
+			return errors.Wrap(err, "注册读取连接池指标失败")
+		}
+	}
+	return nil
+}
+
+// initQueryCache 在读连接上挂载查询缓存拦截器，在写连接上挂载缓存失效钩子
+// 只做写入的进程同样需要调用，以便使其他实例的Redis缓存失效
+func initQueryCache(conf *config.Config) error {
+	if !conf.QueryCache.Enabled {
+		return nil
+	}
+
+	var backend querycache.Backend
+	switch conf.QueryCache.Backend {
+	case "", "memory":
+		backend = querycache.NewMemoryBackend(conf.QueryCache.MaxEntries)
+	case "redis":
+		client := redis.NewClient(&redis.Options{
+			Addr:     conf.QueryCache.Redis.Addr,
+			Username: conf.QueryCache.Redis.Username,
+			Password: conf.QueryCache.Redis.Password,
+			DB:       conf.QueryCache.Redis.DB,
+		})
+		backend = querycache.NewRedisBackend(client, conf.QueryCache.Redis.KeyPrefix)
+	default:
+		return errors.Errorf("查询缓存配置错误: 不支持的存储后端 %s", conf.QueryCache.Backend)
+	}
+
+	cache := querycache.New(backend,
+		querycache.WithTTL(conf.QueryCache.TTL),
+		querycache.WithLogger(zap.L()),
+	)
+	conn.Reader().Intercept(cache.Interceptor())
+	conn.Writer().Use(cache.Hook())
+	return nil
+}
+
+// initFeatureFlags 初始化全局功能开关，配置文件变更时重新加载
+// 返回的 Store 需要运行 Run 定期轮询数据库覆盖
+func initFeatureFlags(ctx context.Context, conf *config.Config) *featureflag.Store {
+	store := featureflag.NewStore(conn.Writer(), conf.FeatureFlag, featureflag.WithLogger(zap.L()))
+	if err := store.Refresh(ctx); err != nil {
+		zap.L().Error("加载功能开关失败", zap.Error(err))
+	}
+	featureflag.SetDefault(store)
+
+	config.OnChange(func(c *config.Config) {
+		store.Reload(c.FeatureFlag)
+	})
+	return store
+}
+
+// initStorage 初始化文件存储，以及全局文件服务和头像处理流水线
+func initStorage(conf *config.Config) error {
+	if !conf.Storage.Enabled && !conf.Avatar.Enabled {
+		return nil
+	}
+
+	store, err := storage.New(conf.Storage)
+	if err != nil {
+		return errors.Wrap(err, "初始化文件存储失败")
+	}
+
+	if conf.Storage.Enabled {
+		svc, err := file.NewService(conn.Writer(), store, conf.Storage, file.WithLogger(zap.L()))
+		if err != nil {
+			return errors.Wrap(err, "初始化文件服务失败")
+		}
+		file.SetDefault(svc)
+	}
+
+	if conf.Avatar.Enabled {
+		avatar.SetDefault(avatar.NewPipeline(store, conf.Avatar, avatar.WithLogger(zap.L())))
+	}
+	return nil
+}
+
+// initNotifications 初始化全局通知中心
+func initNotifications(conf *config.Config) error {
+	if !conf.Notification.Enabled {
+		return nil
+	}
+
+	center, err := notification.NewCenter(conn.Writer(), conf.Notification, notification.WithLogger(zap.L()))
+	if err != nil {
+		return errors.Wrap(err, "初始化通知中心失败")
+	}
+	notification.SetDefault(center)
+	// 其他模块通过事件总线发布 notification.Message 发送通知
+	center.Subscribe(eventbus.Default())
+	return nil
+}
+
+// addCoreComponents 注册服务器和工作进程共用的组件，按依赖顺序排列：
+// 日志、链路追踪、管理端口、数据库、查询缓存、功能开关、文件存储、通知中心、异步事件
+// 停止时按相反顺序，先等待异步事件处理完成，最后关闭数据库连接、管理端口并同步日志
+func addCoreComponents(app *lifecycle.App, conf *config.Config) {
+	app.Add(lifecycle.Component{
+		Name: "logger",
+		Stop: func(ctx context.Context) error {
+			logger.Sync()
+			return nil
+		},
+	})
+
+	// 链路追踪先于其他组件启动、在其之后停止，以便导出停止过程中产生的span
+	if conf.Tracing.Enabled {
+		var provider *sdktrace.TracerProvider
+		app.Add(lifecycle.Component{
+			Name: "tracing",
+			Start: func(ctx context.Context) (err error) {
+				provider, err = tracing.Setup(ctx, conf.Tracing, tracing.WithServiceVersion(Version))
+				return err
+			},
+			Stop: func(ctx context.Context) error {
+				return provider.Shutdown(ctx)
+			},
+		})
+	}
+
+	registerHealthChecks(app, conf)
+
+	// 管理端口在其他组件之前启动，便于排查启动过程中的问题
+	if conf.Admin.Enabled {
+		var srv *admin.Server
+		app.Add(lifecycle.Component{
+			Name: "admin",
+			Start: func(ctx context.Context) (err error) {
+				srv, err = admin.New(conf.Admin,
+					admin.WithLogger(zap.L()),
+					admin.WithBuildInfo(admin.BuildInfo{Version: Version, Commit: CommitHash, BuildTime: BuildTime}),
+				)
+				if err != nil {
+					return err
+				}
+				if conf.Metrics.Enabled {
+					srv.App().Get(conf.Metrics.Path, metrics.Handler())
+				}
+				// 系统订阅接收所有变更事件，只能在管理端口上管理
+				if conf.Webhook.Enabled {
+					webhook.RegisterAdminRoutes(srv.App().Group("/webhooks"))
+				}
+				// 开关规则包含定向名单和未上线功能，修改会影响所有用户，只能在管理端口上查询和修改
+				if conf.FeatureFlag.Enabled {
+					featureflag.RegisterAdminRoutes(srv.App().Group("/feature-flags"))
+				}
+				return nil
+			},
+			Run: func(ctx context.Context) error {
+				return srv.Start()
+			},
+			Stop: func(ctx context.Context) error {
+				return srv.Shutdown(ctx)
+			},
+		})
+	} else if conf.Metrics.Enabled {
+		zap.L().Warn("已启用指标但未启用管理端口，指标不会对外暴露")
+	}
+
+	var flags *featureflag.Store
+	app.Add([]lifecycle.Component{
+		{
+			Name:         "database",
+			Start:        func(ctx context.Context) error { return initDatabase(ctx, conf) },
+			Stop:         func(ctx context.Context) error { conn.Close(); return nil },
+			StartTimeout: 2 * time.Minute, // 包括创建数据库架构
+		},
+		{
+			Name:  "querycache",
+			Start: func(ctx context.Context) error { return initQueryCache(conf) },
+		},
+		{
+			Name: "featureflag",
+			Start: func(ctx context.Context) error {
+				flags = initFeatureFlags(ctx, conf)
+				return nil
+			},
+			Run: func(ctx context.Context) error {
+				// 未启用数据库覆盖时 Run 立即返回，等待停止以免被视为意外退出
+				flags.Run(ctx)
+				<-ctx.Done()
+				return nil
+			},
+		},
+		{
+			Name:  "storage",
+			Start: func(ctx context.Context) error { return initStorage(conf) },
+		},
+		{
+			Name:  "notification",
+			Start: func(ctx context.Context) error { return initNotifications(conf) },
+		},
+		{
+			// 等待请求或任务中发布的异步事件处理完成，需在其依赖的数据库关闭前停止
+			Name: "eventbus",
+			Stop: func(ctx context.Context) error { return eventbus.Default().Wait(ctx) },
+		},
+	}...)
+}
+
+// registerHealthChecks 注册内置的健康检查：读写数据库连接、数据库架构迁移和日志目录的磁盘空间
+// 启动完成前启动探针和就绪探针失败，收到退出信号后就绪探针立即失败
+func registerHealthChecks(app *lifecycle.App, conf *config.Config) {
+	registry := health.Default()
+	registry.Configure(conf.Health)
+	registry.SetLifecycle(app.Started, app.Ready)
+
+	registry.Register(health.Check{Name: "database.writer", Checker: health.Ping(conn.WriteDB)})
+	registry.Register(health.Check{Name: "database.reader", Checker: health.Ping(conn.ReadDB)})
+	registry.Register(health.Check{
+		Name: "database.migration",
+		Checker: health.CheckerFunc(func(ctx context.Context) error {
+			migratedAt, err := conn.MigrationStatus()
+			if err != nil {
+				return err
+			}
+			if migratedAt.IsZero() {
+				return errors.New("数据库架构尚未创建")
+			}
+			return nil
+		}),
+		Kinds: []health.Kind{health.Startup, health.Readiness},
+	})
+
+	if conf.Logger.Outfile != "" && conf.Health.DiskMinFree > 0 {
+		registry.Register(health.Check{
+			Name:    "disk.logs",
+			Checker: health.DiskSpace(filepath.Dir(conf.Logger.Outfile), uint64(conf.Health.DiskMinFree)),
+		})
+	}
+}
+
+// runApp 运行应用直到收到退出信号，启动、运行或停止失败时记录错误并以非零状态退出
+func runApp(app *lifecycle.App) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := app.Run(ctx); err != nil {
+		zap.L().Error("应用异常退出", zap.Error(err))
+		logger.Sync()
+		os.Exit(1)
+	}
+}
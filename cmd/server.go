@@ -2,99 +2,101 @@ package cmd
 
 import (
 	"context"
-	"fmt"
 	"os"
+	"time"
 
+	"doghole/changefeed"
 	"doghole/config"
 	"doghole/domain/conn"
-	"doghole/ent"
+	"doghole/domain/webhook"
+	"doghole/lifecycle"
+	"doghole/listener"
 	"doghole/logger"
+	"doghole/prefork"
 	"doghole/server"
+	"github.com/gofiber/fiber/v3"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
 
 var _config *string
 
+// Authenticator 公开端口的认证中间件，由部署方在调用 Execute 前设置，约定见 server.WithAuth
+// 未设置时所有请求均为匿名请求
+var Authenticator fiber.Handler
+
 var serverCmd = &cobra.Command{
 	Use:   "server",
 	Short: "启动HTTP服务器",
 	Long:  `此命令启动Doghole HTTP服务器，该服务器侦听传入请求并根据配置的设置处理它们。`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// 加载配置文件
-		conf := config.NewConfig()
-		config.SetGlobalConfig(conf)
-
-		if err := conf.LoadSingleConfigFile(*_config); err != nil {
-			fmt.Printf("加载配置文件失败: %s\n", err)
-			os.Exit(1)
-		}
+		conf := loadConfig(*_config)
 
 		// 初始化日志系统
-		err := logger.Init(
-			logger.WithLevel(conf.Logger.Level),
-			logger.WithFormat(conf.Logger.Format),
-			logger.WithOutputPath(conf.Logger.Outfile),
-			logger.WithRotation(conf.Logger.ChuckSize, 3, 7, true),
-			logger.WithDevelopment(conf.Logger.Level == "debug"),
-			logger.WithField("app", "doghole"),
-		)
-		if err != nil {
-			fmt.Printf("初始化日志系统失败: %s\n", err)
-			os.Exit(1)
-		}
+		initLogger(conf)
 
-		// 确保在程序退出时正确关闭资源
-		defer func() {
-			conn.Close()
-			logger.Sync()
-		}()
-
-		// 初始化数据库连接
-		ctx := context.Background()
+		if addr, _ := cmd.Flags().GetString("admin-addr"); addr != "" {
+			conf = overrideConfig(conf, func(c *config.Config) { c.Admin.Addr = addr })
+		}
 
-		if conf.DB.DB != nil {
-			// 单一数据库配置
-			if conf.DB.DB.ToDialect() == "" || conf.DB.DB.ToDNS() == "" {
-				zap.L().Fatal("数据库配置错误", zap.String("error", "数据库配置不能为空"))
-			}
+		addrs := conf.ListenAddrs()
+		if listen, _ := cmd.Flags().GetStringSlice("listen"); len(listen) > 0 {
+			addrs = listen
+		}
 
-			db, err := ent.Open(conf.DB.DB.ToDialect(), conf.DB.DB.ToDNS())
-			if err != nil {
-				zap.L().Fatal("连接数据库失败", zap.Error(err))
-			}
+		// 预分叉模式下由主进程监听地址，子进程各自初始化所有组件并处理请求
+		if conf.Server.EnablePrefork && !prefork.IsChild() {
+			runPreforkMaster(conf, addrs)
+			return
+		}
+		if prefork.IsChild() && prefork.ChildID() > 0 {
+			// 管理端口只由第一个子进程监听
+			conf = overrideConfig(conf, func(c *config.Config) { c.Admin.Enabled = false })
+		}
 
-			// 设置共享连接
-			if err := conn.Initialize(ctx, db, db); err != nil {
-				zap.L().Fatal("初始化数据库连接失败", zap.Error(err))
-			}
-		} else {
-			// 读写分离配置
-			if conf.DB.WriteDB == nil || conf.DB.ReadDB == nil {
-				zap.L().Fatal("数据库配置错误", zap.String("error", "必须至少提供一个读或写数据库配置"))
-			}
+		app := lifecycle.New(lifecycle.WithLogger(zap.L()))
+		addCoreComponents(app, conf)
 
-			// 初始化读取连接
-			reader, err := ent.Open(conf.DB.ReadDB.ToDialect(), conf.DB.ReadDB.ToDNS())
-			if err != nil {
-				zap.L().Fatal("连接读取数据库失败", zap.Error(err))
-			}
-
-			// 初始化写入连接
-			writer, err := ent.Open(conf.DB.WriteDB.ToDialect(), conf.DB.WriteDB.ToDNS())
-			if err != nil {
-				zap.L().Fatal("连接写入数据库失败", zap.Error(err))
-			}
+		// 主进程退出后子进程随之退出
+		if prefork.IsChild() {
+			app.Add(lifecycle.Component{Name: "prefork", Run: prefork.WatchMaster})
+		}
 
-			// 初始化连接管理器
-			if err := conn.Initialize(ctx, writer, reader); err != nil {
-				zap.L().Fatal("初始化数据库连接失败", zap.Error(err))
-			}
+		// 注册变更事件钩子，Webhook同样以变更事件为事件源
+		if conf.ChangeFeed.Enabled || conf.Webhook.Enabled {
+			app.Add(lifecycle.Component{
+				Name: "changefeed",
+				Start: func(ctx context.Context) error {
+					broker := changefeed.NewBroker(conf.ChangeFeed.LogSize, conf.ChangeFeed.BufferSize)
+					changefeed.SetDefault(broker)
+					changefeed.Register(conn.Writer(), broker, conf.ChangeFeed.Entities...)
+					// 订阅者只能收到归属于自己或所在租户的事件，新增推送的实体时需注册其归属
+					changefeed.RegisterOwnership("User", changefeed.SelfOwnership)
+					return nil
+				},
+				// HTTP服务器停止时等待处理中的请求，推送长连接需要在此之前断开
+				PreStop: func(ctx context.Context) error {
+					changefeed.Default().Shutdown()
+					return nil
+				},
+				Stop: func(ctx context.Context) error {
+					changefeed.Default().Close()
+					return nil
+				},
+			})
 		}
 
-		// 创建数据库架构
-		if err := conn.Writer().Schema.Create(ctx); err != nil {
-			zap.L().Fatal("创建数据库架构失败", zap.Error(err))
+		// 启动Webhook投递器
+		if conf.Webhook.Enabled {
+			app.Add(lifecycle.Component{
+				Name: "webhook",
+				Run: func(ctx context.Context) error {
+					dispatcher := webhook.NewDispatcher(conn.Writer(), conf.Webhook, webhook.WithLogger(zap.L()))
+					dispatcher.Run(ctx, changefeed.Default())
+					return nil
+				},
+			})
 		}
 
 		// 创建服务器
@@ -104,21 +106,124 @@ var serverCmd = &cobra.Command{
 			IdleTimeout:       conf.Server.IdleTimeout,
 			ShutdownTimeout:   conf.Server.ShutdownTimeout,
 			EnableCompression: conf.Server.EnableCompression,
+			Compression:       conf.Server.Compression,
 			EnablePrefork:     conf.Server.EnablePrefork,
+			BodyLimit:         conf.Server.BodyLimit,
+			Routes:            conf.Server.Routes,
+			UnixSocket:        conf.Server.UnixSocket,
+			TLS:               conf.Server.TLS,
 		}
 
-		// 使用选项模式创建服务器
-		srv := server.NewServer(
-			server.WithConfig(serverConfig),
-			server.WithLogger(zap.L()),
-		)
+		// HTTP服务器最后启动、最先停止，停止时等待处理中的请求完成
+		var srv *server.Server
+		app.Add(lifecycle.Component{
+			Name: "http",
+			Start: func(ctx context.Context) (err error) {
+				// 使用选项模式创建服务器
+				srv, err = server.NewServer(
+					server.WithConfig(serverConfig),
+					server.WithLogger(zap.L()),
+					server.WithAuth(Authenticator),
+				)
+				return err
+			},
+			Run: func(ctx context.Context) error {
+				return srv.Start(addrs...)
+			},
+			Stop: func(ctx context.Context) error {
+				return srv.Shutdown(ctx)
+			},
+			StopTimeout: conf.Server.ShutdownTimeout,
+		})
+
+		runApp(app)
+	},
+}
 
-		// 启动服务器
-		zap.L().Info("服务器已启动", zap.Int("port", conf.Server.Port))
-		if err := srv.Start(conf.ToPort()); err != nil {
-			zap.L().Fatal("服务器启动失败", zap.Error(err))
+// runPreforkMaster 运行预分叉主进程：完成数据库架构迁移后关闭连接，监听所有地址并启动子进程
+// 子进程启动后各自打开数据库连接池，主进程只负责将子进程的日志写入日志文件和协调退出
+func runPreforkMaster(conf *config.Config, addrs []string) {
+	if conflicts := preforkConflicts(conf); len(conflicts) > 0 {
+		for _, conflict := range conflicts {
+			zap.L().Error(conflict)
 		}
-	},
+		logger.Sync()
+		os.Exit(1)
+	}
+	if conf.Server.TLS.Enabled && conf.Server.TLS.ACME.Enabled {
+		zap.L().Warn("预分叉模式下每个子进程各自管理ACME证书，缓存中没有证书或需要续期时可能重复申请，" +
+			"请确保证书缓存可被所有子进程共享，并注意证书颁发机构的频率限制")
+	}
+
+	// 等待子进程退出的时间，包括关闭HTTP服务器和其他组件
+	childStopTimeout := conf.Server.ShutdownTimeout + 30*time.Second
+
+	app := lifecycle.New(lifecycle.WithLogger(zap.L()))
+	app.Add(lifecycle.Component{
+		Name: "logger",
+		Stop: func(ctx context.Context) error {
+			logger.Sync()
+			return nil
+		},
+	})
+	app.Add(lifecycle.Component{
+		// 在子进程启动前迁移，避免多个子进程同时修改数据库架构
+		Name: "database",
+		Start: func(ctx context.Context) error {
+			if err := initDatabase(ctx, conf); err != nil {
+				return err
+			}
+			conn.Close()
+			return nil
+		},
+		StartTimeout: 2 * time.Minute,
+	})
+
+	var master *prefork.Master
+	app.Add(lifecycle.Component{
+		Name: "prefork",
+		Start: func(ctx context.Context) error {
+			mode, err := listener.ParseMode(conf.Server.UnixSocket.Mode)
+			if err != nil {
+				return err
+			}
+			lns, err := listener.ListenAll(addrs, listener.UnixOptions{Mode: mode, Group: conf.Server.UnixSocket.Group})
+			if err != nil {
+				return err
+			}
+			master = prefork.NewMaster(lns,
+				prefork.WithChildren(conf.Server.PreforkChildren),
+				prefork.WithOutput(logger.Output()),
+				prefork.WithStopTimeout(childStopTimeout),
+				prefork.WithLogger(zap.L()),
+			)
+			return nil
+		},
+		Run: func(ctx context.Context) error {
+			return master.Run(ctx)
+		},
+		StopTimeout: childStopTimeout + 5*time.Second,
+	})
+
+	runApp(app)
+}
+
+// preforkConflicts 返回与预分叉模式冲突的配置，子进程之间不共享内存，进程内的状态在子进程间不一致
+func preforkConflicts(conf *config.Config) []string {
+	var conflicts []string
+	if conf.Server.TLS.Enabled && conf.Server.TLS.ACME.Enabled && conf.Server.TLS.ACME.HTTPAddr != "" {
+		conflicts = append(conflicts, "预分叉模式不支持 acme.http_addr，多个子进程无法同时监听，请使用 TLS-ALPN-01 验证")
+	}
+	if conf.QueryCache.Enabled && conf.QueryCache.Backend != "redis" {
+		conflicts = append(conflicts, "预分叉模式下进程内查询缓存无法在子进程间失效，请将 query_cache.backend 设为 redis")
+	}
+	if conf.RateLimit.Enabled && conf.RateLimit.Store != "sql" {
+		conflicts = append(conflicts, "预分叉模式下每个子进程各自计数，实际限额会成倍放大，请将 rate_limit.store 设为 sql")
+	}
+	if conf.ChangeFeed.Enabled {
+		conflicts = append(conflicts, "预分叉模式不支持变更事件推送，订阅者只能收到所连接的子进程写入的变更，请关闭 change_feed")
+	}
+	return conflicts
 }
 
 func init() {
@@ -128,7 +233,9 @@ func init() {
 
 	// 添加其他可选标志
 	serverCmd.Flags().IntP("port", "p", 0, "服务器端口（覆盖配置文件）")
+	serverCmd.Flags().StringSlice("listen", nil, "监听地址，可重复指定（覆盖配置文件），如 --listen unix:/run/doghole.sock")
 	serverCmd.Flags().StringP("log-level", "l", "", "日志级别（覆盖配置文件）")
+	serverCmd.Flags().String("admin-addr", "", "管理端口监听地址（覆盖配置文件）")
 
 	// 添加服务器命令到根命令
 	rootCmd.AddCommand(serverCmd)
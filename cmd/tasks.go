@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+
+	"doghole/config"
+	"doghole/domain/avatar"
+	"doghole/domain/conn"
+	"doghole/domain/notification"
+	"doghole/jobqueue"
+	"doghole/ratelimit"
+	"doghole/scheduler"
+	"doghole/server"
+	"go.uber.org/zap"
+)
+
+// registerTasks 注册内置定时任务，执行计划在配置文件的 scheduler.tasks 中配置
+// 任务名称会作为配置键，不能包含 "."
+func registerTasks(conf *config.Config) {
+	// 清理过期的幂等键
+	scheduler.Register("purge_idempotency_keys", func(ctx context.Context) error {
+		n, err := server.PurgeExpiredIdempotencyKeys(ctx)
+		if err != nil {
+			return err
+		}
+		zap.L().Info("已清理过期幂等键", zap.Int("count", n))
+		return nil
+	})
+
+	// 清理过期的数据库限流状态
+	scheduler.Register("purge_rate_limits", func(ctx context.Context) error {
+		n, err := ratelimit.NewSQLStore(conn.Writer(), conf.DB.Dialect()).Purge(ctx)
+		if err != nil {
+			return err
+		}
+		zap.L().Info("已清理过期限流状态", zap.Int("count", n))
+		return nil
+	})
+
+	// 清理超过保留时长的成功任务和死信任务
+	scheduler.Register("purge_jobs", func(ctx context.Context) error {
+		jobs := config.GetGlobalConfig().JobQueue
+		n, err := jobqueue.Purge(ctx, conn.Writer(), jobs.SucceededRetention, jobs.DeadRetention)
+		if err != nil {
+			return err
+		}
+		zap.L().Info("已清理已完成任务", zap.Int("count", n))
+		return nil
+	})
+}
+
+// registerJobs 注册内置后台任务的处理函数
+func registerJobs(conf *config.Config) {
+	// 预生成头像缩略图
+	if conf.Avatar.Enabled {
+		jobqueue.Register(avatar.JobGenerateVariants, jobqueue.Handle(avatar.Default().GenerateJob))
+	}
+
+	// 发送通知邮件
+	if conf.Notification.Enabled {
+		jobqueue.Register(notification.JobSendEmail, jobqueue.Handle(notification.Default().SendEmail))
+	}
+}
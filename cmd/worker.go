@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+
+	"doghole/config"
+	"doghole/domain/conn"
+	"doghole/domain/webhook"
+	"doghole/jobqueue"
+	"doghole/lifecycle"
+	"doghole/outbox"
+	"doghole/scheduler"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var _workerConfig *string
+
+var workerCmd = &cobra.Command{
+	Use:   "worker",
+	Short: "启动后台任务工作进程",
+	Long:  `此命令启动Doghole后台任务工作进程，按配置的队列和并发数从数据库拉取并执行任务，启用调度器时同时按计划执行定时任务，启用发件箱时同时发布事务中写入的领域事件，与HTTP服务器分别部署。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 加载配置文件
+		conf := loadConfig(*_workerConfig)
+
+		// 初始化日志系统
+		initLogger(conf)
+
+		queues, _ := cmd.Flags().GetStringToInt("queues")
+		if len(queues) > 0 {
+			conf = overrideConfig(conf, func(c *config.Config) { c.JobQueue.Queues = queues })
+		}
+
+		if addr, _ := cmd.Flags().GetString("admin-addr"); addr != "" {
+			conf = overrideConfig(conf, func(c *config.Config) { c.Admin.Addr = addr })
+		}
+
+		app := lifecycle.New(lifecycle.WithLogger(zap.L()))
+		addCoreComponents(app, conf)
+
+		// 启动定时任务调度器，多实例部署时每个触发点只会由一个实例执行
+		if conf.Scheduler.Enabled {
+			var sched *scheduler.Scheduler
+			app.Add(lifecycle.Component{
+				Name: "scheduler",
+				Start: func(ctx context.Context) error {
+					registerTasks(conf)
+
+					sched = scheduler.NewScheduler(conn.Writer(), scheduler.WithLogger(zap.L()))
+					config.OnValidate(func(c *config.Config) error {
+						return scheduler.Validate(c.Scheduler)
+					})
+					config.OnChange(func(c *config.Config) {
+						sched.Reload(c.Scheduler)
+					})
+					return nil
+				},
+				Run: func(ctx context.Context) error {
+					return errors.Wrap(sched.Run(ctx, conf.Scheduler), "启动定时任务调度器失败")
+				},
+			})
+		}
+
+		// 启动发件箱中继
+		if conf.Outbox.Enabled {
+			app.Add(lifecycle.Component{
+				Name: "outbox",
+				Run: func(ctx context.Context) error {
+					relay := outbox.NewRelay(conn.Writer(), conf.Outbox, outboxPublisher(conf), outbox.WithLogger(zap.L()))
+					relay.Run(ctx)
+					return nil
+				},
+			})
+		}
+
+		// 收到退出信号后停止拉取新任务，在单个任务的执行超时内等待执行中的任务结束
+		if len(conf.JobQueue.Queues) > 0 {
+			var worker *jobqueue.Worker
+			app.Add(lifecycle.Component{
+				Name: "jobqueue",
+				Start: func(ctx context.Context) error {
+					registerJobs(conf)
+					worker = jobqueue.NewWorker(conn.Writer(), conf.JobQueue, conf.DB.Dialect(), jobqueue.WithLogger(zap.L()))
+					return nil
+				},
+				Run: func(ctx context.Context) error {
+					worker.Run(ctx)
+					return nil
+				},
+				StopTimeout: conf.JobQueue.VisibilityTimeout,
+			})
+		}
+
+		runApp(app)
+	},
+}
+
+func init() {
+	// 定义命令行标志
+	_workerConfig = workerCmd.Flags().StringP("config", "c", "config.yaml", "配置文件路径")
+	workerCmd.MarkFlagRequired("config") // 将config标志设为必需
+
+	// 添加其他可选标志
+	workerCmd.Flags().StringToInt("queues", nil, "要消费的队列及并发数（覆盖配置文件），如 default=5,mail=2")
+	workerCmd.Flags().String("admin-addr", "", "管理端口监听地址（覆盖配置文件）")
+
+	// 添加工作进程命令到根命令
+	rootCmd.AddCommand(workerCmd)
+}
+
+// outboxPublisher 创建发件箱事件的发布器
+// 启用Webhook时将领域事件转为Webhook投递，否则仅记录日志
+func outboxPublisher(conf *config.Config) outbox.Publisher {
+	if !conf.Webhook.Enabled {
+		return outbox.LogPublisher(zap.L())
+	}
+
+	dispatcher := webhook.NewDispatcher(conn.Writer(), conf.Webhook, webhook.WithLogger(zap.L()))
+	return outbox.PublisherFunc(func(ctx context.Context, msg outbox.Message) error {
+		return dispatcher.Enqueue(ctx, msg.EventType, msg.Payload, msg.CreatedAt)
+	})
+}
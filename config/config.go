@@ -4,26 +4,39 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"doghole/featureflag/rules"
 	"entgo.io/ent/dialect"
 	"github.com/fsnotify/fsnotify"
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
 var (
-	// _GlobalConfig 全局配置实例
-	_GlobalConfig *Config
+	// _GlobalConfig 全局配置实例，重新加载时整体替换，已发布的配置不再修改
+	_GlobalConfig atomic.Pointer[Config]
+
+	// _changeHooks 配置文件重新加载后的回调
+	_changeHooks   []func(*Config)
+	_changeHooksMu sync.RWMutex
+
+	// _validators 重新加载的配置生效前的校验函数
+	_validators   []func(*Config) error
+	_validatorsMu sync.RWMutex
 )
 
 // GetGlobalConfig 获取全局配置
 func GetGlobalConfig() *Config {
-	if _GlobalConfig == nil {
-		_GlobalConfig = NewConfig()
+	if c := _GlobalConfig.Load(); c != nil {
+		return c
 	}
-	return _GlobalConfig
+	_GlobalConfig.CompareAndSwap(nil, NewConfig())
+	return _GlobalConfig.Load()
 }
 
 // SetGlobalConfig 设置全局配置
@@ -31,7 +44,46 @@ func SetGlobalConfig(c *Config) {
 	if c == nil {
 		panic("无法设置全局配置为nil")
 	}
-	_GlobalConfig = c
+	_GlobalConfig.Store(c)
+}
+
+// OnValidate 注册配置校验函数，重新加载的配置只有全部校验通过才会替换全局配置并通知 OnChange 回调
+func OnValidate(fn func(*Config) error) {
+	_validatorsMu.Lock()
+	defer _validatorsMu.Unlock()
+	_validators = append(_validators, fn)
+}
+
+// validate 使用已注册的校验函数校验配置
+func validate(c *Config) error {
+	_validatorsMu.RLock()
+	validators := append([]func(*Config) error{}, _validators...)
+	_validatorsMu.RUnlock()
+
+	for _, fn := range validators {
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OnChange 注册配置文件重新加载后的回调，用于需要热更新的子系统，回调收到的是替换后的新配置
+func OnChange(fn func(*Config)) {
+	_changeHooksMu.Lock()
+	defer _changeHooksMu.Unlock()
+	_changeHooks = append(_changeHooks, fn)
+}
+
+// notifyChange 通知配置已重新加载
+func notifyChange(c *Config) {
+	_changeHooksMu.RLock()
+	hooks := append([]func(*Config){}, _changeHooks...)
+	_changeHooksMu.RUnlock()
+
+	for _, fn := range hooks {
+		fn(c)
+	}
 }
 
 // Config 应用配置结构体
@@ -39,17 +91,108 @@ type Config struct {
 	Server ServerConfig `json:"server" mapstructure:"server"` // 服务器配置
 	DB     DBConfig     `json:"db" mapstructure:"db"`         // 数据库配置
 	Logger LoggerConfig `json:"logger" mapstructure:"logger"` // 日志配置
+
+	Idempotency  IdempotencyConfig  `json:"idempotency" mapstructure:"idempotency"`   // 幂等键配置
+	RateLimit    RateLimitConfig    `json:"rate_limit" mapstructure:"rate_limit"`     // 限流配置
+	ChangeFeed   ChangeFeedConfig   `json:"change_feed" mapstructure:"change_feed"`   // 变更事件推送配置
+	Webhook      WebhookConfig      `json:"webhook" mapstructure:"webhook"`           // Webhook投递配置
+	JobQueue     JobQueueConfig     `json:"job_queue" mapstructure:"job_queue"`       // 后台任务队列配置
+	Scheduler    SchedulerConfig    `json:"scheduler" mapstructure:"scheduler"`       // 定时任务配置
+	Outbox       OutboxConfig       `json:"outbox" mapstructure:"outbox"`             // 事务发件箱配置
+	QueryCache   QueryCacheConfig   `json:"query_cache" mapstructure:"query_cache"`   // 查询缓存配置
+	HTTPCache    HTTPCacheConfig    `json:"http_cache" mapstructure:"http_cache"`     // HTTP缓存配置
+	FeatureFlag  FeatureFlagConfig  `json:"feature_flag" mapstructure:"feature_flag"` // 功能开关配置
+	Storage      StorageConfig      `json:"storage" mapstructure:"storage"`           // 文件存储配置
+	Avatar       AvatarConfig       `json:"avatar" mapstructure:"avatar"`             // 头像图片处理配置
+	Notification NotificationConfig `json:"notification" mapstructure:"notification"` // 通知中心配置
+	Admin        AdminConfig        `json:"admin" mapstructure:"admin"`               // 管理端口配置
+	Health       HealthConfig       `json:"health" mapstructure:"health"`             // 健康检查配置
+	Metrics      MetricsConfig      `json:"metrics" mapstructure:"metrics"`           // 指标配置
+	Tracing      TracingConfig      `json:"tracing" mapstructure:"tracing"`           // 链路追踪配置
+	CORS         CORSConfig         `json:"cors" mapstructure:"cors"`                 // 跨域资源共享配置
 }
 
 // ServerConfig 服务器配置
 type ServerConfig struct {
-	Port              int           `json:"port" mapstructure:"port"`                             // 服务器端口
-	ReadTimeout       time.Duration `json:"read_timeout" mapstructure:"read_timeout"`             // 读取超时
-	WriteTimeout      time.Duration `json:"write_timeout" mapstructure:"write_timeout"`           // 写入超时
-	IdleTimeout       time.Duration `json:"idle_timeout" mapstructure:"idle_timeout"`             // 空闲超时
-	ShutdownTimeout   time.Duration `json:"shutdown_timeout" mapstructure:"shutdown_timeout"`     // 关闭超时
-	EnableCompression bool          `json:"enable_compression" mapstructure:"enable_compression"` // 启用压缩
-	EnablePrefork     bool          `json:"enable_prefork" mapstructure:"enable_prefork"`         // 启用预分叉
+	Port              int               `json:"port" mapstructure:"port"`                             // 服务器端口
+	Listen            []string          `json:"listen" mapstructure:"listen"`                         // 监听地址，为空时监听 port，支持 host:port、[::]:port、unix:/path.sock、systemd、systemd:名称
+	UnixSocket        UnixSocketConfig  `json:"unix_socket" mapstructure:"unix_socket"`               // Unix域套接字文件权限
+	ReadTimeout       time.Duration     `json:"read_timeout" mapstructure:"read_timeout"`             // 读取超时
+	WriteTimeout      time.Duration     `json:"write_timeout" mapstructure:"write_timeout"`           // 写入超时
+	IdleTimeout       time.Duration     `json:"idle_timeout" mapstructure:"idle_timeout"`             // 空闲超时
+	ShutdownTimeout   time.Duration     `json:"shutdown_timeout" mapstructure:"shutdown_timeout"`     // 关闭超时
+	EnableCompression bool              `json:"enable_compression" mapstructure:"enable_compression"` // 启用压缩
+	Compression       CompressionConfig `json:"compression" mapstructure:"compression"`               // 响应压缩配置
+	EnablePrefork     bool              `json:"enable_prefork" mapstructure:"enable_prefork"`         // 启用预分叉
+	PreforkChildren   int               `json:"prefork_children" mapstructure:"prefork_children"`     // 预分叉的子进程数，0表示CPU核数
+	BodyLimit         int               `json:"body_limit" mapstructure:"body_limit"`                 // 默认请求体大小上限（字节）
+	Routes            []RouteConfig     `json:"routes" mapstructure:"routes"`                         // 按路由组覆盖的超时与请求体配置
+	TLS               TLSConfig         `json:"tls" mapstructure:"tls"`                               // HTTPS配置
+}
+
+// CompressionConfig 响应压缩配置
+type CompressionConfig struct {
+	MinSize      int      `json:"min_size" mapstructure:"min_size"`           // 最小压缩大小（字节），更小的响应不压缩
+	Encodings    []string `json:"encodings" mapstructure:"encodings"`         // 按优先级排列的编码: zstd, br, gzip，客户端同等接受时优先使用靠前的
+	ContentTypes []string `json:"content_types" mapstructure:"content_types"` // 允许压缩的内容类型，支持 text/* 形式的通配
+}
+
+// UnixSocketConfig Unix域套接字文件权限
+type UnixSocketConfig struct {
+	Mode  string `json:"mode" mapstructure:"mode"`   // 八进制文件权限，如 0660，为空时由 umask 决定
+	Group string `json:"group" mapstructure:"group"` // 所属用户组名称或GID，如 www-data，为空时不修改
+}
+
+// TLSConfig HTTPS配置，证书文件变更后自动重新加载，无需重启
+type TLSConfig struct {
+	Enabled        bool          `json:"enabled" mapstructure:"enabled"`                 // 是否启用HTTPS
+	CertFile       string        `json:"cert_file" mapstructure:"cert_file"`             // 证书文件（PEM），可包含中间证书
+	KeyFile        string        `json:"key_file" mapstructure:"key_file"`               // 私钥文件（PEM）
+	MinVersion     string        `json:"min_version" mapstructure:"min_version"`         // 最低TLS版本: 1.2, 1.3
+	CipherSuites   []string      `json:"cipher_suites" mapstructure:"cipher_suites"`     // TLS 1.2 加密套件名称，为空使用Go的默认值，TLS 1.3 的套件不可配置
+	ClientAuth     string        `json:"client_auth" mapstructure:"client_auth"`         // 客户端证书校验: none, optional（提供时校验）, required
+	ClientCAFile   string        `json:"client_ca_file" mapstructure:"client_ca_file"`   // 校验客户端证书的CA文件（PEM）
+	ReloadInterval time.Duration `json:"reload_interval" mapstructure:"reload_interval"` // 检查证书文件变更的间隔
+	ACME           ACMEConfig    `json:"acme" mapstructure:"acme"`                       // 通过ACME自动申请证书，启用后忽略 cert_file 和 key_file
+}
+
+// ACMEConfig ACME自动证书配置，支持 TLS-ALPN-01 和 HTTP-01 验证
+type ACMEConfig struct {
+	Enabled      bool          `json:"enabled" mapstructure:"enabled"`             // 是否启用
+	Domains      []string      `json:"domains" mapstructure:"domains"`             // 允许申请证书的域名
+	Email        string        `json:"email" mapstructure:"email"`                 // 联系邮箱，用于接收证书过期等通知
+	AcceptTOS    bool          `json:"accept_tos" mapstructure:"accept_tos"`       // 是否同意证书颁发机构的服务条款，必须为true
+	DirectoryURL string        `json:"directory_url" mapstructure:"directory_url"` // ACME目录地址，默认为 Let's Encrypt 生产环境
+	CAFile       string        `json:"ca_file" mapstructure:"ca_file"`             // 访问ACME服务器时额外信任的CA文件（PEM），用于 Pebble 等测试服务器
+	Cache        string        `json:"cache" mapstructure:"cache"`                 // 证书缓存: dir（本地目录）, db（数据库，多实例共享）
+	CacheDir     string        `json:"cache_dir" mapstructure:"cache_dir"`         // 证书缓存目录
+	RenewBefore  time.Duration `json:"renew_before" mapstructure:"renew_before"`   // 证书到期前多久续期
+	HTTPAddr     string        `json:"http_addr" mapstructure:"http_addr"`         // HTTP-01 验证的监听地址，如 :80，同时将其余请求重定向到HTTPS，为空只使用 TLS-ALPN-01
+}
+
+// RouteConfig 路由组级别的处理配置
+type RouteConfig struct {
+	Group      string        `json:"group" mapstructure:"group"`             // 路由组前缀，如 /api/v1/exports
+	Timeout    time.Duration `json:"timeout" mapstructure:"timeout"`         // 处理超时，超时后取消处理函数的context
+	BodyLimit  int           `json:"body_limit" mapstructure:"body_limit"`   // 请求体大小上限（字节），0表示使用默认值
+	StreamBody bool          `json:"stream_body" mapstructure:"stream_body"` // 是否以流的方式读取请求体，用于上传
+}
+
+// CORSConfig 跨域资源共享配置，配置文件变更后无需重启即可生效
+type CORSConfig struct {
+	Enabled  bool         `json:"enabled" mapstructure:"enabled"`   // 是否启用CORS，未启用时不返回任何CORS响应头，浏览器拒绝跨域请求
+	Policies []CORSPolicy `json:"policies" mapstructure:"policies"` // 按路由组声明的策略，按最长前缀匹配，group 为空的策略匹配其余所有请求
+}
+
+// CORSPolicy 路由组级别的CORS策略
+type CORSPolicy struct {
+	Group            string        `json:"group" mapstructure:"group"`                         // 路由组前缀，如 /api/v1/webhooks，为空匹配所有路由
+	AllowOrigins     []string      `json:"allow_origins" mapstructure:"allow_origins"`         // 允许的来源: 精确来源 https://app.example.com、子域名通配 https://*.example.com、正则 regex:https://[a-z]+\.example\.org（匹配完整的来源），* 表示任意来源
+	AllowMethods     []string      `json:"allow_methods" mapstructure:"allow_methods"`         // 预检请求允许的方法
+	AllowHeaders     []string      `json:"allow_headers" mapstructure:"allow_headers"`         // 预检请求允许的请求头，为空时允许预检请求中列出的全部请求头
+	ExposeHeaders    []string      `json:"expose_headers" mapstructure:"expose_headers"`       // 允许浏览器脚本读取的响应头
+	AllowCredentials bool          `json:"allow_credentials" mapstructure:"allow_credentials"` // 是否允许携带Cookie等凭据，启用时不能使用 * 来源
+	MaxAge           time.Duration `json:"max_age" mapstructure:"max_age"`                     // 预检结果的缓存时长
 }
 
 // DBConfig 数据库配置
@@ -67,6 +210,228 @@ type LoggerConfig struct {
 	ChuckSize int    `json:"chuck_size" mapstructure:"chuck_size"` // 日志切割大小
 }
 
+// IdempotencyConfig 幂等键配置
+type IdempotencyConfig struct {
+	Enabled     bool          `json:"enabled" mapstructure:"enabled"`             // 是否启用幂等键中间件
+	TTL         time.Duration `json:"ttl" mapstructure:"ttl"`                     // 幂等键保留时长
+	LockTimeout time.Duration `json:"lock_timeout" mapstructure:"lock_timeout"`   // 处理中状态的租约时长，处理期间定期续期，进程崩溃后超过该时长其他请求可以接管
+	MaxBodySize int           `json:"max_body_size" mapstructure:"max_body_size"` // 可保存的最大响应体字节数
+}
+
+// RateLimitConfig 限流配置
+type RateLimitConfig struct {
+	Enabled  bool              `json:"enabled" mapstructure:"enabled"`   // 是否启用限流
+	Store    string            `json:"store" mapstructure:"store"`       // 存储类型: memory, sql
+	Policies []RateLimitPolicy `json:"policies" mapstructure:"policies"` // 按路由组声明的限流策略
+}
+
+// RateLimitPolicy 限流策略
+type RateLimitPolicy struct {
+	Name      string        `json:"name" mapstructure:"name"`           // 策略名称
+	Group     string        `json:"group" mapstructure:"group"`         // 路由组前缀，如 /api/v1/users
+	Algorithm string        `json:"algorithm" mapstructure:"algorithm"` // 算法: token_bucket, sliding_window
+	Limit     int           `json:"limit" mapstructure:"limit"`         // 窗口内允许的请求数
+	Window    time.Duration `json:"window" mapstructure:"window"`       // 时间窗口
+	Burst     int           `json:"burst" mapstructure:"burst"`         // 令牌桶容量，默认等于limit
+	KeyBy     string        `json:"key_by" mapstructure:"key_by"`       // 限流维度: ip, user, api_key, tenant
+}
+
+// ChangeFeedConfig 变更事件推送配置
+type ChangeFeedConfig struct {
+	Enabled    bool          `json:"enabled" mapstructure:"enabled"`         // 是否启用变更事件推送
+	Entities   []string      `json:"entities" mapstructure:"entities"`       // 发布变更事件的实体类型，为空表示除内部实体外的全部实体
+	LogSize    int           `json:"log_size" mapstructure:"log_size"`       // 用于断线续传的事件日志容量
+	BufferSize int           `json:"buffer_size" mapstructure:"buffer_size"` // 每个订阅者的事件缓冲大小
+	Heartbeat  time.Duration `json:"heartbeat" mapstructure:"heartbeat"`     // 心跳间隔
+	AdminRole  string        `json:"admin_role" mapstructure:"admin_role"`   // 拥有该角色的调用方（如管理后台）可以收到所有事件，为空表示只按实体归属推送
+}
+
+// WebhookConfig Webhook投递配置
+type WebhookConfig struct {
+	Enabled             bool          `json:"enabled" mapstructure:"enabled"`                             // 是否启用Webhook投递
+	Timeout             time.Duration `json:"timeout" mapstructure:"timeout"`                             // 单次投递的请求超时
+	MaxAttempts         int           `json:"max_attempts" mapstructure:"max_attempts"`                   // 单个投递的最大尝试次数
+	BackoffBase         time.Duration `json:"backoff_base" mapstructure:"backoff_base"`                   // 重试退避的初始间隔
+	BackoffMax          time.Duration `json:"backoff_max" mapstructure:"backoff_max"`                     // 重试退避的最大间隔
+	DisableAfter        int           `json:"disable_after" mapstructure:"disable_after"`                 // 连续失败多少次后自动停用订阅
+	PollInterval        time.Duration `json:"poll_interval" mapstructure:"poll_interval"`                 // 扫描待投递记录的间隔
+	BatchSize           int           `json:"batch_size" mapstructure:"batch_size"`                       // 每次扫描处理的最大记录数
+	AllowPrivateTargets bool          `json:"allow_private_targets" mapstructure:"allow_private_targets"` // 是否允许投递到回环、内网和链路本地地址，仅用于本地开发
+}
+
+// JobQueueConfig 后台任务队列配置
+type JobQueueConfig struct {
+	Queues             map[string]int `json:"queues" mapstructure:"queues"`                           // 队列名称到并发数的映射
+	PollInterval       time.Duration  `json:"poll_interval" mapstructure:"poll_interval"`             // 队列为空时的轮询间隔
+	VisibilityTimeout  time.Duration  `json:"visibility_timeout" mapstructure:"visibility_timeout"`   // 可见性超时，也是单个任务的执行超时
+	MaxAttempts        int            `json:"max_attempts" mapstructure:"max_attempts"`               // 默认最大执行次数
+	BackoffBase        time.Duration  `json:"backoff_base" mapstructure:"backoff_base"`               // 重试退避的初始间隔
+	BackoffMax         time.Duration  `json:"backoff_max" mapstructure:"backoff_max"`                 // 重试退避的最大间隔
+	SucceededRetention time.Duration  `json:"succeeded_retention" mapstructure:"succeeded_retention"` // 成功任务的保留时长，0表示不清理
+	DeadRetention      time.Duration  `json:"dead_retention" mapstructure:"dead_retention"`           // 死信任务的保留时长，0表示不清理
+}
+
+// OutboxConfig 事务发件箱配置
+type OutboxConfig struct {
+	Enabled        bool          `json:"enabled" mapstructure:"enabled"`                 // 是否启用发件箱中继
+	PollInterval   time.Duration `json:"poll_interval" mapstructure:"poll_interval"`     // 扫描待发布事件的间隔
+	BatchSize      int           `json:"batch_size" mapstructure:"batch_size"`           // 每次扫描处理的最大事件数
+	PublishTimeout time.Duration `json:"publish_timeout" mapstructure:"publish_timeout"` // 单次发布超时，也是占用租约的时长
+	BackoffBase    time.Duration `json:"backoff_base" mapstructure:"backoff_base"`       // 重试退避的初始间隔
+	BackoffMax     time.Duration `json:"backoff_max" mapstructure:"backoff_max"`         // 重试退避的最大间隔
+	Retention      time.Duration `json:"retention" mapstructure:"retention"`             // 已发布事件的保留时长，0表示发布后立即删除
+}
+
+// QueryCacheConfig 读连接查询缓存配置
+type QueryCacheConfig struct {
+	Enabled    bool          `json:"enabled" mapstructure:"enabled"`         // 是否启用查询缓存
+	Backend    string        `json:"backend" mapstructure:"backend"`         // 缓存后端: memory, redis
+	TTL        time.Duration `json:"ttl" mapstructure:"ttl"`                 // 默认缓存时长
+	MaxEntries int           `json:"max_entries" mapstructure:"max_entries"` // 进程内缓存的最大缓存项数
+	Redis      RedisConfig   `json:"redis" mapstructure:"redis"`             // Redis连接配置
+}
+
+// HTTPCacheConfig HTTP条件请求与响应缓存配置
+type HTTPCacheConfig struct {
+	Enabled     bool            `json:"enabled" mapstructure:"enabled"`             // 是否启用ETag与条件请求
+	MaxEntries  int             `json:"max_entries" mapstructure:"max_entries"`     // 服务端响应缓存的最大缓存项数
+	MaxBodySize int             `json:"max_body_size" mapstructure:"max_body_size"` // 计算ETag和写入服务端缓存的响应体大小上限（字节）
+	Rules       []HTTPCacheRule `json:"rules" mapstructure:"rules"`                 // 按路由组配置的缓存规则
+}
+
+// HTTPCacheRule 路由组级别的HTTP缓存规则
+type HTTPCacheRule struct {
+	Group        string        `json:"group" mapstructure:"group"`                 // 路由组前缀，如 /api/v1/users
+	CacheControl string        `json:"cache_control" mapstructure:"cache_control"` // Cache-Control 响应头，处理函数已设置时不覆盖
+	Vary         []string      `json:"vary" mapstructure:"vary"`                   // 响应随之变化的请求头，同时作为服务端缓存键的一部分
+	ServerCache  bool          `json:"server_cache" mapstructure:"server_cache"`   // 是否在服务端缓存匿名GET请求的响应
+	TTL          time.Duration `json:"ttl" mapstructure:"ttl"`                     // 服务端缓存时长
+}
+
+// FeatureFlagConfig 功能开关配置
+type FeatureFlagConfig struct {
+	Enabled      bool                  `json:"enabled" mapstructure:"enabled"`             // 是否启用数据库覆盖和管理接口，未启用时只使用配置文件中的开关
+	PollInterval time.Duration         `json:"poll_interval" mapstructure:"poll_interval"` // 轮询数据库覆盖的间隔
+	Flags        map[string]rules.Flag `json:"flags" mapstructure:"flags"`                 // 开关名称到规则的映射，名称不能包含 "."
+}
+
+// StorageConfig 文件上传与存储配置
+type StorageConfig struct {
+	Enabled      bool               `json:"enabled" mapstructure:"enabled"`             // 是否启用文件上传接口
+	Backend      string             `json:"backend" mapstructure:"backend"`             // 存储后端: local, s3
+	MaxSize      int64              `json:"max_size" mapstructure:"max_size"`           // 单个文件大小上限（字节）
+	AllowedTypes []string           `json:"allowed_types" mapstructure:"allowed_types"` // 允许上传的MIME类型，支持 image/* 形式的通配，为空表示不限制
+	SigningKey   string             `json:"signing_key" mapstructure:"signing_key"`     // 下载链接签名密钥，为空时启动时随机生成
+	URLTTL       time.Duration      `json:"url_ttl" mapstructure:"url_ttl"`             // 下载链接默认有效期
+	MaxURLTTL    time.Duration      `json:"max_url_ttl" mapstructure:"max_url_ttl"`     // 下载链接最长有效期
+	Local        LocalStorageConfig `json:"local" mapstructure:"local"`                 // 本地文件系统存储配置
+	S3           S3StorageConfig    `json:"s3" mapstructure:"s3"`                       // S3兼容存储配置
+}
+
+// AvatarConfig 头像图片处理配置，图片保存在 storage 配置的存储后端中
+type AvatarConfig struct {
+	Enabled     bool          `json:"enabled" mapstructure:"enabled"`             // 是否启用头像上传接口
+	MaxSize     int64         `json:"max_size" mapstructure:"max_size"`           // 上传大小上限（字节）
+	MaxPixels   int           `json:"max_pixels" mapstructure:"max_pixels"`       // 图片像素数上限，GIF为所有帧像素数之和，解码前检查
+	MaxFrames   int           `json:"max_frames" mapstructure:"max_frames"`       // GIF动图的帧数上限，解码前检查
+	Sizes       []int         `json:"sizes" mapstructure:"sizes"`                 // 缩略图边长（像素）
+	Pregenerate bool          `json:"pregenerate" mapstructure:"pregenerate"`     // 上传后由后台任务预生成缩略图，否则在首次请求时生成
+	Quality     int           `json:"quality" mapstructure:"quality"`             // JPEG编码质量 1-100
+	CacheMaxAge time.Duration `json:"cache_max_age" mapstructure:"cache_max_age"` // 响应的 Cache-Control max-age
+}
+
+// LocalStorageConfig 本地文件系统存储配置
+type LocalStorageConfig struct {
+	Root string `json:"root" mapstructure:"root"` // 存储根目录
+}
+
+// S3StorageConfig S3兼容存储配置
+type S3StorageConfig struct {
+	Endpoint  string `json:"endpoint" mapstructure:"endpoint"`     // 服务地址，如 s3.amazonaws.com、127.0.0.1:9000
+	Region    string `json:"region" mapstructure:"region"`         // 区域
+	Bucket    string `json:"bucket" mapstructure:"bucket"`         // 存储桶
+	AccessKey string `json:"access_key" mapstructure:"access_key"` // 访问密钥ID
+	SecretKey string `json:"secret_key" mapstructure:"secret_key"` // 访问密钥
+	UseSSL    bool   `json:"use_ssl" mapstructure:"use_ssl"`       // 是否使用HTTPS
+	PathStyle bool   `json:"path_style" mapstructure:"path_style"` // 是否使用路径风格访问存储桶，MinIO等自建服务通常需要开启
+	Prefix    string `json:"prefix" mapstructure:"prefix"`         // 对象名前缀
+}
+
+// NotificationConfig 通知中心配置
+type NotificationConfig struct {
+	Enabled         bool        `json:"enabled" mapstructure:"enabled"`                   // 是否启用通知中心
+	DefaultLocale   string      `json:"default_locale" mapstructure:"default_locale"`     // 用户未指定语言或模板缺少该语言时使用的语言
+	DefaultChannels []string    `json:"default_channels" mapstructure:"default_channels"` // 用户未设置偏好时启用的渠道: in_app, email, webhook
+	TemplateDir     string      `json:"template_dir" mapstructure:"template_dir"`         // 自定义模板目录，其中的模板覆盖内置模板
+	Email           EmailConfig `json:"email" mapstructure:"email"`                       // 邮件发送配置
+}
+
+// EmailConfig 邮件发送配置，未配置SMTP主机时邮件只写入日志
+type EmailConfig struct {
+	From     string `json:"from" mapstructure:"from"`         // 发件人，如 "Doghole <noreply@example.com>"
+	Host     string `json:"host" mapstructure:"host"`         // SMTP主机
+	Port     int    `json:"port" mapstructure:"port"`         // SMTP端口，服务器支持时自动启用STARTTLS
+	Username string `json:"username" mapstructure:"username"` // SMTP用户名，为空表示不认证
+	Password string `json:"password" mapstructure:"password"` // SMTP密码
+}
+
+// RedisConfig Redis连接配置
+type RedisConfig struct {
+	Addr      string `json:"addr" mapstructure:"addr"`             // 地址，如 127.0.0.1:6379
+	Username  string `json:"username" mapstructure:"username"`     // 用户名
+	Password  string `json:"password" mapstructure:"password"`     // 密码
+	DB        int    `json:"db" mapstructure:"db"`                 // 数据库编号
+	KeyPrefix string `json:"key_prefix" mapstructure:"key_prefix"` // 键前缀
+}
+
+// SchedulerConfig 定时任务配置
+type SchedulerConfig struct {
+	Enabled          bool                     `json:"enabled" mapstructure:"enabled"`                     // 是否启用定时任务
+	TimeZone         string                   `json:"time_zone" mapstructure:"time_zone"`                 // 默认时区
+	HistoryRetention time.Duration            `json:"history_retention" mapstructure:"history_retention"` // 运行记录保留时长
+	Tasks            map[string]ScheduledTask `json:"tasks" mapstructure:"tasks"`                         // 任务名称到调度配置的映射
+}
+
+// ScheduledTask 定时任务调度配置
+type ScheduledTask struct {
+	Cron     string        `json:"cron" mapstructure:"cron"`           // cron表达式，支持 @hourly 等描述符
+	TimeZone string        `json:"time_zone" mapstructure:"time_zone"` // 时区，为空时使用默认时区
+	Enabled  bool          `json:"enabled" mapstructure:"enabled"`     // 是否启用
+	Timeout  time.Duration `json:"timeout" mapstructure:"timeout"`     // 单次运行超时，0表示不限制
+}
+
+// AdminConfig 管理端口配置，健康检查、指标、pprof等运维接口与公开API分开监听
+type AdminConfig struct {
+	Enabled  bool     `json:"enabled" mapstructure:"enabled"`     // 是否启用管理端口
+	Addr     string   `json:"addr" mapstructure:"addr"`           // 监听地址，支持 host:port、unix:/path.sock 和 systemd:名称
+	Token    string   `json:"token" mapstructure:"token"`         // 访问令牌，请求需携带 Authorization: Bearer <token>，为空时不校验
+	AllowIPs []string `json:"allow_ips" mapstructure:"allow_ips"` // 允许访问的IP或CIDR，为空时不限制，Unix域套接字的连接不受限制
+	Pprof    bool     `json:"pprof" mapstructure:"pprof"`         // 是否开放 /debug/pprof
+}
+
+// HealthConfig 健康检查配置
+type HealthConfig struct {
+	Timeout     time.Duration `json:"timeout" mapstructure:"timeout"`             // 单个检查的默认超时
+	CacheTTL    time.Duration `json:"cache_ttl" mapstructure:"cache_ttl"`         // 检查结果的默认缓存时长，避免频繁的探针请求压垮依赖
+	DiskMinFree int64         `json:"disk_min_free" mapstructure:"disk_min_free"` // 日志目录所在磁盘的最小可用空间（字节），0表示不检查
+}
+
+// MetricsConfig Prometheus指标配置，指标通过管理端口暴露
+type MetricsConfig struct {
+	Enabled bool   `json:"enabled" mapstructure:"enabled"` // 是否采集HTTP请求、数据库语句和连接池指标
+	Path    string `json:"path" mapstructure:"path"`       // 管理端口上的指标路径
+}
+
+// TracingConfig OpenTelemetry链路追踪配置
+type TracingConfig struct {
+	Enabled     bool              `json:"enabled" mapstructure:"enabled"`           // 是否启用链路追踪
+	Exporter    string            `json:"exporter" mapstructure:"exporter"`         // 导出器: otlp（OTLP/HTTP）, stdout（标准输出）, none（不导出，只在日志中记录追踪ID）
+	Endpoint    string            `json:"endpoint" mapstructure:"endpoint"`         // OTLP/HTTP接收地址，如 http://localhost:4318/v1/traces，为空时使用 OTEL_EXPORTER_OTLP_ENDPOINT 环境变量
+	Headers     map[string]string `json:"headers" mapstructure:"headers"`           // 发送到OTLP接收地址时附加的请求头，如认证令牌
+	ServiceName string            `json:"service_name" mapstructure:"service_name"` // 上报的服务名称
+	SampleRatio float64           `json:"sample_ratio" mapstructure:"sample_ratio"` // 新追踪的采样比例 0-1，上游请求已决定采样时沿用上游的决定
+}
+
 // DB 数据库连接配置
 type DB struct {
 	Driver   string `json:"driver" mapstructure:"driver"`     // 数据库驱动
@@ -78,6 +443,14 @@ type DB struct {
 	SSLMode  string `json:"ssl_mode" mapstructure:"ssl_mode"` // SSL模式
 }
 
+// Dialect 返回写入数据库的ent方言
+func (c *DBConfig) Dialect() string {
+	if c.DB != nil {
+		return c.DB.ToDialect()
+	}
+	return c.WriteDB.ToDialect()
+}
+
 // ToDialect 转换为ent方言
 func (db *DB) ToDialect() string {
 	if db == nil {
@@ -116,7 +489,33 @@ func NewConfig() *Config {
 			IdleTimeout:       120 * time.Second,
 			ShutdownTimeout:   5 * time.Second,
 			EnableCompression: true,
-			EnablePrefork:     false,
+			Compression: CompressionConfig{
+				MinSize:   1024,
+				Encodings: []string{"zstd", "br", "gzip"},
+				ContentTypes: []string{
+					"text/*",
+					"application/json",
+					"application/problem+json",
+					"application/javascript",
+					"application/xml",
+					"image/svg+xml",
+				},
+			},
+			EnablePrefork:   false,
+			PreforkChildren: 0,
+			BodyLimit:       4 * 1024 * 1024,
+			TLS: TLSConfig{
+				MinVersion:     "1.2",
+				ClientAuth:     "none",
+				ReloadInterval: time.Minute,
+				ACME: ACMEConfig{
+					DirectoryURL: "https://acme-v02.api.letsencrypt.org/directory",
+					Cache:        "dir",
+					CacheDir:     "data/acme",
+					RenewBefore:  30 * 24 * time.Hour,
+					HTTPAddr:     ":80",
+				},
+			},
 		},
 		Logger: LoggerConfig{
 			Level:     "info",
@@ -124,6 +523,136 @@ func NewConfig() *Config {
 			Outfile:   "",
 			ChuckSize: 100,
 		},
+		Idempotency: IdempotencyConfig{
+			Enabled:     true,
+			TTL:         24 * time.Hour,
+			LockTimeout: time.Minute,
+			MaxBodySize: 1 << 20,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: false,
+			Store:   "memory",
+		},
+		ChangeFeed: ChangeFeedConfig{
+			Enabled:    true,
+			Entities:   []string{"User"},
+			LogSize:    1000,
+			BufferSize: 64,
+			Heartbeat:  15 * time.Second,
+			AdminRole:  "admin",
+		},
+		Webhook: WebhookConfig{
+			Enabled:      false,
+			Timeout:      10 * time.Second,
+			MaxAttempts:  8,
+			BackoffBase:  30 * time.Second,
+			BackoffMax:   6 * time.Hour,
+			DisableAfter: 20,
+			PollInterval: 5 * time.Second,
+			BatchSize:    50,
+		},
+		JobQueue: JobQueueConfig{
+			Queues:             map[string]int{"default": 5},
+			PollInterval:       time.Second,
+			VisibilityTimeout:  5 * time.Minute,
+			MaxAttempts:        5,
+			BackoffBase:        10 * time.Second,
+			BackoffMax:         time.Hour,
+			SucceededRetention: 7 * 24 * time.Hour,
+			DeadRetention:      30 * 24 * time.Hour,
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:          false,
+			TimeZone:         "Local",
+			HistoryRetention: 30 * 24 * time.Hour,
+		},
+		FeatureFlag: FeatureFlagConfig{
+			Enabled:      false,
+			PollInterval: 10 * time.Second,
+		},
+		HTTPCache: HTTPCacheConfig{
+			Enabled:     false,
+			MaxEntries:  1000,
+			MaxBodySize: 1 << 20,
+		},
+		QueryCache: QueryCacheConfig{
+			Enabled:    false,
+			Backend:    "memory",
+			TTL:        time.Minute,
+			MaxEntries: 10000,
+			Redis: RedisConfig{
+				Addr:      "127.0.0.1:6379",
+				KeyPrefix: "doghole:cache:",
+			},
+		},
+		Storage: StorageConfig{
+			Enabled:   false,
+			Backend:   "local",
+			MaxSize:   100 << 20,
+			URLTTL:    15 * time.Minute,
+			MaxURLTTL: 7 * 24 * time.Hour,
+			Local: LocalStorageConfig{
+				Root: "data/files",
+			},
+		},
+		Avatar: AvatarConfig{
+			Enabled:     false,
+			MaxSize:     10 << 20,
+			MaxPixels:   25_000_000,
+			MaxFrames:   100,
+			Sizes:       []int{64, 128, 256},
+			Pregenerate: false,
+			Quality:     85,
+			CacheMaxAge: 365 * 24 * time.Hour,
+		},
+		Notification: NotificationConfig{
+			Enabled:         false,
+			DefaultLocale:   "zh-CN",
+			DefaultChannels: []string{"in_app"},
+			Email: EmailConfig{
+				Port: 587,
+			},
+		},
+		Admin: AdminConfig{
+			Enabled:  false,
+			Addr:     "127.0.0.1:9090",
+			AllowIPs: []string{"127.0.0.1", "::1"},
+			Pprof:    false,
+		},
+		Health: HealthConfig{
+			Timeout:     2 * time.Second,
+			CacheTTL:    time.Second,
+			DiskMinFree: 100 << 20,
+		},
+		Metrics: MetricsConfig{
+			Enabled: false,
+			Path:    "/metrics",
+		},
+		CORS: CORSConfig{
+			Enabled: true,
+			Policies: []CORSPolicy{{
+				AllowOrigins:  []string{"*"},
+				AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+				AllowHeaders:  []string{"Origin", "Content-Type", "Accept", "Authorization", "Idempotency-Key"},
+				ExposeHeaders: []string{"Idempotent-Replayed", "X-Cache"},
+				MaxAge:        5 * time.Minute,
+			}},
+		},
+		Tracing: TracingConfig{
+			Enabled:     false,
+			Exporter:    "otlp",
+			ServiceName: "doghole",
+			SampleRatio: 1,
+		},
+		Outbox: OutboxConfig{
+			Enabled:        false,
+			PollInterval:   time.Second,
+			BatchSize:      100,
+			PublishTimeout: 30 * time.Second,
+			BackoffBase:    time.Second,
+			BackoffMax:     5 * time.Minute,
+			Retention:      24 * time.Hour,
+		},
 	}
 }
 
@@ -132,6 +661,14 @@ func (c *Config) ToPort() string {
 	return fmt.Sprintf(":%d", c.Server.Port)
 }
 
+// ListenAddrs 返回服务器的监听地址，未配置 listen 时监听 port
+func (c *Config) ListenAddrs() []string {
+	if len(c.Server.Listen) > 0 {
+		return c.Server.Listen
+	}
+	return []string{c.ToPort()}
+}
+
 // LoadSingleConfigFile 从单个配置文件加载配置
 func (c *Config) LoadSingleConfigFile(filename string) error {
 	filetype, err := assertFileType(filename)
@@ -147,19 +684,11 @@ func (c *Config) LoadSingleConfigFile(filename string) error {
 		return errors.Wrap(err, "读取配置文件失败")
 	}
 
-	if err := v.Unmarshal(c); err != nil {
+	if err := v.Unmarshal(c, zeroFields); err != nil {
 		return errors.Wrap(err, "解析配置文件失败")
 	}
 
-	// 监听配置文件变化
-	v.WatchConfig()
-	v.OnConfigChange(func(e fsnotify.Event) {
-		zap.L().Info("配置文件已更改", zap.String("file", e.Name))
-		if err := v.Unmarshal(c); err != nil {
-			zap.L().Error("重新加载配置失败", zap.Error(err))
-		}
-	})
-
+	watch(v)
 	return nil
 }
 
@@ -182,20 +711,40 @@ func (c *Config) LoadConfigFromDirs(filename string, dirs ...string) error {
 		return errors.Wrap(err, "读取配置文件失败")
 	}
 
-	if err := v.Unmarshal(c); err != nil {
+	if err := v.Unmarshal(c, zeroFields); err != nil {
 		return errors.Wrap(err, "解析配置文件失败")
 	}
 
-	// 监听配置文件变化
+	watch(v)
+	return nil
+}
+
+// zeroFields 解码时清空map和slice后再写入，配置文件中删除的定时任务、功能开关等不会保留默认值或旧值
+func zeroFields(dc *mapstructure.DecoderConfig) {
+	dc.ZeroFields = true
+}
+
+// watch 监听配置文件变化
+// 变更后解析为一份新的配置，校验通过后整体替换全局配置再通知各子系统；
+// 不在正在使用的配置上修改，并发读取配置的请求看到的始终是完整的一份
+func watch(v *viper.Viper) {
 	v.WatchConfig()
 	v.OnConfigChange(func(e fsnotify.Event) {
 		zap.L().Info("配置文件已更改", zap.String("file", e.Name))
-		if err := v.Unmarshal(c); err != nil {
+
+		next := NewConfig()
+		if err := v.Unmarshal(next, zeroFields); err != nil {
 			zap.L().Error("重新加载配置失败", zap.Error(err))
+			return
+		}
+		if err := validate(next); err != nil {
+			zap.L().Error("新配置无效，继续使用原有配置", zap.Error(err))
+			return
 		}
-	})
 
-	return nil
+		SetGlobalConfig(next)
+		notifyChange(next)
+	})
 }
 
 // LoadEnvConfig 从环境变量加载配置
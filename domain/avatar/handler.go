@@ -0,0 +1,113 @@
+package avatar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"doghole/auth"
+	"doghole/domain/file"
+	"doghole/imaging"
+	"doghole/storage"
+	"github.com/gofiber/fiber/v3"
+	"github.com/pkg/errors"
+)
+
+// handler 头像接口处理器
+type handler struct {
+	pipeline *Pipeline
+	body     file.BodyReader
+	maxAge   int
+}
+
+// RegisterRoutes 注册头像上传和访问路由，上传需要认证
+// 访问路径为 /:hash/:variant，内容由摘要唯一确定，响应可被客户端和CDN长期缓存
+func RegisterRoutes(router fiber.Router, pipeline *Pipeline, body file.BodyReader) {
+	h := &handler{
+		pipeline: pipeline,
+		body:     body,
+		maxAge:   int(pipeline.conf.CacheMaxAge.Seconds()),
+	}
+	router.Post("/", h.upload, auth.Required())
+	router.Get("/:hash/:variant", h.serve)
+}
+
+// upload 上传头像，返回原图和各尺寸缩略图的访问地址
+func (h *handler) upload(c fiber.Ctx) error {
+	body, _, err := file.OpenUpload(c, h.body(c))
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	avatar, err := h.pipeline.Upload(c.Context(), auth.UserID(c), body)
+	if err != nil {
+		return httpError(err)
+	}
+
+	base := c.BaseURL() + strings.TrimSuffix(c.Path(), "/") + "/" + avatar.Hash + "/"
+	urls := map[string]string{VariantOriginal: base + VariantOriginal}
+	for _, size := range h.pipeline.Sizes() {
+		urls[strconv.Itoa(size)] = base + strconv.Itoa(size)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": avatar, "urls": urls})
+}
+
+// serve 返回原图或缩略图
+func (h *handler) serve(c fiber.Ctx) error {
+	hash, variant := c.Params("hash"), c.Params("variant")
+	etag := fmt.Sprintf(`"%s-%s"`, hash, variant)
+	cacheControl := fmt.Sprintf("public, max-age=%d, immutable", h.maxAge)
+
+	// 内容由摘要和尺寸唯一确定，无需读取存储即可响应条件请求
+	if _, err := h.pipeline.parseVariant(hash, variant); err == nil && strings.Contains(c.Get(fiber.HeaderIfNoneMatch), etag) {
+		c.Set(fiber.HeaderETag, etag)
+		c.Set(fiber.HeaderCacheControl, cacheControl)
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	rc, err := h.pipeline.Open(c.Context(), hash, variant)
+	if err != nil {
+		return httpError(err)
+	}
+
+	br := bufio.NewReader(rc)
+	head, _ := br.Peek(512)
+
+	c.Set(fiber.HeaderContentType, http.DetectContentType(head))
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderCacheControl, cacheControl)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+
+	// SendStream 在响应发送完成后关闭实现了 io.Closer 的读取流
+	return c.SendStream(readCloser{Reader: br, Closer: rc})
+}
+
+// readCloser 组合带缓冲的读取流和底层流的关闭方法
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// httpError 将头像处理的错误转换为对应的HTTP状态码
+func httpError(err error) error {
+	var fe *fiber.Error
+	switch {
+	case errors.As(err, &fe):
+		return fe
+	case errors.Is(err, ErrTooLarge), errors.Is(err, imaging.ErrTooManyPixels), errors.Is(err, imaging.ErrTooManyFrames):
+		return fiber.NewError(fiber.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, imaging.ErrUnsupportedFormat):
+		return fiber.NewError(fiber.StatusUnsupportedMediaType, "仅支持 PNG、JPEG、WebP 和 GIF 格式的图片")
+	case errors.Is(err, imaging.ErrCorrupt):
+		return fiber.NewError(fiber.StatusBadRequest, imaging.ErrCorrupt.Error())
+	case errors.Is(err, ErrInvalidHash), errors.Is(err, ErrUnknownVariant), errors.Is(err, storage.ErrNotFound):
+		return fiber.ErrNotFound
+	default:
+		return err
+	}
+}
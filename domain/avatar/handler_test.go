@@ -0,0 +1,165 @@
+package avatar
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color/palette"
+	"image/gif"
+	"image/png"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"doghole/auth"
+	"doghole/config"
+	"doghole/storage"
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+// avatarApp 创建挂载头像路由的测试应用，认证中间件从 X-Test-User 请求头读取用户ID
+func avatarApp(t *testing.T, conf config.AvatarConfig) *fiber.App {
+	t.Helper()
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pipeline := NewPipeline(store, conf, WithLogger(zap.NewNop()))
+
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error {
+		if id := c.Get("X-Test-User"); id != "" {
+			c.Locals(auth.LocalsUserID, id)
+		}
+		return c.Next()
+	})
+	RegisterRoutes(app.Group("/avatars"), pipeline, func(c fiber.Ctx) io.Reader {
+		return bytes.NewReader(c.Body())
+	})
+	return app
+}
+
+// pngImage 生成指定尺寸的PNG图片
+func pngImage(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// animatedGIF 生成 frames 帧、每帧 size×size 的GIF动图
+func animatedGIF(t *testing.T, size, frames int) []byte {
+	t.Helper()
+	g := &gif.GIF{}
+	for i := 0; i < frames; i++ {
+		g.Image = append(g.Image, image.NewPaletted(image.Rect(0, 0, size, size), palette.Plan9))
+		g.Delay = append(g.Delay, 10)
+	}
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUpload(t *testing.T) {
+	app := avatarApp(t, config.AvatarConfig{
+		MaxSize:   64 << 10,
+		MaxPixels: 10_000,
+		MaxFrames: 10,
+		Sizes:     []int{16},
+	})
+
+	tests := []struct {
+		name        string
+		user        string
+		contentType string
+		body        []byte
+		status      int
+	}{
+		{"未登录", "", "image/png", pngImage(t, 32, 32), fiber.StatusUnauthorized},
+		{"PNG", "alice", "image/png", pngImage(t, 32, 32), fiber.StatusCreated},
+		{"伪装成PNG的HTML", "alice", "image/png", []byte("<html><script>alert(1)</script></html>"), fiber.StatusUnsupportedMediaType},
+		{"超过大小上限", "alice", "image/png", bytes.Repeat([]byte{0}, 64<<10+1), fiber.StatusRequestEntityTooLarge},
+		{"超过像素数上限", "alice", "image/png", pngImage(t, 101, 100), fiber.StatusRequestEntityTooLarge},
+		{"GIF动图", "alice", "image/gif", animatedGIF(t, 20, 5), fiber.StatusCreated},
+		{"GIF帧数超限", "alice", "image/gif", animatedGIF(t, 4, 11), fiber.StatusRequestEntityTooLarge},
+		// 逻辑屏幕只有 40×40，所有帧的像素数之和超过上限
+		{"GIF总像素数超限", "alice", "image/gif", animatedGIF(t, 40, 10), fiber.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodPost, "/avatars", bytes.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, tt.contentType)
+			if tt.user != "" {
+				req.Header.Set("X-Test-User", tt.user)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.status {
+				body, _ := io.ReadAll(resp.Body)
+				t.Fatalf("status = %d，期望 %d: %s", resp.StatusCode, tt.status, body)
+			}
+		})
+	}
+}
+
+func TestServe(t *testing.T) {
+	app := avatarApp(t, config.AvatarConfig{MaxSize: 64 << 10, MaxPixels: 10_000, Sizes: []int{16}})
+
+	req := httptest.NewRequest(fiber.MethodPost, "/avatars", bytes.NewReader(animatedGIF(t, 32, 3)))
+	req.Header.Set("X-Test-User", "alice")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result struct {
+		URLs map[string]string `json:"urls"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	// 缩略图在首次请求时生成，GIF动图的缩略图为PNG静态图
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, result.URLs["16"], nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d: %s", resp.StatusCode, data)
+	}
+	if ct := resp.Header.Get(fiber.HeaderContentType); ct != "image/png" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	if resp.Header.Get(fiber.HeaderXContentTypeOptions) != "nosniff" {
+		t.Fatal("缺少 X-Content-Type-Options")
+	}
+	cfg, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Width != 16 || cfg.Height != 16 {
+		t.Fatalf("缩略图尺寸 = %dx%d", cfg.Width, cfg.Height)
+	}
+
+	// 条件请求直接返回304
+	req = httptest.NewRequest(fiber.MethodGet, result.URLs["16"], nil)
+	req.Header.Set(fiber.HeaderIfNoneMatch, resp.Header.Get(fiber.HeaderETag))
+	if resp, err = app.Test(req); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusNotModified {
+		t.Fatalf("条件请求 status = %d", resp.StatusCode)
+	}
+}
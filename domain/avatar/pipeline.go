@@ -0,0 +1,293 @@
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"sync"
+
+	"doghole/config"
+	"doghole/imaging"
+	"doghole/jobqueue"
+	"doghole/logger"
+	"doghole/storage"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// JobGenerateVariants 预生成缩略图的后台任务类型
+const JobGenerateVariants = "avatar.generate_variants"
+
+// VariantOriginal 去除元数据后的原图
+const VariantOriginal = "original"
+
+var (
+	// ErrTooLarge 图片超过大小上限
+	ErrTooLarge = errors.New("图片过大")
+	// ErrInvalidHash 无效的内容摘要
+	ErrInvalidHash = errors.New("无效的头像摘要")
+	// ErrUnknownVariant 未配置的缩略图尺寸
+	ErrUnknownVariant = errors.New("未配置的缩略图尺寸")
+)
+
+// Avatar 已上传的头像
+type Avatar struct {
+	Hash string `json:"hash"` // 去除元数据后原图的SHA-256摘要，也是头像的标识
+	imaging.Info
+}
+
+// GeneratePayload 预生成缩略图任务的参数
+type GeneratePayload struct {
+	Hash string `json:"hash"`
+}
+
+// Pipeline 头像处理流水线
+// 原图和缩略图都以内容摘要为键保存在存储后端中，相同内容只保存一份，且内容不会变化，可被客户端长期缓存
+type Pipeline struct {
+	storage storage.Storage
+	conf    config.AvatarConfig
+	logger  *zap.Logger
+	group   singleflight.Group
+}
+
+// NewPipeline 创建头像处理流水线
+func NewPipeline(store storage.Storage, conf config.AvatarConfig, options ...func(*Pipeline)) *Pipeline {
+	if conf.Quality <= 0 || conf.Quality > 100 {
+		conf.Quality = 85
+	}
+
+	p := &Pipeline{
+		storage: store,
+		conf:    conf,
+		logger:  zap.L(),
+	}
+
+	for _, option := range options {
+		option(p)
+	}
+
+	return p
+}
+
+// WithLogger 设置日志记录器
+func WithLogger(logger *zap.Logger) func(*Pipeline) {
+	return func(p *Pipeline) {
+		p.logger = logger
+	}
+}
+
+// Sizes 返回配置的缩略图尺寸
+func (p *Pipeline) Sizes() []int {
+	return p.conf.Sizes
+}
+
+// Upload 校验图片格式、去除元数据后保存原图，uploader 为上传者的用户ID
+// 启用预生成时投递后台任务生成缩略图，否则缩略图在首次请求时生成
+func (p *Pipeline) Upload(ctx context.Context, uploader string, r io.Reader) (*Avatar, error) {
+	data, err := readLimited(r, p.conf.MaxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := imaging.Detect(data, p.conf.MaxPixels, p.conf.MaxFrames)
+	if err != nil {
+		return nil, err
+	}
+
+	clean, err := imaging.Sanitize(data, info.Format, p.conf.Quality)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(clean)
+	avatar := &Avatar{Hash: hex.EncodeToString(sum[:]), Info: info}
+
+	key := variantKey(avatar.Hash, VariantOriginal)
+	if _, err := p.storage.Stat(ctx, key); err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return nil, err
+		}
+		if err := p.storage.Put(ctx, key, bytes.NewReader(clean), int64(len(clean)), "image/"+string(info.Format)); err != nil {
+			return nil, err
+		}
+	}
+
+	// 相同内容的头像只保存一份，上传者记录在日志中用于审计
+	logger.WithContext(ctx, p.logger).Info("头像已上传",
+		zap.String("hash", avatar.Hash),
+		zap.String("uploader", uploader),
+		zap.String("format", string(info.Format)),
+	)
+
+	if p.conf.Pregenerate {
+		if _, err := jobqueue.Enqueue(ctx, JobGenerateVariants, GeneratePayload{Hash: avatar.Hash}); err != nil {
+			// 缩略图仍可在首次请求时生成，不影响上传结果
+			logger.WithContext(ctx, p.logger).Error("投递缩略图生成任务失败", zap.String("hash", avatar.Hash), zap.Error(err))
+		}
+	}
+
+	return avatar, nil
+}
+
+// Open 打开原图或缩略图，缩略图不存在时同步生成并写入存储后端
+// variant 为 original 或配置中的缩略图边长
+func (p *Pipeline) Open(ctx context.Context, hash, variant string) (io.ReadCloser, error) {
+	size, err := p.parseVariant(hash, variant)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, _, err := p.storage.Get(ctx, variantKey(hash, variant))
+	if err == nil || size == 0 || !errors.Is(err, storage.ErrNotFound) {
+		return rc, err
+	}
+
+	data, err := p.generate(ctx, hash, size)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// parseVariant 校验摘要和变体，返回缩略图边长，原图返回0
+func (p *Pipeline) parseVariant(hash, variant string) (int, error) {
+	if !validHash(hash) {
+		return 0, ErrInvalidHash
+	}
+	if variant == VariantOriginal {
+		return 0, nil
+	}
+	size, err := strconv.Atoi(variant)
+	if err != nil || !slices.Contains(p.conf.Sizes, size) {
+		return 0, ErrUnknownVariant
+	}
+	return size, nil
+}
+
+// GenerateVariants 生成全部已配置尺寸中尚不存在的缩略图
+func (p *Pipeline) GenerateVariants(ctx context.Context, hash string) error {
+	if !validHash(hash) {
+		return ErrInvalidHash
+	}
+
+	for _, size := range p.conf.Sizes {
+		if _, err := p.storage.Stat(ctx, variantKey(hash, strconv.Itoa(size))); err == nil {
+			continue
+		}
+		if _, err := p.generate(ctx, hash, size); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateJob 预生成缩略图任务的处理函数
+//
+//	jobqueue.Register(avatar.JobGenerateVariants, jobqueue.Handle(pipeline.GenerateJob))
+func (p *Pipeline) GenerateJob(ctx context.Context, payload GeneratePayload) error {
+	err := p.GenerateVariants(ctx, payload.Hash)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, ErrInvalidHash) {
+		return jobqueue.Permanent(err)
+	}
+	return err
+}
+
+// generate 生成单个缩略图，同一缩略图的并发请求只生成一次
+func (p *Pipeline) generate(ctx context.Context, hash string, size int) ([]byte, error) {
+	key := variantKey(hash, strconv.Itoa(size))
+
+	v, err, _ := p.group.Do(key, func() (any, error) {
+		// 生成结果由所有等待者共享，不随首个请求取消
+		ctx := context.WithoutCancel(ctx)
+
+		rc, _, err := p.storage.Get(ctx, variantKey(hash, VariantOriginal))
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+
+		img, format, err := imaging.Decode(rc)
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		if err := imaging.Encode(&buf, imaging.Thumbnail(img, size), format, p.conf.Quality); err != nil {
+			return nil, err
+		}
+
+		data := buf.Bytes()
+		contentType := "image/png"
+		if format == imaging.JPEG {
+			contentType = "image/jpeg"
+		}
+		if err := p.storage.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+			return nil, err
+		}
+
+		logger.WithContext(ctx, p.logger).Debug("已生成头像缩略图", zap.String("hash", hash), zap.Int("size", size))
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]byte), nil
+}
+
+// variantKey 原图或缩略图在存储后端中的对象键，按摘要前两位分目录
+func variantKey(hash, variant string) string {
+	return fmt.Sprintf("avatars/%s/%s/%s", hash[:2], hash, variant)
+}
+
+// validHash 校验摘要是否为64位小写十六进制
+func validHash(hash string) bool {
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+	for _, c := range hash {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// readLimited 读取全部内容，超过上限时返回 ErrTooLarge
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, ErrTooLarge
+	}
+	return data, nil
+}
+
+var (
+	_defaultPipeline   *Pipeline
+	_defaultPipelineMu sync.RWMutex
+)
+
+// Default 返回全局头像处理流水线，未启用头像上传时为nil
+func Default() *Pipeline {
+	_defaultPipelineMu.RLock()
+	defer _defaultPipelineMu.RUnlock()
+	return _defaultPipeline
+}
+
+// SetDefault 设置全局头像处理流水线
+func SetDefault(p *Pipeline) {
+	_defaultPipelineMu.Lock()
+	defer _defaultPipelineMu.Unlock()
+	_defaultPipeline = p
+}
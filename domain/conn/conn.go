@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"doghole/ent"
+	"doghole/logger"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -155,6 +156,8 @@ func Close() {
 
 	_writeConn = nil
 	_readConn = nil
+	_writeDB = nil
+	_readDB = nil
 	_initialized = false
 }
 
@@ -174,7 +177,7 @@ func WithTx(ctx context.Context, fn func(tx *ent.Tx) error) error {
 
 	if err := fn(tx); err != nil {
 		if rerr := tx.Rollback(); rerr != nil {
-			zap.L().Error("回滚事务失败", zap.Error(rerr))
+			logger.Ctx(ctx).Error("回滚事务失败", zap.Error(rerr))
 		}
 		return err
 	}
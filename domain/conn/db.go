@@ -0,0 +1,105 @@
+package conn
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"doghole/ent"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/pkg/errors"
+)
+
+var (
+	_writeDB *sql.DB
+	_readDB  *sql.DB
+
+	_migrationMu  sync.RWMutex
+	_migratedAt   time.Time
+	_migrationErr error
+)
+
+// DriverWrapper 包装ent驱动，用于在语句执行前后附加指标、追踪等逻辑，name 为 "writer" 或 "reader"
+type DriverWrapper func(name string, drv dialect.Driver) dialect.Driver
+
+// Open 打开数据库连接池，返回的连接池可以直接传给 InitializeDB
+func Open(driverName, dsn string) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "打开数据库连接失败: %s", driverName)
+	}
+	return db, nil
+}
+
+// InitializeDB 使用连接池初始化读写连接，并保留连接池用于健康检查和连接池统计
+// readDB 为nil或与 writeDB 相同时读写共用同一个连接，wrappers 按顺序包装读写连接的驱动
+func InitializeDB(ctx context.Context, dialectName string, writeDB, readDB *sql.DB, wrappers ...DriverWrapper) error {
+	if writeDB == nil {
+		return errors.New("必须提供写入数据库连接")
+	}
+	if readDB == nil {
+		readDB = writeDB
+	}
+
+	writer := ent.NewClient(ent.Driver(wrapDriver("writer", entsql.OpenDB(dialectName, writeDB), wrappers)))
+	reader := writer
+	if readDB != writeDB {
+		reader = ent.NewClient(ent.Driver(wrapDriver("reader", entsql.OpenDB(dialectName, readDB), wrappers)))
+	}
+	if err := Initialize(ctx, writer, reader); err != nil {
+		return err
+	}
+
+	_connMutex.Lock()
+	defer _connMutex.Unlock()
+	_writeDB = writeDB
+	_readDB = readDB
+	return nil
+}
+
+// wrapDriver 按顺序应用驱动包装
+func wrapDriver(name string, drv dialect.Driver, wrappers []DriverWrapper) dialect.Driver {
+	for _, wrap := range wrappers {
+		drv = wrap(name, drv)
+	}
+	return drv
+}
+
+// WriteDB 获取写入连接的连接池，未通过 InitializeDB 初始化时返回nil
+func WriteDB() *sql.DB {
+	_connMutex.RLock()
+	defer _connMutex.RUnlock()
+	return _writeDB
+}
+
+// ReadDB 获取读取连接的连接池，未通过 InitializeDB 初始化时返回nil
+func ReadDB() *sql.DB {
+	_connMutex.RLock()
+	defer _connMutex.RUnlock()
+	return _readDB
+}
+
+// Migrate 在写入连接上创建或更新数据库架构，并记录结果供健康检查使用
+func Migrate(ctx context.Context) error {
+	err := Writer().Schema.Create(ctx)
+	if err != nil {
+		err = errors.Wrap(err, "创建数据库架构失败")
+	}
+
+	_migrationMu.Lock()
+	defer _migrationMu.Unlock()
+	_migrationErr = err
+	if err == nil {
+		_migratedAt = time.Now()
+	}
+	return err
+}
+
+// MigrationStatus 返回最近一次成功迁移的时间和最近一次迁移的错误，尚未迁移时时间为零值
+func MigrationStatus() (time.Time, error) {
+	_migrationMu.RLock()
+	defer _migrationMu.RUnlock()
+	return _migratedAt, _migrationErr
+}
@@ -0,0 +1,249 @@
+package file
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strconv"
+	"strings"
+	"time"
+
+	"doghole/auth"
+	"doghole/ent"
+	"doghole/storage"
+	"github.com/gofiber/fiber/v3"
+	"github.com/pkg/errors"
+)
+
+// formField multipart上传时文件所在的表单字段
+const formField = "file"
+
+// BodyReader 返回请求体读取流的函数，流式上传的路由组应传入 server.RequestBodyStream
+type BodyReader func(c fiber.Ctx) io.Reader
+
+// handler 文件接口处理器
+type handler struct {
+	svc  *Service
+	body BodyReader
+}
+
+// RegisterRoutes 注册文件上传、下载和删除路由
+// 上传支持 multipart/form-data（文件位于 file 字段）和直接以请求体上传（文件名通过 name 查询参数传递）
+// 除通过签名链接下载外均需要认证，用户只能访问自己上传的文件
+func RegisterRoutes(router fiber.Router, svc *Service, body BodyReader) {
+	h := &handler{svc: svc, body: body}
+	router.Post("/", h.upload, auth.Required())
+	router.Get("/:id", h.get, auth.Required())
+	router.Delete("/:id", h.delete, auth.Required())
+	router.Post("/:id/url", h.signURL, auth.Required())
+	router.Get("/:id/content", h.download)
+}
+
+// upload 上传文件
+func (h *handler) upload(c fiber.Ctx) error {
+	body, name, err := OpenUpload(c, h.body(c))
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	f, err := h.svc.Upload(c.Context(), name, body, WithOwner(auth.UserID(c)))
+	if err != nil {
+		return httpError(err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": f})
+}
+
+// OpenUpload 从请求体中取出上传的文件内容和文件名
+// multipart/form-data 请求读取 file 字段，之前的普通字段会被跳过；其余请求以整个请求体作为文件内容，文件名取自 name 查询参数
+func OpenUpload(c fiber.Ctx, body io.Reader) (io.ReadCloser, string, error) {
+	name := c.Query("name")
+
+	mediaType, params, _ := mime.ParseMediaType(c.Get(fiber.HeaderContentType))
+	if mediaType != fiber.MIMEMultipartForm {
+		return io.NopCloser(body), name, nil
+	}
+
+	part, err := filePart(body, params["boundary"])
+	if err != nil {
+		return nil, "", err
+	}
+	if part.FileName() != "" {
+		name = part.FileName()
+	}
+	return part, name, nil
+}
+
+// filePart 在multipart流中定位文件字段
+func filePart(body io.Reader, boundary string) (*multipart.Part, error) {
+	if boundary == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "缺少multipart边界")
+	}
+
+	reader := multipart.NewReader(body, boundary)
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "缺少 file 字段")
+			}
+			var fe *fiber.Error
+			if errors.As(err, &fe) {
+				return nil, fe
+			}
+			return nil, fiber.NewError(fiber.StatusBadRequest, "multipart请求体格式错误")
+		}
+		if part.FormName() == formField {
+			return part, nil
+		}
+		part.Close()
+	}
+}
+
+// get 获取文件元数据
+func (h *handler) get(c fiber.Ctx) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	f, err := h.owned(c, id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": f})
+}
+
+// delete 删除文件
+func (h *handler) delete(c fiber.Ctx) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.owned(c, id); err != nil {
+		return err
+	}
+	if err := h.svc.Delete(c.Context(), id); err != nil {
+		return httpError(err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// signURL 签发限时下载链接，有效期通过 ttl 查询参数指定，如 ttl=1h
+func (h *handler) signURL(c fiber.Ctx) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	var ttl time.Duration
+	if raw := c.Query("ttl"); raw != "" {
+		if ttl, err = time.ParseDuration(raw); err != nil || ttl <= 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "无效的有效期")
+		}
+	}
+
+	if _, err := h.owned(c, id); err != nil {
+		return err
+	}
+
+	expires := time.Now().Add(h.svc.URLTTL(ttl)).Truncate(time.Second)
+	path := strings.TrimSuffix(c.Path(), "/url") + "/content"
+	query := h.svc.Signer().Query(strconv.Itoa(id), expires)
+
+	return c.JSON(fiber.Map{
+		"url":        c.BaseURL() + path + "?" + query.Encode(),
+		"expires_at": expires,
+	})
+}
+
+// download 通过签名链接下载文件内容
+func (h *handler) download(c fiber.Ctx) error {
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	expires := c.Query(QueryExpires)
+	if err := h.svc.Signer().Verify(strconv.Itoa(id), expires, c.Query(QuerySignature)); err != nil {
+		return fiber.NewError(fiber.StatusForbidden, err.Error())
+	}
+
+	f, rc, err := h.svc.Open(c.Context(), id)
+	if err != nil {
+		return httpError(err)
+	}
+
+	// 缓存时长不超过链接的剩余有效期
+	ts, _ := strconv.ParseInt(expires, 10, 64)
+	maxAge := max(ts-time.Now().Unix(), 0)
+
+	c.Set(fiber.HeaderContentType, f.ContentType)
+	c.Set(fiber.HeaderContentDisposition, contentDisposition(f))
+	c.Set(fiber.HeaderETag, `"`+f.Checksum+`"`)
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("private, max-age=%d", maxAge))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Set(fiber.HeaderContentSecurityPolicy, "default-src 'none'; sandbox")
+
+	return c.SendStream(rc, int(f.Size))
+}
+
+// owned 获取当前用户上传的文件，文件不存在或属于其他用户时都返回404，避免泄露文件是否存在
+func (h *handler) owned(c fiber.Ctx, id int) (*ent.File, error) {
+	f, err := h.svc.Get(c.Context(), id)
+	if err != nil {
+		return nil, httpError(err)
+	}
+	if f.OwnerID == "" || f.OwnerID != auth.UserID(c) {
+		return nil, fiber.ErrNotFound
+	}
+	return f, nil
+}
+
+// contentDisposition 图片、音视频和PDF在浏览器中直接展示，其余类型作为附件下载
+func contentDisposition(f *ent.File) string {
+	disposition := "attachment"
+	mediaType, _, _ := mime.ParseMediaType(f.ContentType)
+	if strings.HasPrefix(mediaType, "image/") || strings.HasPrefix(mediaType, "video/") ||
+		strings.HasPrefix(mediaType, "audio/") || mediaType == "application/pdf" {
+		disposition = "inline"
+	}
+	if f.Name == "" {
+		return disposition
+	}
+	// 非ASCII文件名按 RFC 2231 编码
+	if v := mime.FormatMediaType(disposition, map[string]string{"filename": f.Name}); v != "" {
+		return v
+	}
+	return disposition
+}
+
+// paramID 解析路径中的文件ID
+func paramID(c fiber.Ctx) (int, error) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil || id <= 0 {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "无效的ID")
+	}
+	return id, nil
+}
+
+// httpError 将文件服务的错误转换为对应的HTTP状态码
+func httpError(err error) error {
+	var fe *fiber.Error
+	switch {
+	case errors.As(err, &fe):
+		return fe
+	case errors.Is(err, ErrEmpty):
+		return fiber.NewError(fiber.StatusBadRequest, ErrEmpty.Error())
+	case errors.Is(err, ErrTooLarge):
+		return fiber.NewError(fiber.StatusRequestEntityTooLarge, ErrTooLarge.Error())
+	case errors.Is(err, ErrTypeNotAllowed):
+		return fiber.NewError(fiber.StatusUnsupportedMediaType, err.Error())
+	case ent.IsNotFound(err), errors.Is(err, storage.ErrNotFound):
+		return fiber.ErrNotFound
+	default:
+		return err
+	}
+}
@@ -0,0 +1,249 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"doghole/auth"
+	"doghole/config"
+	"doghole/ent/enttest"
+	"doghole/storage"
+	"github.com/gofiber/fiber/v3"
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// headerTestUser 测试中代替认证中间件传递用户ID的请求头
+const headerTestUser = "X-Test-User"
+
+// newTestApp 使用内存S3和SQLite创建挂载了文件路由的应用
+func newTestApp(t *testing.T) (*fiber.App, *s3mem.Backend) {
+	t.Helper()
+
+	backend := s3mem.New()
+	if err := backend.CreateBucket("files"); err != nil {
+		t.Fatal(err)
+	}
+	fake := httptest.NewServer(decodeChunked(gofakes3.New(backend).Server()))
+	t.Cleanup(fake.Close)
+
+	store, err := storage.NewS3Storage(config.S3StorageConfig{
+		Endpoint:  strings.TrimPrefix(fake.URL, "http://"),
+		Region:    "us-east-1",
+		Bucket:    "files",
+		AccessKey: "test",
+		SecretKey: "test",
+		PathStyle: true,
+		Prefix:    "uploads",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	t.Cleanup(func() { client.Close() })
+
+	svc, err := NewService(client, store, config.StorageConfig{Backend: "s3", SigningKey: "test-signing-key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error {
+		if id := c.Get(headerTestUser); id != "" {
+			c.Locals(auth.LocalsUserID, id)
+		}
+		return c.Next()
+	})
+	RegisterRoutes(app.Group("/files"), svc, func(c fiber.Ctx) io.Reader {
+		return bytes.NewReader(c.Body())
+	})
+	return app, backend
+}
+
+// decodeChunked 解码分片上传请求中按 aws-chunked 编码的请求体
+// 客户端通过HTTP连接上传时使用流式签名，gofakes3 只在单次上传时解码，分片上传时会原样保存
+func decodeChunked(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Content-Sha256") != "STREAMING-AWS4-HMAC-SHA256-PAYLOAD" || r.URL.Query().Get("partNumber") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var body bytes.Buffer
+		br := bufio.NewReader(r.Body)
+		for {
+			line, err := br.ReadString('\n')
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			size, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+			n, err := strconv.ParseInt(size, 16, 64)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if n == 0 {
+				break
+			}
+			if _, err := io.CopyN(&body, br, n+2); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			body.Truncate(body.Len() - 2)
+		}
+
+		r.Body = io.NopCloser(&body)
+		r.ContentLength = int64(body.Len())
+		r.Header.Set("Content-Length", strconv.Itoa(body.Len()))
+		r.Header.Set("X-Amz-Content-Sha256", "UNSIGNED-PAYLOAD")
+		next.ServeHTTP(w, r)
+	})
+}
+
+// do 以指定用户发送请求，user 为空表示未认证
+func do(t *testing.T, app *fiber.App, method, target, user string, body io.Reader) (*http.Response, []byte) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, body)
+	if user != "" {
+		req.Header.Set(headerTestUser, user)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, data
+}
+
+// upload 以指定用户上传文件，返回文件ID
+func upload(t *testing.T, app *fiber.App, user, name, content string) string {
+	t.Helper()
+	resp, body := do(t, app, fiber.MethodPost, "/files?name="+url.QueryEscape(name), user, strings.NewReader(content))
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Fatalf("上传返回 %d: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Data struct {
+			ID      int    `json:"id"`
+			OwnerID string `json:"owner_id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Data.OwnerID != user {
+		t.Fatalf("文件所有者 = %q，期望 %q", result.Data.OwnerID, user)
+	}
+	return strconv.Itoa(result.Data.ID)
+}
+
+func TestUploadRequiresAuth(t *testing.T) {
+	app, backend := newTestApp(t)
+
+	resp, _ := do(t, app, fiber.MethodPost, "/files?name=a.txt", "", strings.NewReader("hello"))
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Fatalf("未认证上传返回 %d", resp.StatusCode)
+	}
+
+	objects, err := backend.ListBucket("files", nil, gofakes3.ListBucketPage{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects.Contents) != 0 {
+		t.Fatalf("未认证上传不应写入存储，实际有 %d 个对象", len(objects.Contents))
+	}
+}
+
+func TestOwnerAccess(t *testing.T) {
+	app, _ := newTestApp(t)
+	id := upload(t, app, "alice", "a.txt", "hello")
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		user   string
+		status int
+	}{
+		{"未认证获取", fiber.MethodGet, "/files/" + id, "", fiber.StatusUnauthorized},
+		{"其他用户获取", fiber.MethodGet, "/files/" + id, "bob", fiber.StatusNotFound},
+		{"其他用户签发链接", fiber.MethodPost, "/files/" + id + "/url", "bob", fiber.StatusNotFound},
+		{"其他用户删除", fiber.MethodDelete, "/files/" + id, "bob", fiber.StatusNotFound},
+		{"所有者获取", fiber.MethodGet, "/files/" + id, "alice", fiber.StatusOK},
+		{"不存在的文件", fiber.MethodGet, "/files/999", "alice", fiber.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := do(t, app, tt.method, tt.path, tt.user, nil)
+			if resp.StatusCode != tt.status {
+				t.Fatalf("返回 %d，期望 %d: %s", resp.StatusCode, tt.status, body)
+			}
+		})
+	}
+}
+
+func TestSignedDownloadAndDelete(t *testing.T) {
+	app, backend := newTestApp(t)
+	id := upload(t, app, "alice", "a.txt", "hello")
+
+	resp, body := do(t, app, fiber.MethodPost, "/files/"+id+"/url?ttl=1m", "alice", nil)
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("签发链接返回 %d: %s", resp.StatusCode, body)
+	}
+	var signed struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(body, &signed); err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(signed.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 签名链接不需要认证
+	resp, body = do(t, app, fiber.MethodGet, u.RequestURI(), "", nil)
+	if resp.StatusCode != fiber.StatusOK || string(body) != "hello" {
+		t.Fatalf("下载返回 %d: %s", resp.StatusCode, body)
+	}
+
+	resp, _ = do(t, app, fiber.MethodGet, "/files/"+id+"/content", "alice", nil)
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Fatalf("未签名下载返回 %d", resp.StatusCode)
+	}
+
+	resp, _ = do(t, app, fiber.MethodDelete, "/files/"+id, "alice", nil)
+	if resp.StatusCode != fiber.StatusNoContent {
+		t.Fatalf("删除返回 %d", resp.StatusCode)
+	}
+
+	objects, err := backend.ListBucket("files", nil, gofakes3.ListBucketPage{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects.Contents) != 0 {
+		t.Fatalf("删除后存储中仍有 %d 个对象", len(objects.Contents))
+	}
+
+	resp, _ = do(t, app, fiber.MethodGet, u.RequestURI(), "", nil)
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Fatalf("删除后下载返回 %d", resp.StatusCode)
+	}
+}
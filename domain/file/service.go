@@ -0,0 +1,322 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"doghole/config"
+	"doghole/ent"
+	"doghole/logger"
+	"doghole/storage"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrEmpty 上传的文件为空
+	ErrEmpty = errors.New("文件不能为空")
+	// ErrTooLarge 文件超过大小上限
+	ErrTooLarge = errors.New("文件过大")
+	// ErrTypeNotAllowed 文件类型不在允许列表中
+	ErrTypeNotAllowed = errors.New("不允许的文件类型")
+)
+
+// sniffLen 识别MIME类型需要读取的字节数
+const sniffLen = 512
+
+// maxNameLen 文件名的最大字符数
+const maxNameLen = 255
+
+// Service 文件上传与下载服务
+type Service struct {
+	client  *ent.Client
+	storage storage.Storage
+	backend string
+	conf    config.StorageConfig
+	signer  *Signer
+	logger  *zap.Logger
+}
+
+// NewService 创建文件服务
+func NewService(client *ent.Client, store storage.Storage, conf config.StorageConfig, options ...func(*Service)) (*Service, error) {
+	if conf.Backend == "" {
+		conf.Backend = "local"
+	}
+
+	signer, err := NewSigner(conf.SigningKey)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Service{
+		client:  client,
+		storage: store,
+		backend: conf.Backend,
+		conf:    conf,
+		signer:  signer,
+		logger:  zap.L(),
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	if conf.SigningKey == "" {
+		s.logger.Warn("未配置下载链接签名密钥，使用随机密钥，重启或多实例部署时已签发的链接会失效")
+	}
+
+	return s, nil
+}
+
+// WithLogger 设置日志记录器
+func WithLogger(logger *zap.Logger) func(*Service) {
+	return func(s *Service) {
+		s.logger = logger
+	}
+}
+
+// Storage 返回文件服务使用的存储后端
+func (s *Service) Storage() storage.Storage {
+	return s.storage
+}
+
+// Signer 返回下载链接签名器
+func (s *Service) Signer() *Signer {
+	return s.signer
+}
+
+// UploadOption 上传选项
+type UploadOption func(*uploadOptions)
+
+// uploadOptions 上传选项
+type uploadOptions struct {
+	owner        string
+	maxSize      int64
+	allowedTypes []string
+}
+
+// WithOwner 设置上传者的用户ID
+func WithOwner(id string) UploadOption {
+	return func(o *uploadOptions) {
+		o.owner = id
+	}
+}
+
+// WithMaxSize 覆盖配置中的文件大小上限
+func WithMaxSize(n int64) UploadOption {
+	return func(o *uploadOptions) {
+		o.maxSize = n
+	}
+}
+
+// WithAllowedTypes 覆盖配置中允许的MIME类型
+func WithAllowedTypes(types ...string) UploadOption {
+	return func(o *uploadOptions) {
+		o.allowedTypes = types
+	}
+}
+
+// Upload 以流的方式保存文件并记录元数据
+// MIME类型根据文件内容识别，不信任客户端声明的类型；超过大小上限时中止写入，不会留下不完整的对象
+func (s *Service) Upload(ctx context.Context, name string, r io.Reader, options ...UploadOption) (*ent.File, error) {
+	opts := uploadOptions{
+		maxSize:      s.conf.MaxSize,
+		allowedTypes: s.conf.AllowedTypes,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, ErrEmpty
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	if !typeAllowed(contentType, opts.allowedTypes) {
+		return nil, errors.Wrap(ErrTypeNotAllowed, contentType)
+	}
+
+	hash := sha256.New()
+	counter := &countingReader{
+		r:   io.TeeReader(io.MultiReader(bytes.NewReader(head), r), hash),
+		max: opts.maxSize,
+	}
+
+	key := NewKey(time.Now())
+	if err := s.storage.Put(ctx, key, counter, -1, contentType); err != nil {
+		// 存储后端可能不保留读取器返回的错误，以已读取的字节数为准
+		if counter.exceeded() {
+			return nil, ErrTooLarge
+		}
+		return nil, err
+	}
+
+	f, err := s.client.File.Create().
+		SetKey(key).
+		SetBackend(s.backend).
+		SetName(cleanName(name)).
+		SetContentType(contentType).
+		SetSize(counter.n).
+		SetChecksum(hex.EncodeToString(hash.Sum(nil))).
+		SetOwnerID(opts.owner).
+		Save(ctx)
+	if err != nil {
+		// 元数据写入失败时删除已上传的对象
+		if derr := s.storage.Delete(context.WithoutCancel(ctx), key); derr != nil {
+			logger.WithContext(ctx, s.logger).Error("清理上传对象失败", zap.String("key", key), zap.Error(derr))
+		}
+		return nil, errors.Wrap(err, "保存文件记录失败")
+	}
+
+	logger.WithContext(ctx, s.logger).Info("文件已上传",
+		zap.Int("id", f.ID),
+		zap.String("key", f.Key),
+		zap.String("content_type", f.ContentType),
+		zap.Int64("size", f.Size),
+	)
+	return f, nil
+}
+
+// Get 获取文件元数据
+func (s *Service) Get(ctx context.Context, id int) (*ent.File, error) {
+	return s.client.File.Get(ctx, id)
+}
+
+// Open 获取文件元数据并打开内容读取流，调用方负责关闭
+func (s *Service) Open(ctx context.Context, id int) (*ent.File, io.ReadCloser, error) {
+	f, err := s.client.File.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rc, _, err := s.storage.Get(ctx, f.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, rc, nil
+}
+
+// Delete 删除文件记录和存储对象
+// 先删除记录，对象删除失败只会留下无引用的对象，不会出现指向不存在对象的记录
+func (s *Service) Delete(ctx context.Context, id int) error {
+	f, err := s.client.File.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.client.File.DeleteOne(f).Exec(ctx); err != nil {
+		return err
+	}
+	if err := s.storage.Delete(ctx, f.Key); err != nil {
+		logger.WithContext(ctx, s.logger).Error("删除存储对象失败", zap.String("key", f.Key), zap.Error(err))
+	}
+	return nil
+}
+
+// URLTTL 将请求的有效期限制在配置范围内，0表示使用默认有效期
+func (s *Service) URLTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		ttl = s.conf.URLTTL
+	}
+	if s.conf.MaxURLTTL > 0 && ttl > s.conf.MaxURLTTL {
+		ttl = s.conf.MaxURLTTL
+	}
+	return ttl
+}
+
+// NewKey 生成按日期分目录的对象键
+func NewKey(now time.Time) string {
+	return now.UTC().Format("2006/01/02") + "/" + uuid.NewString()
+}
+
+// typeAllowed 判断MIME类型是否在允许列表中，支持 image/* 形式的通配
+func typeAllowed(contentType string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, a := range allowed {
+		a = strings.ToLower(strings.TrimSpace(a))
+		if a == mediaType || a == "*/*" {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(a, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanName 去除文件名中的路径并限制长度
+func cleanName(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = strings.TrimSpace(filepath.Base("/" + name))
+	if name == "/" || name == "." {
+		return ""
+	}
+	name = strings.ToValidUTF8(name, "_")
+	if r := []rune(name); len(r) > maxNameLen {
+		name = string(r[:maxNameLen])
+	}
+	return name
+}
+
+// countingReader 统计读取字节数并在超过上限时返回 ErrTooLarge 的读取器
+type countingReader struct {
+	r   io.Reader
+	n   int64
+	max int64
+}
+
+// Read 实现 io.Reader 接口
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	if c.exceeded() {
+		return n, ErrTooLarge
+	}
+	return n, err
+}
+
+// exceeded 判断是否已超过上限
+func (c *countingReader) exceeded() bool {
+	return c.max > 0 && c.n > c.max
+}
+
+var (
+	_defaultService   *Service
+	_defaultServiceMu sync.RWMutex
+)
+
+// Default 返回全局文件服务，未启用文件存储时为nil
+func Default() *Service {
+	_defaultServiceMu.RLock()
+	defer _defaultServiceMu.RUnlock()
+	return _defaultService
+}
+
+// SetDefault 设置全局文件服务
+func SetDefault(s *Service) {
+	_defaultServiceMu.Lock()
+	defer _defaultServiceMu.Unlock()
+	_defaultService = s
+}
@@ -0,0 +1,317 @@
+package notification
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"doghole/config"
+	"doghole/domain/webhook"
+	"doghole/ent"
+	"doghole/ent/notificationpreference"
+	"doghole/eventbus"
+	"doghole/jobqueue"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// JobSendEmail 发送通知邮件的后台任务类型
+const JobSendEmail = "notification.email"
+
+// TopicAll 偏好设置中表示全部主题的通配符
+const TopicAll = "*"
+
+// 通知渠道
+const (
+	ChannelInApp   = notificationpreference.ChannelInApp
+	ChannelEmail   = notificationpreference.ChannelEmail
+	ChannelWebhook = notificationpreference.ChannelWebhook
+)
+
+// Channels 全部通知渠道
+var Channels = []notificationpreference.Channel{ChannelInApp, ChannelEmail, ChannelWebhook}
+
+// Message 待发送的通知
+type Message struct {
+	UserID string         // 接收者
+	Topic  string         // 主题，对应模板文件名，如 export.ready
+	Data   map[string]any // 模板数据，同时随站内通知和Webhook一起保存和推送
+	Locale string         // 语言，为空时通过 Directory 查询
+	Email  string         // 收件地址，为空时通过 Directory 查询
+}
+
+// Recipient 接收者的联系方式和语言偏好
+type Recipient struct {
+	Email  string
+	Locale string
+}
+
+// Directory 查询接收者信息，由业务方提供
+type Directory interface {
+	Lookup(ctx context.Context, userID string) (Recipient, error)
+}
+
+// DirectoryFunc 函数形式的 Directory
+type DirectoryFunc func(ctx context.Context, userID string) (Recipient, error)
+
+// Lookup 调用函数本身
+func (f DirectoryFunc) Lookup(ctx context.Context, userID string) (Recipient, error) {
+	return f(ctx, userID)
+}
+
+// WebhookPayload 推送到用户Webhook的通知内容，事件类型为 "notification." + 主题
+type WebhookPayload struct {
+	ID     int            `json:"id,omitempty"` // 站内通知ID，未启用站内渠道时为空
+	Topic  string         `json:"topic"`
+	Locale string         `json:"locale"`
+	Title  string         `json:"title"`
+	Body   string         `json:"body"`
+	Data   map[string]any `json:"data,omitempty"`
+}
+
+// Center 通知中心
+// 按用户的渠道偏好渲染模板，写入站内通知，并通过后台任务发送邮件、通过用户订阅的Webhook推送
+type Center struct {
+	client    *ent.Client
+	conf      config.NotificationConfig
+	templates *Templates
+	mailer    Mailer
+	directory Directory
+	logger    *zap.Logger
+}
+
+// NewCenter 创建通知中心，加载模板并根据配置创建邮件发送器
+func NewCenter(client *ent.Client, conf config.NotificationConfig, options ...func(*Center)) (*Center, error) {
+	for _, ch := range conf.DefaultChannels {
+		if err := notificationpreference.ChannelValidator(notificationpreference.Channel(ch)); err != nil {
+			return nil, errors.Errorf("未知的默认通知渠道: %s", ch)
+		}
+	}
+
+	c := &Center{
+		client: client,
+		conf:   conf,
+		logger: zap.L(),
+	}
+	for _, option := range options {
+		option(c)
+	}
+
+	templates, err := LoadTemplates(conf.TemplateDir, conf.DefaultLocale)
+	if err != nil {
+		return nil, err
+	}
+	c.templates = templates
+
+	if c.mailer == nil {
+		if c.mailer, err = NewMailer(conf.Email, c.logger); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// WithDirectory 设置接收者信息的查询方式，未设置时只能使用 Message 中显式指定的语言和收件地址
+func WithDirectory(directory Directory) func(*Center) {
+	return func(c *Center) {
+		c.directory = directory
+	}
+}
+
+// WithMailer 设置邮件发送器，默认根据配置创建
+func WithMailer(mailer Mailer) func(*Center) {
+	return func(c *Center) {
+		c.mailer = mailer
+	}
+}
+
+// WithLogger 设置日志记录器
+func WithLogger(logger *zap.Logger) func(*Center) {
+	return func(c *Center) {
+		c.logger = logger
+	}
+}
+
+// Notify 发送通知，返回创建的站内通知，未启用站内渠道时返回nil
+// 站内通知、邮件任务和Webhook投递记录在同一事务中创建，邮件和Webhook由后台异步发送
+func (c *Center) Notify(ctx context.Context, msg Message) (*ent.Notification, error) {
+	if msg.UserID == "" || msg.Topic == "" {
+		return nil, errors.New("通知的接收者和主题不能为空")
+	}
+
+	channels, err := c.Channels(ctx, msg.UserID, msg.Topic)
+	if err != nil {
+		return nil, err
+	}
+	if len(channels) == 0 {
+		return nil, nil
+	}
+
+	if c.directory != nil && (msg.Locale == "" || msg.Email == "") {
+		recipient, err := c.directory.Lookup(ctx, msg.UserID)
+		if err != nil {
+			return nil, errors.Wrap(err, "查询通知接收者失败")
+		}
+		if msg.Locale == "" {
+			msg.Locale = recipient.Locale
+		}
+		if msg.Email == "" {
+			msg.Email = recipient.Email
+		}
+	}
+
+	rendered, err := c.templates.Render(msg.Topic, msg.Locale, msg.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := c.client.Tx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "开启事务失败")
+	}
+
+	n, err := c.deliver(ctx, tx, msg, rendered, channels)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "提交事务失败")
+	}
+	if n != nil {
+		n = n.Unwrap()
+	}
+	return n, nil
+}
+
+// Subscribe 订阅事件总线中的 Message 事件并异步发送通知，返回取消订阅的函数
+// 其他模块无需持有通知中心，发布 Message 事件即可发送通知；
+// 在业务事务中发布时使用 eventbus.AfterCommit，事务回滚时不会发出通知
+//
+//	eventbus.Publish(ctx, eventbus.Default(), notification.Message{UserID: id, Topic: "export.ready"}, eventbus.AfterCommit(tx))
+func (c *Center) Subscribe(bus *eventbus.Bus) func() {
+	return eventbus.Subscribe(bus, func(ctx context.Context, msg Message) error {
+		_, err := c.Notify(ctx, msg)
+		return err
+	}, eventbus.Async(), eventbus.Named("notification"))
+}
+
+// deliver 在事务中写入各渠道的通知
+func (c *Center) deliver(ctx context.Context, tx *ent.Tx, msg Message, rendered Rendered, channels []notificationpreference.Channel) (*ent.Notification, error) {
+	var n *ent.Notification
+	if slices.Contains(channels, ChannelInApp) {
+		var err error
+		n, err = tx.Notification.Create().
+			SetUserID(msg.UserID).
+			SetTopic(msg.Topic).
+			SetLocale(rendered.Locale).
+			SetTitle(rendered.Title).
+			SetBody(rendered.Body).
+			SetData(msg.Data).
+			Save(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "创建站内通知失败")
+		}
+	}
+
+	if slices.Contains(channels, ChannelEmail) {
+		if msg.Email == "" {
+			c.logger.Debug("接收者没有邮箱地址，跳过邮件通知", zap.String("user_id", msg.UserID), zap.String("topic", msg.Topic))
+		} else {
+			email := Email{To: msg.Email, Subject: rendered.EmailSubject, Body: rendered.EmailBody}
+			if _, err := jobqueue.EnqueueWith(ctx, tx.Job, JobSendEmail, email); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if slices.Contains(channels, ChannelWebhook) {
+		payload := WebhookPayload{
+			Topic:  msg.Topic,
+			Locale: rendered.Locale,
+			Title:  rendered.Title,
+			Body:   rendered.Body,
+			Data:   msg.Data,
+		}
+		createdAt := time.Now()
+		if n != nil {
+			payload.ID = n.ID
+			createdAt = n.CreatedAt
+		}
+		if err := webhook.EnqueueFor(ctx, tx.Client(), msg.UserID, "notification."+msg.Topic, payload, createdAt); err != nil {
+			return nil, err
+		}
+	}
+
+	return n, nil
+}
+
+// Channels 返回用户对该主题启用的渠道
+// 优先使用针对该主题的偏好，其次是全部主题（*）的偏好，都未设置时使用配置中的默认渠道
+func (c *Center) Channels(ctx context.Context, userID, topic string) ([]notificationpreference.Channel, error) {
+	prefs, err := c.client.NotificationPreference.Query().
+		Where(
+			notificationpreference.UserID(userID),
+			notificationpreference.TopicIn(topic, TopicAll),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "查询通知偏好失败")
+	}
+
+	channels := make([]notificationpreference.Channel, 0, len(Channels))
+	for _, ch := range Channels {
+		enabled := slices.Contains(c.conf.DefaultChannels, string(ch))
+		specific := false
+		for _, p := range prefs {
+			if p.Channel != ch {
+				continue
+			}
+			if p.Topic == topic {
+				enabled, specific = p.Enabled, true
+			} else if !specific {
+				enabled = p.Enabled
+			}
+		}
+		if enabled {
+			channels = append(channels, ch)
+		}
+	}
+	return channels, nil
+}
+
+// SendEmail 后台任务处理函数，发送通知邮件
+func (c *Center) SendEmail(ctx context.Context, email Email) error {
+	if err := c.mailer.Send(ctx, email); err != nil {
+		if errors.Is(err, errInvalidAddress) {
+			return jobqueue.Permanent(err)
+		}
+		return err
+	}
+	return nil
+}
+
+// DefaultChannels 配置中的默认渠道
+func (c *Center) DefaultChannels() []string {
+	return c.conf.DefaultChannels
+}
+
+var (
+	_defaultCenter   *Center
+	_defaultCenterMu sync.RWMutex
+)
+
+// Default 返回全局通知中心，未启用通知中心时为nil
+func Default() *Center {
+	_defaultCenterMu.RLock()
+	defer _defaultCenterMu.RUnlock()
+	return _defaultCenter
+}
+
+// SetDefault 设置全局通知中心
+func SetDefault(c *Center) {
+	_defaultCenterMu.Lock()
+	defer _defaultCenterMu.Unlock()
+	_defaultCenter = c
+}
@@ -0,0 +1,131 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"doghole/config"
+	"doghole/ent"
+	"doghole/ent/enttest"
+	"doghole/ent/notification"
+	"doghole/ent/notificationpreference"
+	"doghole/eventbus"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+// newCenter 使用内存数据库和内置模板创建通知中心
+func newCenter(t *testing.T, conf config.NotificationConfig, options ...func(*Center)) (*Center, *ent.Client) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", strings.ReplaceAll(t.Name(), "/", "_")))
+	t.Cleanup(func() { client.Close() })
+
+	if conf.DefaultLocale == "" {
+		conf.DefaultLocale = "zh-CN"
+	}
+	center, err := NewCenter(client, conf, append([]func(*Center){WithLogger(zap.NewNop())}, options...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return center, client
+}
+
+func TestSubscribe(t *testing.T) {
+	ctx := context.Background()
+	center, client := newCenter(t, config.NotificationConfig{DefaultChannels: []string{"in_app"}})
+
+	bus := eventbus.New(eventbus.WithLogger(zap.NewNop()))
+	unsubscribe := center.Subscribe(bus)
+
+	// 业务事务回滚时不发送通知
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := Message{UserID: "alice", Topic: "export.ready", Data: map[string]any{"name": "orders.csv", "expires_in": "24小时", "url": "https://example.com/d/1"}}
+	if err := eventbus.Publish(ctx, bus, msg, eventbus.AfterCommit(tx)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err = client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := eventbus.Publish(ctx, bus, msg, eventbus.AfterCommit(tx)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	notifications := client.Notification.Query().Where(notification.UserID("alice")).AllX(ctx)
+	if len(notifications) != 1 {
+		t.Fatalf("站内通知数量 = %d", len(notifications))
+	}
+	if n := notifications[0]; n.Title != "导出已完成" || !strings.Contains(n.Body, "orders.csv") {
+		t.Fatalf("站内通知 = %q %q", n.Title, n.Body)
+	}
+
+	// 取消订阅后不再发送
+	unsubscribe()
+	eventbus.Publish(ctx, bus, msg)
+	bus.Wait(ctx)
+	if n := client.Notification.Query().CountX(ctx); n != 1 {
+		t.Fatalf("取消订阅后站内通知数量 = %d", n)
+	}
+}
+
+func TestChannels(t *testing.T) {
+	ctx := context.Background()
+	center, client := newCenter(t, config.NotificationConfig{DefaultChannels: []string{"in_app", "email"}})
+
+	// prefer 写入用户的渠道偏好
+	prefer := func(userID string, channel notificationpreference.Channel, topic string, enabled bool) {
+		client.NotificationPreference.Create().
+			SetUserID(userID).
+			SetChannel(channel).
+			SetTopic(topic).
+			SetEnabled(enabled).
+			ExecX(ctx)
+	}
+	// 全部主题关闭邮件、开启Webhook，单独为 export.ready 开启邮件
+	prefer("alice", ChannelEmail, TopicAll, false)
+	prefer("alice", ChannelWebhook, TopicAll, true)
+	prefer("alice", ChannelEmail, "export.ready", true)
+	// 针对主题的关闭优先于全部主题的开启
+	prefer("bob", ChannelInApp, "login.new_device", false)
+	prefer("bob", ChannelInApp, TopicAll, true)
+
+	tests := []struct {
+		name   string
+		userID string
+		topic  string
+		want   []notificationpreference.Channel
+	}{
+		{"未设置偏好时使用默认渠道", "carol", "export.ready", []notificationpreference.Channel{ChannelInApp, ChannelEmail}},
+		{"全部主题的偏好覆盖默认渠道", "alice", "login.new_device", []notificationpreference.Channel{ChannelInApp, ChannelWebhook}},
+		{"主题偏好优先于全部主题", "alice", "export.ready", []notificationpreference.Channel{ChannelInApp, ChannelEmail, ChannelWebhook}},
+		{"主题偏好关闭", "bob", "login.new_device", []notificationpreference.Channel{ChannelEmail}},
+		{"其他主题不受影响", "bob", "export.ready", []notificationpreference.Channel{ChannelInApp, ChannelEmail}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := center.Channels(ctx, tt.userID, tt.topic)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("Channels = %v，期望 %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package notification
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"doghole/auth"
+	"doghole/domain/conn"
+	"doghole/ent"
+	"doghole/ent/notification"
+	"doghole/ent/notificationpreference"
+	"github.com/gofiber/fiber/v3"
+	"github.com/pkg/errors"
+)
+
+// preferenceRequest 更新通知偏好的请求体，enabled 为 null 时删除该设置，恢复为上一级的偏好
+type preferenceRequest struct {
+	Preferences []struct {
+		Channel notificationpreference.Channel `json:"channel"`
+		Topic   string                         `json:"topic"`
+		Enabled *bool                          `json:"enabled"`
+	} `json:"preferences"`
+}
+
+// handler 通知接口处理器
+type handler struct {
+	center *Center
+}
+
+// RegisterRoutes 注册当前用户的通知收件箱和偏好设置路由，用户取自认证中间件设置的 userid
+func RegisterRoutes(router fiber.Router, center *Center) {
+	h := &handler{center: center}
+	router.Get("/", h.list)
+	router.Get("/unread-count", h.unreadCount)
+	router.Post("/read-all", h.readAll)
+	router.Post("/:id/read", h.markRead)
+	router.Post("/:id/unread", h.markUnread)
+	router.Delete("/:id", h.delete)
+	router.Get("/preferences", h.getPreferences)
+	router.Put("/preferences", h.putPreferences)
+}
+
+// list 分页列出通知，按时间倒序，支持 unread=true 和 topic 过滤
+func (h *handler) list(c fiber.Ctx) error {
+	userID, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	limit := fiber.Query(c, "limit", 50)
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	offset := max(fiber.Query(c, "offset", 0), 0)
+
+	query := conn.Reader().Notification.Query().
+		Where(notification.UserID(userID))
+	if fiber.Query(c, "unread", false) {
+		query.Where(notification.ReadAtIsNil())
+	}
+	if topic := c.Query("topic"); topic != "" {
+		query.Where(notification.Topic(topic))
+	}
+
+	notifications, err := query.
+		Order(ent.Desc(notification.FieldCreatedAt), ent.Desc(notification.FieldID)).
+		Limit(limit).
+		Offset(offset).
+		All(c.Context())
+	if err != nil {
+		return err
+	}
+
+	unread, err := countUnread(c, userID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": notifications, "unread_count": unread})
+}
+
+// unreadCount 返回未读通知数量
+func (h *handler) unreadCount(c fiber.Ctx) error {
+	userID, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	unread, err := countUnread(c, userID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"count": unread})
+}
+
+// markRead 标记为已读，已读的通知保留原有的阅读时间
+func (h *handler) markRead(c fiber.Ctx) error {
+	return h.setRead(c, true)
+}
+
+// markUnread 标记为未读
+func (h *handler) markUnread(c fiber.Ctx) error {
+	return h.setRead(c, false)
+}
+
+// setRead 修改当前用户一条通知的已读状态
+func (h *handler) setRead(c fiber.Ctx, read bool) error {
+	userID, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	n, err := conn.Writer().Notification.Query().
+		Where(notification.ID(id), notification.UserID(userID)).
+		Only(c.Context())
+	if err != nil {
+		return notFound(err)
+	}
+
+	switch {
+	case read && n.ReadAt == nil:
+		n, err = n.Update().SetReadAt(time.Now()).Save(c.Context())
+	case !read && n.ReadAt != nil:
+		n, err = n.Update().ClearReadAt().Save(c.Context())
+	}
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": n})
+}
+
+// readAll 将当前用户的全部未读通知标记为已读
+func (h *handler) readAll(c fiber.Ctx) error {
+	userID, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	n, err := conn.Writer().Notification.Update().
+		Where(notification.UserID(userID), notification.ReadAtIsNil()).
+		SetReadAt(time.Now()).
+		Save(c.Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"updated": n})
+}
+
+// delete 删除当前用户的一条通知
+func (h *handler) delete(c fiber.Ctx) error {
+	userID, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+	id, err := paramID(c)
+	if err != nil {
+		return err
+	}
+
+	n, err := conn.Writer().Notification.Delete().
+		Where(notification.ID(id), notification.UserID(userID)).
+		Exec(c.Context())
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fiber.ErrNotFound
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// getPreferences 返回当前用户已保存的偏好和配置中的默认渠道
+func (h *handler) getPreferences(c fiber.Ctx) error {
+	userID, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	prefs, err := conn.Reader().NotificationPreference.Query().
+		Where(notificationpreference.UserID(userID)).
+		Order(ent.Asc(notificationpreference.FieldTopic), ent.Asc(notificationpreference.FieldChannel)).
+		All(c.Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": prefs, "default_channels": h.center.DefaultChannels()})
+}
+
+// putPreferences 批量设置当前用户的偏好，topic 为空或 * 表示全部主题
+func (h *handler) putPreferences(c fiber.Ctx) error {
+	userID, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	var req preferenceRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "请求体格式错误")
+	}
+	for i := range req.Preferences {
+		p := &req.Preferences[i]
+		if err := notificationpreference.ChannelValidator(p.Channel); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("未知的通知渠道: %s", p.Channel))
+		}
+		if p.Topic == "" {
+			p.Topic = TopicAll
+		}
+	}
+
+	tx, err := conn.Writer().Tx(c.Context())
+	if err != nil {
+		return errors.Wrap(err, "开启事务失败")
+	}
+	for _, p := range req.Preferences {
+		if err := setPreference(c, tx, userID, p.Channel, p.Topic, p.Enabled); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "提交事务失败")
+	}
+
+	return h.getPreferences(c)
+}
+
+// setPreference 保存单条偏好，enabled 为nil时删除
+func setPreference(c fiber.Ctx, tx *ent.Tx, userID string, channel notificationpreference.Channel, topic string, enabled *bool) error {
+	match := notificationpreference.And(
+		notificationpreference.UserID(userID),
+		notificationpreference.ChannelEQ(channel),
+		notificationpreference.Topic(topic),
+	)
+
+	if enabled == nil {
+		_, err := tx.NotificationPreference.Delete().Where(match).Exec(c.Context())
+		return err
+	}
+
+	n, err := tx.NotificationPreference.Update().
+		Where(match).
+		SetEnabled(*enabled).
+		Save(c.Context())
+	if err != nil || n > 0 {
+		return err
+	}
+
+	return tx.NotificationPreference.Create().
+		SetUserID(userID).
+		SetChannel(channel).
+		SetTopic(topic).
+		SetEnabled(*enabled).
+		Exec(c.Context())
+}
+
+// countUnread 统计未读通知数量
+func countUnread(c fiber.Ctx, userID string) (int, error) {
+	return conn.Reader().Notification.Query().
+		Where(notification.UserID(userID), notification.ReadAtIsNil()).
+		Count(c.Context())
+}
+
+// currentUser 返回认证中间件设置的当前用户ID，未认证时返回401
+func currentUser(c fiber.Ctx) (string, error) {
+	if id := auth.UserID(c); id != "" {
+		return id, nil
+	}
+	return "", fiber.ErrUnauthorized
+}
+
+// paramID 解析路径中的通知ID
+func paramID(c fiber.Ctx) (int, error) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil || id <= 0 {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "无效的ID")
+	}
+	return id, nil
+}
+
+// notFound 将ent的未找到错误转换为404
+func notFound(err error) error {
+	if ent.IsNotFound(err) {
+		return fiber.ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,175 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"doghole/config"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// errInvalidAddress 收件地址无效，重试也无法成功
+var errInvalidAddress = errors.New("无效的邮箱地址")
+
+// Email 待发送的邮件
+type Email struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer 邮件发送器
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+// NewMailer 根据配置创建邮件发送器，未配置SMTP主机时返回只写日志的发送器
+func NewMailer(conf config.EmailConfig, logger *zap.Logger) (Mailer, error) {
+	if conf.Host == "" {
+		return &LogMailer{logger: logger}, nil
+	}
+	return NewSMTPMailer(conf)
+}
+
+// LogMailer 只把邮件写入日志，用于开发环境
+type LogMailer struct {
+	logger *zap.Logger
+}
+
+// Send 把邮件写入日志
+func (m *LogMailer) Send(_ context.Context, email Email) error {
+	m.logger.Info("邮件未配置SMTP，仅记录日志",
+		zap.String("to", email.To),
+		zap.String("subject", email.Subject),
+		zap.String("body", email.Body),
+	)
+	return nil
+}
+
+// SMTPMailer 通过SMTP发送纯文本邮件
+// 465 端口使用隐式TLS，其余端口在服务器支持时启用STARTTLS
+type SMTPMailer struct {
+	conf config.EmailConfig
+	from *mail.Address
+}
+
+// NewSMTPMailer 创建SMTP邮件发送器
+func NewSMTPMailer(conf config.EmailConfig) (*SMTPMailer, error) {
+	from, err := mail.ParseAddress(conf.From)
+	if err != nil {
+		return nil, errors.Wrapf(err, "无效的发件人地址: %q", conf.From)
+	}
+	if conf.Port <= 0 {
+		conf.Port = 587
+	}
+	return &SMTPMailer{conf: conf, from: from}, nil
+}
+
+// Send 发送邮件
+func (m *SMTPMailer) Send(ctx context.Context, email Email) error {
+	to, err := mail.ParseAddress(email.To)
+	if err != nil {
+		return errors.Wrapf(errInvalidAddress, "%q: %v", email.To, err)
+	}
+
+	msg, err := m.message(to, email)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(m.conf.Host, strconv.Itoa(m.conf.Port))
+	var conn net.Conn
+	if m.conf.Port == 465 {
+		conn, err = (&tls.Dialer{Config: &tls.Config{ServerName: m.conf.Host}}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return errors.Wrap(err, "连接SMTP服务器失败")
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, m.conf.Host)
+	if err != nil {
+		conn.Close()
+		return errors.Wrap(err, "连接SMTP服务器失败")
+	}
+	defer c.Close()
+
+	if _, isTLS := conn.(*tls.Conn); !isTLS {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(&tls.Config{ServerName: m.conf.Host}); err != nil {
+				return errors.Wrap(err, "启用STARTTLS失败")
+			}
+		}
+	}
+	if m.conf.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", m.conf.Username, m.conf.Password, m.conf.Host)); err != nil {
+			return errors.Wrap(err, "SMTP认证失败")
+		}
+	}
+
+	if err := c.Mail(m.from.Address); err != nil {
+		return errors.Wrap(err, "设置发件人失败")
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return errors.Wrap(err, "设置收件人失败")
+	}
+	w, err := c.Data()
+	if err != nil {
+		return errors.Wrap(err, "发送邮件失败")
+	}
+	if _, err := w.Write(msg); err != nil {
+		w.Close()
+		return errors.Wrap(err, "发送邮件失败")
+	}
+	if err := w.Close(); err != nil {
+		return errors.Wrap(err, "发送邮件失败")
+	}
+	return c.Quit()
+}
+
+// message 构造UTF-8纯文本邮件，主题使用Q编码，正文使用quoted-printable编码
+func (m *SMTPMailer) message(to *mail.Address, email Email) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", m.from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n", uuid.NewString(), m.domain())
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	body := strings.ReplaceAll(strings.ReplaceAll(email.Body, "\r\n", "\n"), "\n", "\r\n")
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return nil, errors.Wrap(err, "编码邮件正文失败")
+	}
+	if err := qp.Close(); err != nil {
+		return nil, errors.Wrap(err, "编码邮件正文失败")
+	}
+	return buf.Bytes(), nil
+}
+
+// domain 发件人地址的域名，用于生成 Message-ID
+func (m *SMTPMailer) domain() string {
+	if _, domain, ok := strings.Cut(m.from.Address, "@"); ok {
+		return domain
+	}
+	return m.conf.Host
+}
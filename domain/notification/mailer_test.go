@@ -0,0 +1,90 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"doghole/config"
+)
+
+func TestSMTPMailerMessage(t *testing.T) {
+	m, err := NewSMTPMailer(config.EmailConfig{From: "狗洞 <noreply@example.com>", Host: "smtp.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := &mail.Address{Name: "Alice", Address: "alice@example.com"}
+	body := "您好：\n\n您的导出文件「orders.csv」已生成，请在 24小时 内通过以下链接下载：https://example.com/d/1?token=" + strings.Repeat("a", 80)
+
+	raw, err := m.message(to, Email{To: to.String(), Subject: "[Doghole] 您的导出文件已就绪", Body: body})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 主题使用Q编码，原始报文中只包含ASCII字符，解码后与原文相同
+	subject := msg.Header.Get("Subject")
+	if !strings.HasPrefix(subject, "=?utf-8?q?") {
+		t.Fatalf("Subject 未使用Q编码: %q", subject)
+	}
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(subject); err != nil || decoded != "[Doghole] 您的导出文件已就绪" {
+		t.Fatalf("解码后的主题 = %q, %v", decoded, err)
+	}
+	for i, b := range raw {
+		if b >= 0x80 {
+			t.Fatalf("邮件第 %d 字节包含非ASCII字符", i)
+		}
+	}
+
+	from, err := msg.Header.AddressList("From")
+	if err != nil || len(from) != 1 || from[0].Name != "狗洞" || from[0].Address != "noreply@example.com" {
+		t.Fatalf("From = %v, %v", from, err)
+	}
+	if id := msg.Header.Get("Message-ID"); !strings.HasSuffix(id, "@example.com>") {
+		t.Fatalf("Message-ID = %q", id)
+	}
+	if ct := msg.Header.Get("Content-Type"); ct != "text/plain; charset=UTF-8" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	// 正文使用quoted-printable编码，行长度不超过76个字符，换行统一为CRLF
+	_, encoded, _ := strings.Cut(string(raw), "\r\n\r\n")
+	for _, line := range strings.Split(encoded, "\r\n") {
+		if len(line) > 76 {
+			t.Fatalf("行长度超过76个字符: %q", line)
+		}
+	}
+	decoded, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strings.ReplaceAll(body, "\n", "\r\n"); string(decoded) != want {
+		t.Fatalf("解码后的正文 = %q，期望 %q", decoded, want)
+	}
+}
+
+func TestSMTPMailerInvalid(t *testing.T) {
+	if _, err := NewSMTPMailer(config.EmailConfig{From: "noreply", Host: "smtp.example.com"}); err == nil {
+		t.Fatal("无效的发件人地址应返回错误")
+	}
+
+	m, err := NewSMTPMailer(config.EmailConfig{From: "noreply@example.com", Host: "smtp.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 无效的收件地址在连接服务器前返回
+	center := &Center{mailer: m}
+	err = center.SendEmail(context.Background(), Email{To: "not an address", Subject: "s", Body: "b"})
+	if !errors.Is(err, errInvalidAddress) {
+		t.Fatalf("err = %v", err)
+	}
+}
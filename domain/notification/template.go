@@ -0,0 +1,159 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+//go:embed templates
+var builtinTemplates embed.FS
+
+// ErrNoTemplate 通知主题没有可用的模板
+var ErrNoTemplate = errors.New("通知模板不存在")
+
+// 模板中定义的片段，email_subject 和 email_body 缺省时使用 title 和 body
+const (
+	blockTitle        = "title"
+	blockBody         = "body"
+	blockEmailSubject = "email_subject"
+	blockEmailBody    = "email_body"
+)
+
+// Rendered 渲染后的通知内容
+type Rendered struct {
+	Locale       string // 实际使用的语言
+	Title        string
+	Body         string
+	EmailSubject string
+	EmailBody    string
+}
+
+// Templates 按语言和主题组织的通知模板
+// 模板文件位于 <语言>/<主题>.tmpl，使用 text/template 语法定义 title、body 以及可选的 email_subject、email_body 片段
+type Templates struct {
+	defaultLocale string
+	templates     map[string]map[string]*template.Template // 小写语言 -> 主题 -> 模板
+}
+
+// LoadTemplates 加载内置模板，dir 不为空时加载该目录中的模板并覆盖同语言同主题的内置模板
+func LoadTemplates(dir, defaultLocale string) (*Templates, error) {
+	t := &Templates{
+		defaultLocale: defaultLocale,
+		templates:     make(map[string]map[string]*template.Template),
+	}
+
+	builtin, err := fs.Sub(builtinTemplates, "templates")
+	if err != nil {
+		return nil, errors.Wrap(err, "加载内置通知模板失败")
+	}
+	if err := t.load(builtin); err != nil {
+		return nil, err
+	}
+
+	if dir != "" {
+		if err := t.load(os.DirFS(dir)); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// load 从文件系统加载 <语言>/<主题>.tmpl 形式的模板
+func (t *Templates) load(fsys fs.FS) error {
+	files, err := fs.Glob(fsys, "*/*.tmpl")
+	if err != nil {
+		return errors.Wrap(err, "查找通知模板失败")
+	}
+
+	for _, name := range files {
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return errors.Wrapf(err, "读取通知模板失败: %s", name)
+		}
+
+		locale := strings.ToLower(path.Dir(name))
+		topic := strings.TrimSuffix(path.Base(name), ".tmpl")
+
+		tmpl, err := template.New(topic).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return errors.Wrapf(err, "解析通知模板失败: %s", name)
+		}
+		if tmpl.Lookup(blockTitle) == nil || tmpl.Lookup(blockBody) == nil {
+			return errors.Errorf("通知模板缺少 title 或 body 定义: %s", name)
+		}
+
+		if t.templates[locale] == nil {
+			t.templates[locale] = make(map[string]*template.Template)
+		}
+		t.templates[locale][topic] = tmpl
+	}
+	return nil
+}
+
+// Render 使用最匹配的语言渲染通知
+// 依次尝试请求的语言、其主语言（zh-CN -> zh）、默认语言及其主语言
+func (t *Templates) Render(topic, locale string, data map[string]any) (Rendered, error) {
+	for _, candidate := range localeCandidates(locale, t.defaultLocale) {
+		tmpl, ok := t.templates[strings.ToLower(candidate)][topic]
+		if !ok {
+			continue
+		}
+
+		r := Rendered{Locale: candidate}
+		var err error
+		if r.Title, err = execute(tmpl, blockTitle, data); err != nil {
+			return Rendered{}, err
+		}
+		if r.Body, err = execute(tmpl, blockBody, data); err != nil {
+			return Rendered{}, err
+		}
+
+		r.EmailSubject, r.EmailBody = r.Title, r.Body
+		if tmpl.Lookup(blockEmailSubject) != nil {
+			if r.EmailSubject, err = execute(tmpl, blockEmailSubject, data); err != nil {
+				return Rendered{}, err
+			}
+		}
+		if tmpl.Lookup(blockEmailBody) != nil {
+			if r.EmailBody, err = execute(tmpl, blockEmailBody, data); err != nil {
+				return Rendered{}, err
+			}
+		}
+		return r, nil
+	}
+
+	return Rendered{}, errors.Wrapf(ErrNoTemplate, "%s (%s)", topic, locale)
+}
+
+// execute 渲染模板中的片段
+func execute(tmpl *template.Template, name string, data map[string]any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", errors.Wrapf(err, "渲染通知模板失败: %s/%s", tmpl.Name(), name)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// localeCandidates 生成按优先级排列的候选语言
+func localeCandidates(locale, defaultLocale string) []string {
+	candidates := make([]string, 0, 4)
+	for _, l := range []string{locale, defaultLocale} {
+		l = strings.ReplaceAll(strings.TrimSpace(l), "_", "-")
+		if l == "" {
+			continue
+		}
+		candidates = append(candidates, l)
+		if base, _, ok := strings.Cut(l, "-"); ok {
+			candidates = append(candidates, base)
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTemplate 在 dir 下写入 <语言>/<主题>.tmpl 模板
+func writeTemplate(t *testing.T, dir, locale, topic, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, locale), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, locale, topic+".tmpl"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRender(t *testing.T) {
+	dir := t.TempDir()
+	// 覆盖内置的英文模板，并新增只有 title 和 body 的主题
+	writeTemplate(t, dir, "en", "export.ready", `{{define "title"}}Custom export{{end}}{{define "body"}}{{.name}}{{end}}`)
+	writeTemplate(t, dir, "zh", "plain", `{{define "title"}}标题 {{.n}}{{end}}{{define "body"}}
+  正文 {{.n}}
+{{end}}`)
+
+	templates, err := LoadTemplates(dir, "zh-CN")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := map[string]any{"name": "orders.csv", "expires_in": "24h", "url": "https://example.com/d/1", "n": 1}
+
+	tests := []struct {
+		name   string
+		topic  string
+		locale string
+		want   Rendered
+	}{
+		{"请求的语言", "export.ready", "zh-CN", Rendered{Locale: "zh-CN", Title: "导出已完成", EmailSubject: "[Doghole] 您的导出文件已就绪"}},
+		{"语言不区分大小写", "export.ready", "zh_cn", Rendered{Locale: "zh-cn", Title: "导出已完成"}},
+		{"目录中的模板覆盖内置模板", "export.ready", "en", Rendered{Locale: "en", Title: "Custom export", EmailSubject: "Custom export"}},
+		{"回退到主语言", "export.ready", "en-GB", Rendered{Locale: "en", Title: "Custom export"}},
+		{"回退到默认语言", "export.ready", "fr-FR", Rendered{Locale: "zh-CN", Title: "导出已完成"}},
+		{"未指定语言", "export.ready", "", Rendered{Locale: "zh-CN", Title: "导出已完成"}},
+		{"回退到默认语言的主语言", "plain", "en", Rendered{Locale: "zh", Title: "标题 1", EmailSubject: "标题 1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := templates.Render(tt.topic, tt.locale, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Locale != tt.want.Locale || got.Title != tt.want.Title {
+				t.Fatalf("Render = %q %q，期望 %q %q", got.Locale, got.Title, tt.want.Locale, tt.want.Title)
+			}
+			if tt.want.EmailSubject != "" && got.EmailSubject != tt.want.EmailSubject {
+				t.Fatalf("EmailSubject = %q，期望 %q", got.EmailSubject, tt.want.EmailSubject)
+			}
+		})
+	}
+
+	// 未定义 email_subject 和 email_body 时使用 title 和 body，首尾空白被去除
+	got, err := templates.Render("plain", "zh", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Body != "正文 1" || got.EmailBody != got.Body {
+		t.Fatalf("Body = %q，EmailBody = %q", got.Body, got.EmailBody)
+	}
+
+	if _, err := templates.Render("missing", "zh-CN", data); !errors.Is(err, ErrNoTemplate) {
+		t.Fatalf("不存在的主题 err = %v", err)
+	}
+	// 缺少模板数据时报错，而不是渲染出 <no value>
+	if _, err := templates.Render("export.ready", "zh-CN", map[string]any{"name": "orders.csv"}); err == nil || !strings.Contains(err.Error(), "export.ready") {
+		t.Fatalf("缺少模板数据 err = %v", err)
+	}
+}
+
+func TestLoadTemplatesInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"语法错误", `{{define "title"}}{{.name{{end}}`, "解析通知模板失败"},
+		{"缺少body", `{{define "title"}}标题{{end}}`, "缺少 title 或 body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTemplate(t, dir, "zh-CN", "broken", tt.content)
+			if _, err := LoadTemplates(dir, "zh-CN"); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v，期望包含 %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,438 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"doghole/changefeed"
+	"doghole/config"
+	"doghole/ent"
+	"doghole/ent/webhookdelivery"
+	"doghole/ent/webhookendpoint"
+	applogger "doghole/logger"
+	"doghole/tracing"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// maxResponseBody 投递日志中保存的响应体最大长度
+const maxResponseBody = 1024
+
+// Payload 投递的请求体
+type Payload struct {
+	ID        string    `json:"id"`         // 事件ID，接收方可用于去重
+	Type      string    `json:"type"`       // 事件类型，如 user.updated
+	CreatedAt time.Time `json:"created_at"` // 事件发生时间
+	Data      any       `json:"data"`       // 事件数据
+}
+
+// Dispatcher Webhook投递器
+// 将事件写入投递记录，并由后台循环按退避策略投递和重试
+type Dispatcher struct {
+	client     *ent.Client
+	config     config.WebhookConfig
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewDispatcher 创建Webhook投递器
+func NewDispatcher(client *ent.Client, conf config.WebhookConfig, options ...func(*Dispatcher)) *Dispatcher {
+	if conf.Timeout <= 0 {
+		conf.Timeout = 10 * time.Second
+	}
+	if conf.MaxAttempts <= 0 {
+		conf.MaxAttempts = 8
+	}
+	if conf.BackoffBase <= 0 {
+		conf.BackoffBase = 30 * time.Second
+	}
+	if conf.BackoffMax <= 0 {
+		conf.BackoffMax = 6 * time.Hour
+	}
+	if conf.PollInterval <= 0 {
+		conf.PollInterval = 5 * time.Second
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = 50
+	}
+
+	httpClient := &http.Client{Timeout: conf.Timeout}
+	if !conf.AllowPrivateTargets {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = publicDialer(conf.Timeout).DialContext
+		httpClient.Transport = transport
+	}
+
+	d := &Dispatcher{
+		client:     client,
+		config:     conf,
+		httpClient: httpClient,
+		logger:     zap.L(),
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// WithHTTPClient 设置投递使用的HTTP客户端
+func WithHTTPClient(client *http.Client) func(*Dispatcher) {
+	return func(d *Dispatcher) {
+		d.httpClient = client
+	}
+}
+
+// WithLogger 设置日志记录器
+func WithLogger(logger *zap.Logger) func(*Dispatcher) {
+	return func(d *Dispatcher) {
+		d.logger = logger
+	}
+}
+
+// Enqueue 为所有订阅了该事件类型的启用中的系统订阅创建投递记录
+func (d *Dispatcher) Enqueue(ctx context.Context, eventType string, data any, createdAt time.Time) error {
+	return enqueue(ctx, d.client, "", eventType, data, createdAt)
+}
+
+// EnqueueFor 为指定用户订阅的地址创建投递记录，用于向用户自己的Webhook推送通知
+// 投递由运行中的 Dispatcher 完成，与系统事件共享签名、重试和自动停用机制
+func EnqueueFor(ctx context.Context, client *ent.Client, ownerID, eventType string, data any, createdAt time.Time) error {
+	if ownerID == "" {
+		return errors.New("用户ID不能为空")
+	}
+	return enqueue(ctx, client, ownerID, eventType, data, createdAt)
+}
+
+// enqueue 为属于ownerID的启用中且订阅了该事件类型的地址创建投递记录
+func enqueue(ctx context.Context, client *ent.Client, ownerID, eventType string, data any, createdAt time.Time) error {
+	endpoints, err := client.WebhookEndpoint.Query().
+		Where(webhookendpoint.Enabled(true), webhookendpoint.OwnerID(ownerID)).
+		All(ctx)
+	if err != nil {
+		return errors.Wrap(err, "查询Webhook订阅失败")
+	}
+
+	eventID := "evt_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	body, err := json.Marshal(Payload{
+		ID:        eventID,
+		Type:      eventType,
+		CreatedAt: createdAt,
+		Data:      data,
+	})
+	if err != nil {
+		return errors.Wrap(err, "序列化Webhook事件失败")
+	}
+
+	builders := make([]*ent.WebhookDeliveryCreate, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if !MatchEventType(ep.EventTypes, eventType) {
+			continue
+		}
+		builders = append(builders, client.WebhookDelivery.Create().
+			SetEndpointID(ep.ID).
+			SetEventID(eventID).
+			SetEventType(eventType).
+			SetPayload(string(body)))
+	}
+	if len(builders) == 0 {
+		return nil
+	}
+
+	if err := client.WebhookDelivery.CreateBulk(builders...).Exec(ctx); err != nil {
+		return errors.Wrap(err, "创建Webhook投递记录失败")
+	}
+	return nil
+}
+
+// Redeliver 以原投递的请求体创建一条新的投递记录，原记录作为日志保留
+func Redeliver(ctx context.Context, client *ent.Client, deliveryID int) (*ent.WebhookDelivery, error) {
+	original, err := client.WebhookDelivery.Get(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.WebhookDelivery.Create().
+		SetEndpointID(original.EndpointID).
+		SetEventID(original.EventID).
+		SetEventType(original.EventType).
+		SetPayload(original.Payload).
+		SetRedeliveryOf(original.ID).
+		Save(ctx)
+}
+
+// Run 运行投递器，直到ctx被取消
+// broker 不为空时订阅实体变更事件并转为Webhook事件
+func (d *Dispatcher) Run(ctx context.Context, broker *changefeed.Broker) {
+	if broker != nil {
+		go d.consume(ctx, broker)
+	}
+
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatchDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// consume 订阅变更事件，转为系统订阅和实体所属用户订阅的Webhook事件，
+// 订阅因消费过慢被断开时从最后处理的事件续订
+func (d *Dispatcher) consume(ctx context.Context, broker *changefeed.Broker) {
+	var lastID uint64
+
+	for ctx.Err() == nil {
+		sub, backlog, complete := broker.Subscribe(changefeed.Filter{}, lastID)
+		if !complete {
+			d.logger.Warn("部分变更事件已超出事件日志范围，未能转为Webhook事件", zap.Uint64("last_id", lastID))
+		}
+
+		handle := func(e changefeed.Event) {
+			if err := d.Enqueue(ctx, e.Type, e, e.Time); err != nil {
+				d.logger.Error("创建Webhook投递失败", zap.String("event", e.Type), zap.Error(err))
+			}
+			// 实体所属的用户订阅的地址同样收到该用户的变更
+			if owner, _ := changefeed.Owner(e); owner != "" {
+				if err := enqueue(ctx, d.client, owner, e.Type, e, e.Time); err != nil {
+					d.logger.Error("创建Webhook投递失败", zap.String("event", e.Type), zap.String("owner", owner), zap.Error(err))
+				}
+			}
+			lastID = e.ID
+		}
+
+		for _, e := range backlog {
+			handle(e)
+		}
+
+	loop:
+		for {
+			select {
+			case <-ctx.Done():
+				sub.Close()
+				return
+			case e, ok := <-sub.C:
+				if !ok {
+					break loop
+				}
+				handle(e)
+			}
+		}
+	}
+}
+
+// dispatchDue 投递到期的记录
+func (d *Dispatcher) dispatchDue(ctx context.Context) {
+	due, err := d.client.WebhookDelivery.Query().
+		Where(
+			webhookdelivery.StatusIn(webhookdelivery.StatusPending, webhookdelivery.StatusFailed),
+			webhookdelivery.NextAttemptAtLTE(time.Now()),
+		).
+		Order(ent.Asc(webhookdelivery.FieldNextAttemptAt)).
+		Limit(d.config.BatchSize).
+		WithEndpoint().
+		All(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			d.logger.Error("查询待投递的Webhook失败", zap.Error(err))
+		}
+		return
+	}
+
+	for _, delivery := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		d.dispatch(ctx, delivery)
+	}
+}
+
+// dispatch 在span中占用并投递一条记录，投递日志带有追踪ID
+func (d *Dispatcher) dispatch(ctx context.Context, delivery *ent.WebhookDelivery) {
+	ctx, span := tracing.Tracer().Start(ctx, "webhook "+delivery.EventType,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.Int("webhook.delivery", delivery.ID),
+			attribute.String("webhook.event_id", delivery.EventID),
+			attribute.Int("webhook.attempt", delivery.Attempts+1),
+		),
+	)
+	defer span.End()
+
+	if !d.claim(ctx, delivery) {
+		return
+	}
+	d.deliver(ctx, delivery)
+}
+
+// claim 通过条件更新占用投递记录，避免多个实例重复投递
+// 占用期间将下一次尝试时间推迟一个请求超时，实例崩溃后记录会被重新投递
+func (d *Dispatcher) claim(ctx context.Context, delivery *ent.WebhookDelivery) bool {
+	n, err := d.client.WebhookDelivery.Update().
+		Where(
+			webhookdelivery.ID(delivery.ID),
+			webhookdelivery.NextAttemptAt(delivery.NextAttemptAt),
+			webhookdelivery.StatusIn(webhookdelivery.StatusPending, webhookdelivery.StatusFailed),
+		).
+		SetNextAttemptAt(time.Now().Add(2 * d.config.Timeout)).
+		Save(ctx)
+	if err != nil {
+		applogger.WithContext(ctx, d.logger).Error("占用Webhook投递记录失败", zap.Int("delivery", delivery.ID), zap.Error(err))
+		return false
+	}
+	return n == 1
+}
+
+// deliver 执行一次投递并记录结果
+func (d *Dispatcher) deliver(ctx context.Context, delivery *ent.WebhookDelivery) {
+	endpoint := delivery.Edges.Endpoint
+	if endpoint == nil || !endpoint.Enabled {
+		// 订阅已停用，放弃投递
+		if err := d.client.WebhookDelivery.UpdateOne(delivery).
+			SetStatus(webhookdelivery.StatusDead).
+			SetError("订阅已停用").
+			Exec(ctx); err != nil {
+			applogger.WithContext(ctx, d.logger).Error("更新Webhook投递记录失败", zap.Int("delivery", delivery.ID), zap.Error(err))
+		}
+		return
+	}
+
+	now := time.Now()
+	code, body, sendErr := d.send(ctx, endpoint, delivery, now)
+	attempts := delivery.Attempts + 1
+
+	update := d.client.WebhookDelivery.UpdateOne(delivery).
+		SetAttempts(attempts).
+		SetLastAttemptAt(now).
+		SetResponseBody(body)
+	if code > 0 {
+		update.SetResponseCode(code)
+	} else {
+		update.ClearResponseCode()
+	}
+
+	succeeded := sendErr == nil && code >= 200 && code < 300
+	span := trace.SpanFromContext(ctx)
+	if code > 0 {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(code))
+	}
+	if !succeeded {
+		span.SetStatus(codes.Error, deliveryError(code, sendErr))
+	}
+
+	switch {
+	case succeeded:
+		update.SetStatus(webhookdelivery.StatusSucceeded).SetError("")
+	case attempts >= d.config.MaxAttempts:
+		update.SetStatus(webhookdelivery.StatusDead).SetError(deliveryError(code, sendErr))
+	default:
+		update.SetStatus(webhookdelivery.StatusFailed).
+			SetError(deliveryError(code, sendErr)).
+			SetNextAttemptAt(now.Add(d.backoff(attempts)))
+	}
+
+	if err := update.Exec(ctx); err != nil {
+		applogger.WithContext(ctx, d.logger).Error("更新Webhook投递记录失败", zap.Int("delivery", delivery.ID), zap.Error(err))
+	}
+
+	d.recordEndpointResult(ctx, endpoint, succeeded)
+}
+
+// send 发送签名后的投递请求，返回响应状态码和截断后的响应体
+func (d *Dispatcher) send(ctx context.Context, endpoint *ent.WebhookEndpoint, delivery *ent.WebhookDelivery, now time.Time) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Doghole-Webhook/1.0")
+	req.Header.Set(HeaderWebhookID, delivery.EventID)
+	req.Header.Set(HeaderWebhookEvent, delivery.EventType)
+	req.Header.Set(HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderWebhookSignature, Sign(endpoint.Secret, timestamp, []byte(delivery.Payload)))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	return resp.StatusCode, strings.ToValidUTF8(string(body), ""), nil
+}
+
+// recordEndpointResult 更新订阅的连续失败次数，超过阈值时自动停用
+func (d *Dispatcher) recordEndpointResult(ctx context.Context, endpoint *ent.WebhookEndpoint, succeeded bool) {
+	update := d.client.WebhookEndpoint.UpdateOneID(endpoint.ID)
+	if succeeded {
+		if endpoint.FailureCount == 0 {
+			return
+		}
+		update.SetFailureCount(0)
+	} else {
+		update.AddFailureCount(1)
+		if d.config.DisableAfter > 0 && endpoint.FailureCount+1 >= d.config.DisableAfter {
+			update.SetEnabled(false).SetDisabledAt(time.Now())
+			applogger.WithContext(ctx, d.logger).Warn("Webhook订阅连续投递失败，已自动停用",
+				zap.Int("endpoint", endpoint.ID),
+				zap.Int("failures", endpoint.FailureCount+1),
+			)
+		}
+	}
+
+	if err := update.Exec(ctx); err != nil {
+		applogger.WithContext(ctx, d.logger).Error("更新Webhook订阅状态失败", zap.Int("endpoint", endpoint.ID), zap.Error(err))
+	}
+}
+
+// backoff 计算第attempts次失败后的重试间隔
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.config.BackoffBase
+	for i := 1; i < attempts && delay < d.config.BackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, d.config.BackoffMax)
+}
+
+// deliveryError 生成投递失败的错误信息
+func deliveryError(code int, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return "非2xx响应: " + strconv.Itoa(code)
+}
+
+// MatchEventType 判断事件类型是否匹配订阅过滤条件
+// 过滤条件为空表示订阅全部事件，"user.*" 匹配所有 user. 开头的事件
+func MatchEventType(filters []string, eventType string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, f := range filters {
+		if f == "*" || f == eventType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(f, "*"); ok && strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"doghole/changefeed"
+	"doghole/config"
+	"doghole/ent"
+	"doghole/ent/enttest"
+	"doghole/ent/webhookdelivery"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+// openClient 打开测试用的内存数据库
+func openClient(t *testing.T) *ent.Client {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", strings.ReplaceAll(t.Name(), "/", "_")))
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestConsumeOwner(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changefeed.RegisterOwnership("User", changefeed.SelfOwnership)
+	client := openClient(t)
+
+	create := func(owner string, types ...string) int {
+		return client.WebhookEndpoint.Create().
+			SetURL("https://hooks.example.com/" + owner).
+			SetSecret("secret").
+			SetOwnerID(owner).
+			SetEventTypes(types).
+			SaveX(ctx).ID
+	}
+	system := create("", "user.*", "probe.*")
+	alice := create("1")
+	create("2")
+	create("1", "order.*")
+
+	broker := changefeed.NewBroker(100, 10)
+	d := NewDispatcher(client, config.WebhookConfig{}, WithLogger(zap.NewNop()))
+	go d.consume(ctx, broker)
+
+	// waitDeliveries 等待除探测事件外至少有n条投递记录，返回按地址排序的地址ID
+	waitDeliveries := func(n int) []int {
+		var ids []int
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			ids = client.WebhookDelivery.Query().
+				Where(webhookdelivery.EventTypeNEQ("probe.ping")).
+				Order(ent.Asc(webhookdelivery.FieldEndpointID)).
+				Select(webhookdelivery.FieldEndpointID).
+				IntsX(ctx)
+			if len(ids) >= n {
+				break
+			}
+		}
+		return ids
+	}
+	// 订阅建立前发布的事件不会被投递，先发布探测事件确认投递器已开始消费
+	for i := 0; client.WebhookDelivery.Query().CountX(ctx) == 0; i++ {
+		if i == 500 {
+			t.Fatal("投递器没有消费变更事件")
+		}
+		broker.Publish(changefeed.Event{Type: "probe.ping", Entity: "Probe", Time: time.Now()})
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	broker.Publish(changefeed.Event{Type: "user.updated", Entity: "User", Op: changefeed.OpUpdate, EntityID: 1, Time: time.Now()})
+	// 无法确定归属的事件只投递给系统订阅
+	broker.Publish(changefeed.Event{Type: "user.updated", Entity: "User", Op: changefeed.OpUpdate, Time: time.Now()})
+
+	want := []int{system, system, alice}
+	if got := waitDeliveries(len(want)); !slices.Equal(got, want) {
+		t.Fatalf("投递的地址 = %v，期望 %v", got, want)
+	}
+}
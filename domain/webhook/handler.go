@@ -0,0 +1,310 @@
+package webhook
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"doghole/auth"
+	"doghole/config"
+	"doghole/domain/conn"
+	"doghole/ent"
+	"doghole/ent/webhookdelivery"
+	"doghole/ent/webhookendpoint"
+	"github.com/gofiber/fiber/v3"
+)
+
+// endpointRequest 创建或更新订阅的请求体
+type endpointRequest struct {
+	URL         *string   `json:"url"`
+	Description *string   `json:"description"`
+	EventTypes  *[]string `json:"event_types"`
+	Enabled     *bool     `json:"enabled"`
+}
+
+// handler Webhook订阅管理接口，所有查询和修改都限定在 owner 返回的所属用户下
+type handler struct {
+	owner func(c fiber.Ctx) string
+}
+
+// RegisterRoutes 注册当前用户的Webhook订阅管理路由，需要认证，用户只能管理自己的订阅
+func RegisterRoutes(router fiber.Router) {
+	router.Use(auth.Required())
+	register(router, &handler{owner: auth.UserID})
+}
+
+// RegisterAdminRoutes 注册系统订阅的管理路由，系统订阅接收所有变更事件，只能挂载在管理端口上
+func RegisterAdminRoutes(router fiber.Router) {
+	register(router, &handler{owner: func(fiber.Ctx) string { return "" }})
+}
+
+// register 注册订阅管理路由
+func register(router fiber.Router, h *handler) {
+	router.Get("/", h.listEndpoints)
+	router.Post("/", h.createEndpoint)
+	router.Get("/:id", h.getEndpoint)
+	router.Patch("/:id", h.updateEndpoint)
+	router.Delete("/:id", h.deleteEndpoint)
+	router.Post("/:id/rotate-secret", h.rotateSecret)
+	router.Get("/:id/deliveries", h.listDeliveries)
+	router.Post("/:id/deliveries/:deliveryID/redeliver", h.redeliver)
+}
+
+// listEndpoints 列出所属用户的订阅
+func (h *handler) listEndpoints(c fiber.Ctx) error {
+	endpoints, err := conn.Reader().WebhookEndpoint.Query().
+		Where(webhookendpoint.OwnerID(h.owner(c))).
+		Order(ent.Asc(webhookendpoint.FieldID)).
+		All(c.Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": endpoints})
+}
+
+// createEndpoint 创建订阅，仅在创建和轮换时返回签名密钥
+func (h *handler) createEndpoint(c fiber.Ctx) error {
+	var req endpointRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "请求体格式错误")
+	}
+	if req.URL == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "url 不能为空")
+	}
+	if err := validateURL(c.Context(), *req.URL); err != nil {
+		return err
+	}
+
+	secret, err := NewSecret()
+	if err != nil {
+		return err
+	}
+
+	create := conn.Writer().WebhookEndpoint.Create().
+		SetURL(*req.URL).
+		SetSecret(secret).
+		SetOwnerID(h.owner(c))
+	if req.Description != nil {
+		create.SetDescription(*req.Description)
+	}
+	if req.EventTypes != nil {
+		create.SetEventTypes(*req.EventTypes)
+	}
+	if req.Enabled != nil {
+		create.SetEnabled(*req.Enabled)
+	}
+
+	endpoint, err := create.Save(c.Context())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": endpoint, "secret": secret})
+}
+
+// getEndpoint 获取单个订阅
+func (h *handler) getEndpoint(c fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := conn.Reader().WebhookEndpoint.Query().
+		Where(webhookendpoint.ID(id), webhookendpoint.OwnerID(h.owner(c))).
+		Only(c.Context())
+	if err != nil {
+		return notFound(err)
+	}
+	return c.JSON(fiber.Map{"data": endpoint})
+}
+
+// updateEndpoint 更新订阅，重新启用时清零连续失败次数
+func (h *handler) updateEndpoint(c fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req endpointRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "请求体格式错误")
+	}
+
+	update := conn.Writer().WebhookEndpoint.UpdateOneID(id).
+		Where(webhookendpoint.OwnerID(h.owner(c)))
+	if req.URL != nil {
+		if err := validateURL(c.Context(), *req.URL); err != nil {
+			return err
+		}
+		update.SetURL(*req.URL)
+	}
+	if req.Description != nil {
+		update.SetDescription(*req.Description)
+	}
+	if req.EventTypes != nil {
+		update.SetEventTypes(*req.EventTypes)
+	}
+	if req.Enabled != nil {
+		update.SetEnabled(*req.Enabled)
+		if *req.Enabled {
+			update.SetFailureCount(0).ClearDisabledAt()
+		}
+	}
+
+	endpoint, err := update.Save(c.Context())
+	if err != nil {
+		return notFound(err)
+	}
+	return c.JSON(fiber.Map{"data": endpoint})
+}
+
+// deleteEndpoint 删除订阅及其投递记录
+func (h *handler) deleteEndpoint(c fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	err = conn.Writer().WebhookEndpoint.DeleteOneID(id).
+		Where(webhookendpoint.OwnerID(h.owner(c))).
+		Exec(c.Context())
+	if err != nil {
+		return notFound(err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// rotateSecret 轮换签名密钥
+func (h *handler) rotateSecret(c fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	secret, err := NewSecret()
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := conn.Writer().WebhookEndpoint.UpdateOneID(id).
+		Where(webhookendpoint.OwnerID(h.owner(c))).
+		SetSecret(secret).
+		Save(c.Context())
+	if err != nil {
+		return notFound(err)
+	}
+	return c.JSON(fiber.Map{"data": endpoint, "secret": secret})
+}
+
+// listDeliveries 分页列出订阅的投递日志，支持 status 过滤
+func (h *handler) listDeliveries(c fiber.Ctx) error {
+	id, err := h.endpointID(c)
+	if err != nil {
+		return err
+	}
+
+	limit := fiber.Query(c, "limit", 50)
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	offset := max(fiber.Query(c, "offset", 0), 0)
+
+	query := conn.Reader().WebhookDelivery.Query().
+		Where(webhookdelivery.EndpointID(id))
+	if status := c.Query("status"); status != "" {
+		s := webhookdelivery.Status(status)
+		if err := webhookdelivery.StatusValidator(s); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "无效的投递状态")
+		}
+		query.Where(webhookdelivery.StatusEQ(s))
+	}
+
+	deliveries, err := query.
+		Order(ent.Desc(webhookdelivery.FieldCreatedAt), ent.Desc(webhookdelivery.FieldID)).
+		Limit(limit).
+		Offset(offset).
+		All(c.Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": deliveries})
+}
+
+// redeliver 手动重新投递
+func (h *handler) redeliver(c fiber.Ctx) error {
+	id, err := h.endpointID(c)
+	if err != nil {
+		return err
+	}
+	deliveryID, err := paramID(c, "deliveryID")
+	if err != nil {
+		return err
+	}
+
+	exists, err := conn.Reader().WebhookDelivery.Query().
+		Where(webhookdelivery.ID(deliveryID), webhookdelivery.EndpointID(id)).
+		Exist(c.Context())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fiber.ErrNotFound
+	}
+
+	delivery, err := Redeliver(c.Context(), conn.Writer(), deliveryID)
+	if err != nil {
+		return notFound(err)
+	}
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"data": delivery})
+}
+
+// endpointID 解析路径中的订阅ID，订阅不存在或不属于当前用户时返回404
+func (h *handler) endpointID(c fiber.Ctx) (int, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return 0, err
+	}
+
+	exists, err := conn.Reader().WebhookEndpoint.Query().
+		Where(webhookendpoint.ID(id), webhookendpoint.OwnerID(h.owner(c))).
+		Exist(c.Context())
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, fiber.ErrNotFound
+	}
+	return id, nil
+}
+
+// paramID 解析路径中的整数ID
+func paramID(c fiber.Ctx, key string) (int, error) {
+	id, err := strconv.Atoi(c.Params(key))
+	if err != nil || id <= 0 {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "无效的ID")
+	}
+	return id, nil
+}
+
+// notFound 将ent的未找到错误转换为404
+func notFound(err error) error {
+	if ent.IsNotFound(err) {
+		return fiber.ErrNotFound
+	}
+	return err
+}
+
+// validateURL 校验订阅地址，未开启 allow_private_targets 时拒绝解析到回环、内网和链路本地地址的目标
+func validateURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "url 必须是有效的 http 或 https 地址")
+	}
+	if config.GetGlobalConfig().Webhook.AllowPrivateTargets {
+		return nil
+	}
+	if err := checkHost(ctx, u.Hostname()); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	return nil
+}
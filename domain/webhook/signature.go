@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// 投递请求头
+const (
+	HeaderWebhookID        = "Webhook-Id"
+	HeaderWebhookEvent     = "Webhook-Event"
+	HeaderWebhookTimestamp = "Webhook-Timestamp"
+	HeaderWebhookSignature = "Webhook-Signature"
+)
+
+// signatureVersion 签名版本前缀
+const signatureVersion = "v1"
+
+// Sign 计算投递签名
+// 签名内容为 "<时间戳>.<请求体>"，使用 HMAC-SHA256 和订阅密钥计算，结果为 "v1=<十六进制摘要>"
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 校验投递签名，供接收方使用
+// tolerance 为允许的时间偏差，用于防止重放
+func Verify(secret, signature, timestamp string, body []byte, tolerance time.Duration) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("无效的时间戳")
+	}
+	if tolerance > 0 {
+		if diff := time.Since(time.Unix(ts, 0)); diff > tolerance || diff < -tolerance {
+			return errors.New("时间戳超出允许范围")
+		}
+	}
+
+	expected := Sign(secret, ts, body)
+	// 签名头可能包含多个以空格分隔的签名（密钥轮换期间）
+	for _, s := range strings.Fields(signature) {
+		if hmac.Equal([]byte(s), []byte(expected)) {
+			return nil
+		}
+	}
+	return errors.New("签名不匹配")
+}
+
+// NewSecret 生成随机签名密钥
+func NewSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "生成签名密钥失败")
+	}
+	return fmt.Sprintf("whsec_%s", hex.EncodeToString(b)), nil
+}
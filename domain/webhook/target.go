@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrPrivateTarget 投递目标为回环、内网或链路本地地址
+var ErrPrivateTarget = errors.New("url 不能指向回环、内网或链路本地地址")
+
+// sharedAddressSpace 运营商级NAT地址段（100.64.0.0/10），同样不应从公网访问
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// publicAddr 判断地址是否可以作为投递目标
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsUnspecified() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// checkHost 校验主机名或IP，主机名解析出的任一地址不可用时返回错误
+func checkHost(ctx context.Context, host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !publicAddr(addr) {
+			return ErrPrivateTarget
+		}
+		return nil
+	}
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrPrivateTarget
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return errors.Errorf("无法解析 url 的主机名 %s", host)
+	}
+	for _, addr := range addrs {
+		if !publicAddr(addr) {
+			return ErrPrivateTarget
+		}
+	}
+	return nil
+}
+
+// publicDialer 只允许连接公网地址的拨号器
+// 在建立连接前校验实际连接的地址，防止地址在注册后通过DNS重新绑定或重定向指向内网
+func publicDialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return errors.Wrapf(err, "无效的连接地址 %s", address)
+			}
+			if !publicAddr(addrPort.Addr()) {
+				return ErrPrivateTarget
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,129 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"doghole/ent/certcache"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// CertCache is the model entity for the CertCache schema.
+type CertCache struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// 缓存键，通常为域名
+	Key string `json:"key,omitempty"`
+	// PEM编码的证书链和私钥
+	Data []byte `json:"-"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*CertCache) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case certcache.FieldData:
+			values[i] = new([]byte)
+		case certcache.FieldID:
+			values[i] = new(sql.NullInt64)
+		case certcache.FieldKey:
+			values[i] = new(sql.NullString)
+		case certcache.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the CertCache fields.
+func (cc *CertCache) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case certcache.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			cc.ID = int(value.Int64)
+		case certcache.FieldKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key", values[i])
+			} else if value.Valid {
+				cc.Key = value.String
+			}
+		case certcache.FieldData:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field data", values[i])
+			} else if value != nil {
+				cc.Data = *value
+			}
+		case certcache.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				cc.UpdatedAt = value.Time
+			}
+		default:
+			cc.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the CertCache.
+// This includes values selected through modifiers, order, etc.
+func (cc *CertCache) Value(name string) (ent.Value, error) {
+	return cc.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this CertCache.
+// Note that you need to call CertCache.Unwrap() before calling this method if this CertCache
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (cc *CertCache) Update() *CertCacheUpdateOne {
+	return NewCertCacheClient(cc.config).UpdateOne(cc)
+}
+
+// Unwrap unwraps the CertCache entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (cc *CertCache) Unwrap() *CertCache {
+	_tx, ok := cc.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: CertCache is not a transactional entity")
+	}
+	cc.config.driver = _tx.drv
+	return cc
+}
+
+// String implements the fmt.Stringer.
+func (cc *CertCache) String() string {
+	var builder strings.Builder
+	builder.WriteString("CertCache(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", cc.ID))
+	builder.WriteString("key=")
+	builder.WriteString(cc.Key)
+	builder.WriteString(", ")
+	builder.WriteString("data=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(cc.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// CertCaches is a parsable slice of CertCache.
+type CertCaches []*CertCache
@@ -0,0 +1,233 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/certcache"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// CertCacheCreate is the builder for creating a CertCache entity.
+type CertCacheCreate struct {
+	config
+	mutation *CertCacheMutation
+	hooks    []Hook
+}
+
+// SetKey sets the "key" field.
+func (ccc *CertCacheCreate) SetKey(s string) *CertCacheCreate {
+	ccc.mutation.SetKey(s)
+	return ccc
+}
+
+// SetData sets the "data" field.
+func (ccc *CertCacheCreate) SetData(b []byte) *CertCacheCreate {
+	ccc.mutation.SetData(b)
+	return ccc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ccc *CertCacheCreate) SetUpdatedAt(t time.Time) *CertCacheCreate {
+	ccc.mutation.SetUpdatedAt(t)
+	return ccc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (ccc *CertCacheCreate) SetNillableUpdatedAt(t *time.Time) *CertCacheCreate {
+	if t != nil {
+		ccc.SetUpdatedAt(*t)
+	}
+	return ccc
+}
+
+// Mutation returns the CertCacheMutation object of the builder.
+func (ccc *CertCacheCreate) Mutation() *CertCacheMutation {
+	return ccc.mutation
+}
+
+// Save creates the CertCache in the database.
+func (ccc *CertCacheCreate) Save(ctx context.Context) (*CertCache, error) {
+	ccc.defaults()
+	return withHooks(ctx, ccc.sqlSave, ccc.mutation, ccc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ccc *CertCacheCreate) SaveX(ctx context.Context) *CertCache {
+	v, err := ccc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ccc *CertCacheCreate) Exec(ctx context.Context) error {
+	_, err := ccc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ccc *CertCacheCreate) ExecX(ctx context.Context) {
+	if err := ccc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ccc *CertCacheCreate) defaults() {
+	if _, ok := ccc.mutation.UpdatedAt(); !ok {
+		v := certcache.DefaultUpdatedAt()
+		ccc.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ccc *CertCacheCreate) check() error {
+	if _, ok := ccc.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "CertCache.key"`)}
+	}
+	if v, ok := ccc.mutation.Key(); ok {
+		if err := certcache.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "CertCache.key": %w`, err)}
+		}
+	}
+	if _, ok := ccc.mutation.Data(); !ok {
+		return &ValidationError{Name: "data", err: errors.New(`ent: missing required field "CertCache.data"`)}
+	}
+	if _, ok := ccc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "CertCache.updated_at"`)}
+	}
+	return nil
+}
+
+func (ccc *CertCacheCreate) sqlSave(ctx context.Context) (*CertCache, error) {
+	if err := ccc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := ccc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ccc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	ccc.mutation.id = &_node.ID
+	ccc.mutation.done = true
+	return _node, nil
+}
+
+func (ccc *CertCacheCreate) createSpec() (*CertCache, *sqlgraph.CreateSpec) {
+	var (
+		_node = &CertCache{config: ccc.config}
+		_spec = sqlgraph.NewCreateSpec(certcache.Table, sqlgraph.NewFieldSpec(certcache.FieldID, field.TypeInt))
+	)
+	if value, ok := ccc.mutation.Key(); ok {
+		_spec.SetField(certcache.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := ccc.mutation.Data(); ok {
+		_spec.SetField(certcache.FieldData, field.TypeBytes, value)
+		_node.Data = value
+	}
+	if value, ok := ccc.mutation.UpdatedAt(); ok {
+		_spec.SetField(certcache.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// CertCacheCreateBulk is the builder for creating many CertCache entities in bulk.
+type CertCacheCreateBulk struct {
+	config
+	err      error
+	builders []*CertCacheCreate
+}
+
+// Save creates the CertCache entities in the database.
+func (cccb *CertCacheCreateBulk) Save(ctx context.Context) ([]*CertCache, error) {
+	if cccb.err != nil {
+		return nil, cccb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(cccb.builders))
+	nodes := make([]*CertCache, len(cccb.builders))
+	mutators := make([]Mutator, len(cccb.builders))
+	for i := range cccb.builders {
+		func(i int, root context.Context) {
+			builder := cccb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*CertCacheMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, cccb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, cccb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, cccb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cccb *CertCacheCreateBulk) SaveX(ctx context.Context) []*CertCache {
+	v, err := cccb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cccb *CertCacheCreateBulk) Exec(ctx context.Context) error {
+	_, err := cccb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cccb *CertCacheCreateBulk) ExecX(ctx context.Context) {
+	if err := cccb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/certcache"
+	"doghole/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// CertCacheDelete is the builder for deleting a CertCache entity.
+type CertCacheDelete struct {
+	config
+	hooks    []Hook
+	mutation *CertCacheMutation
+}
+
+// Where appends a list predicates to the CertCacheDelete builder.
+func (ccd *CertCacheDelete) Where(ps ...predicate.CertCache) *CertCacheDelete {
+	ccd.mutation.Where(ps...)
+	return ccd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (ccd *CertCacheDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, ccd.sqlExec, ccd.mutation, ccd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ccd *CertCacheDelete) ExecX(ctx context.Context) int {
+	n, err := ccd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (ccd *CertCacheDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(certcache.Table, sqlgraph.NewFieldSpec(certcache.FieldID, field.TypeInt))
+	if ps := ccd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, ccd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	ccd.mutation.done = true
+	return affected, err
+}
+
+// CertCacheDeleteOne is the builder for deleting a single CertCache entity.
+type CertCacheDeleteOne struct {
+	ccd *CertCacheDelete
+}
+
+// Where appends a list predicates to the CertCacheDelete builder.
+func (ccdo *CertCacheDeleteOne) Where(ps ...predicate.CertCache) *CertCacheDeleteOne {
+	ccdo.ccd.mutation.Where(ps...)
+	return ccdo
+}
+
+// Exec executes the deletion query.
+func (ccdo *CertCacheDeleteOne) Exec(ctx context.Context) error {
+	n, err := ccdo.ccd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{certcache.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ccdo *CertCacheDeleteOne) ExecX(ctx context.Context) {
+	if err := ccdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,564 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/certcache"
+	"doghole/ent/predicate"
+	"fmt"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// CertCacheQuery is the builder for querying CertCache entities.
+type CertCacheQuery struct {
+	config
+	ctx        *QueryContext
+	order      []certcache.OrderOption
+	inters     []Interceptor
+	predicates []predicate.CertCache
+	modifiers  []func(*sql.Selector)
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the CertCacheQuery builder.
+func (ccq *CertCacheQuery) Where(ps ...predicate.CertCache) *CertCacheQuery {
+	ccq.predicates = append(ccq.predicates, ps...)
+	return ccq
+}
+
+// Limit the number of records to be returned by this query.
+func (ccq *CertCacheQuery) Limit(limit int) *CertCacheQuery {
+	ccq.ctx.Limit = &limit
+	return ccq
+}
+
+// Offset to start from.
+func (ccq *CertCacheQuery) Offset(offset int) *CertCacheQuery {
+	ccq.ctx.Offset = &offset
+	return ccq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (ccq *CertCacheQuery) Unique(unique bool) *CertCacheQuery {
+	ccq.ctx.Unique = &unique
+	return ccq
+}
+
+// Order specifies how the records should be ordered.
+func (ccq *CertCacheQuery) Order(o ...certcache.OrderOption) *CertCacheQuery {
+	ccq.order = append(ccq.order, o...)
+	return ccq
+}
+
+// First returns the first CertCache entity from the query.
+// Returns a *NotFoundError when no CertCache was found.
+func (ccq *CertCacheQuery) First(ctx context.Context) (*CertCache, error) {
+	nodes, err := ccq.Limit(1).All(setContextOp(ctx, ccq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{certcache.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (ccq *CertCacheQuery) FirstX(ctx context.Context) *CertCache {
+	node, err := ccq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first CertCache ID from the query.
+// Returns a *NotFoundError when no CertCache ID was found.
+func (ccq *CertCacheQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = ccq.Limit(1).IDs(setContextOp(ctx, ccq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{certcache.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (ccq *CertCacheQuery) FirstIDX(ctx context.Context) int {
+	id, err := ccq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single CertCache entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one CertCache entity is found.
+// Returns a *NotFoundError when no CertCache entities are found.
+func (ccq *CertCacheQuery) Only(ctx context.Context) (*CertCache, error) {
+	nodes, err := ccq.Limit(2).All(setContextOp(ctx, ccq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{certcache.Label}
+	default:
+		return nil, &NotSingularError{certcache.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (ccq *CertCacheQuery) OnlyX(ctx context.Context) *CertCache {
+	node, err := ccq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only CertCache ID in the query.
+// Returns a *NotSingularError when more than one CertCache ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (ccq *CertCacheQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = ccq.Limit(2).IDs(setContextOp(ctx, ccq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{certcache.Label}
+	default:
+		err = &NotSingularError{certcache.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (ccq *CertCacheQuery) OnlyIDX(ctx context.Context) int {
+	id, err := ccq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of CertCaches.
+func (ccq *CertCacheQuery) All(ctx context.Context) ([]*CertCache, error) {
+	ctx = setContextOp(ctx, ccq.ctx, ent.OpQueryAll)
+	if err := ccq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*CertCache, *CertCacheQuery]()
+	return withInterceptors[[]*CertCache](ctx, ccq, qr, ccq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (ccq *CertCacheQuery) AllX(ctx context.Context) []*CertCache {
+	nodes, err := ccq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of CertCache IDs.
+func (ccq *CertCacheQuery) IDs(ctx context.Context) (ids []int, err error) {
+	if ccq.ctx.Unique == nil && ccq.path != nil {
+		ccq.Unique(true)
+	}
+	ctx = setContextOp(ctx, ccq.ctx, ent.OpQueryIDs)
+	if err = ccq.Select(certcache.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (ccq *CertCacheQuery) IDsX(ctx context.Context) []int {
+	ids, err := ccq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (ccq *CertCacheQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, ccq.ctx, ent.OpQueryCount)
+	if err := ccq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, ccq, querierCount[*CertCacheQuery](), ccq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (ccq *CertCacheQuery) CountX(ctx context.Context) int {
+	count, err := ccq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (ccq *CertCacheQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, ccq.ctx, ent.OpQueryExist)
+	switch _, err := ccq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (ccq *CertCacheQuery) ExistX(ctx context.Context) bool {
+	exist, err := ccq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the CertCacheQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (ccq *CertCacheQuery) Clone() *CertCacheQuery {
+	if ccq == nil {
+		return nil
+	}
+	return &CertCacheQuery{
+		config:     ccq.config,
+		ctx:        ccq.ctx.Clone(),
+		order:      append([]certcache.OrderOption{}, ccq.order...),
+		inters:     append([]Interceptor{}, ccq.inters...),
+		predicates: append([]predicate.CertCache{}, ccq.predicates...),
+		// clone intermediate query.
+		sql:  ccq.sql.Clone(),
+		path: ccq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		Key string `json:"key,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.CertCache.Query().
+//		GroupBy(certcache.FieldKey).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (ccq *CertCacheQuery) GroupBy(field string, fields ...string) *CertCacheGroupBy {
+	ccq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &CertCacheGroupBy{build: ccq}
+	grbuild.flds = &ccq.ctx.Fields
+	grbuild.label = certcache.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		Key string `json:"key,omitempty"`
+//	}
+//
+//	client.CertCache.Query().
+//		Select(certcache.FieldKey).
+//		Scan(ctx, &v)
+func (ccq *CertCacheQuery) Select(fields ...string) *CertCacheSelect {
+	ccq.ctx.Fields = append(ccq.ctx.Fields, fields...)
+	sbuild := &CertCacheSelect{CertCacheQuery: ccq}
+	sbuild.label = certcache.Label
+	sbuild.flds, sbuild.scan = &ccq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a CertCacheSelect configured with the given aggregations.
+func (ccq *CertCacheQuery) Aggregate(fns ...AggregateFunc) *CertCacheSelect {
+	return ccq.Select().Aggregate(fns...)
+}
+
+func (ccq *CertCacheQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range ccq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, ccq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range ccq.ctx.Fields {
+		if !certcache.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if ccq.path != nil {
+		prev, err := ccq.path(ctx)
+		if err != nil {
+			return err
+		}
+		ccq.sql = prev
+	}
+	return nil
+}
+
+func (ccq *CertCacheQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*CertCache, error) {
+	var (
+		nodes = []*CertCache{}
+		_spec = ccq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*CertCache).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &CertCache{config: ccq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(ccq.modifiers) > 0 {
+		_spec.Modifiers = ccq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, ccq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (ccq *CertCacheQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := ccq.querySpec()
+	if len(ccq.modifiers) > 0 {
+		_spec.Modifiers = ccq.modifiers
+	}
+	_spec.Node.Columns = ccq.ctx.Fields
+	if len(ccq.ctx.Fields) > 0 {
+		_spec.Unique = ccq.ctx.Unique != nil && *ccq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, ccq.driver, _spec)
+}
+
+func (ccq *CertCacheQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(certcache.Table, certcache.Columns, sqlgraph.NewFieldSpec(certcache.FieldID, field.TypeInt))
+	_spec.From = ccq.sql
+	if unique := ccq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if ccq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := ccq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, certcache.FieldID)
+		for i := range fields {
+			if fields[i] != certcache.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := ccq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := ccq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := ccq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := ccq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (ccq *CertCacheQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(ccq.driver.Dialect())
+	t1 := builder.Table(certcache.Table)
+	columns := ccq.ctx.Fields
+	if len(columns) == 0 {
+		columns = certcache.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if ccq.sql != nil {
+		selector = ccq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if ccq.ctx.Unique != nil && *ccq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, m := range ccq.modifiers {
+		m(selector)
+	}
+	for _, p := range ccq.predicates {
+		p(selector)
+	}
+	for _, p := range ccq.order {
+		p(selector)
+	}
+	if offset := ccq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := ccq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ForUpdate locks the selected rows against concurrent updates, and prevent them from being
+// updated, deleted or "selected ... for update" by other sessions, until the transaction is
+// either committed or rolled-back.
+func (ccq *CertCacheQuery) ForUpdate(opts ...sql.LockOption) *CertCacheQuery {
+	if ccq.driver.Dialect() == dialect.Postgres {
+		ccq.Unique(false)
+	}
+	ccq.modifiers = append(ccq.modifiers, func(s *sql.Selector) {
+		s.ForUpdate(opts...)
+	})
+	return ccq
+}
+
+// ForShare behaves similarly to ForUpdate, except that it acquires a shared mode lock
+// on any rows that are read. Other sessions can read the rows, but cannot modify them
+// until your transaction commits.
+func (ccq *CertCacheQuery) ForShare(opts ...sql.LockOption) *CertCacheQuery {
+	if ccq.driver.Dialect() == dialect.Postgres {
+		ccq.Unique(false)
+	}
+	ccq.modifiers = append(ccq.modifiers, func(s *sql.Selector) {
+		s.ForShare(opts...)
+	})
+	return ccq
+}
+
+// CertCacheGroupBy is the group-by builder for CertCache entities.
+type CertCacheGroupBy struct {
+	selector
+	build *CertCacheQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (ccgb *CertCacheGroupBy) Aggregate(fns ...AggregateFunc) *CertCacheGroupBy {
+	ccgb.fns = append(ccgb.fns, fns...)
+	return ccgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (ccgb *CertCacheGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, ccgb.build.ctx, ent.OpQueryGroupBy)
+	if err := ccgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*CertCacheQuery, *CertCacheGroupBy](ctx, ccgb.build, ccgb, ccgb.build.inters, v)
+}
+
+func (ccgb *CertCacheGroupBy) sqlScan(ctx context.Context, root *CertCacheQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(ccgb.fns))
+	for _, fn := range ccgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*ccgb.flds)+len(ccgb.fns))
+		for _, f := range *ccgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*ccgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := ccgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// CertCacheSelect is the builder for selecting fields of CertCache entities.
+type CertCacheSelect struct {
+	*CertCacheQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (ccs *CertCacheSelect) Aggregate(fns ...AggregateFunc) *CertCacheSelect {
+	ccs.fns = append(ccs.fns, fns...)
+	return ccs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (ccs *CertCacheSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, ccs.ctx, ent.OpQuerySelect)
+	if err := ccs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*CertCacheQuery, *CertCacheSelect](ctx, ccs.CertCacheQuery, ccs, ccs.inters, v)
+}
+
+func (ccs *CertCacheSelect) sqlScan(ctx context.Context, root *CertCacheQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(ccs.fns))
+	for _, fn := range ccs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*ccs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := ccs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,230 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/certcache"
+	"doghole/ent/predicate"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// CertCacheUpdate is the builder for updating CertCache entities.
+type CertCacheUpdate struct {
+	config
+	hooks    []Hook
+	mutation *CertCacheMutation
+}
+
+// Where appends a list predicates to the CertCacheUpdate builder.
+func (ccu *CertCacheUpdate) Where(ps ...predicate.CertCache) *CertCacheUpdate {
+	ccu.mutation.Where(ps...)
+	return ccu
+}
+
+// SetData sets the "data" field.
+func (ccu *CertCacheUpdate) SetData(b []byte) *CertCacheUpdate {
+	ccu.mutation.SetData(b)
+	return ccu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ccu *CertCacheUpdate) SetUpdatedAt(t time.Time) *CertCacheUpdate {
+	ccu.mutation.SetUpdatedAt(t)
+	return ccu
+}
+
+// Mutation returns the CertCacheMutation object of the builder.
+func (ccu *CertCacheUpdate) Mutation() *CertCacheMutation {
+	return ccu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ccu *CertCacheUpdate) Save(ctx context.Context) (int, error) {
+	ccu.defaults()
+	return withHooks(ctx, ccu.sqlSave, ccu.mutation, ccu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ccu *CertCacheUpdate) SaveX(ctx context.Context) int {
+	affected, err := ccu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ccu *CertCacheUpdate) Exec(ctx context.Context) error {
+	_, err := ccu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ccu *CertCacheUpdate) ExecX(ctx context.Context) {
+	if err := ccu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ccu *CertCacheUpdate) defaults() {
+	if _, ok := ccu.mutation.UpdatedAt(); !ok {
+		v := certcache.UpdateDefaultUpdatedAt()
+		ccu.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (ccu *CertCacheUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(certcache.Table, certcache.Columns, sqlgraph.NewFieldSpec(certcache.FieldID, field.TypeInt))
+	if ps := ccu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ccu.mutation.Data(); ok {
+		_spec.SetField(certcache.FieldData, field.TypeBytes, value)
+	}
+	if value, ok := ccu.mutation.UpdatedAt(); ok {
+		_spec.SetField(certcache.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ccu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{certcache.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	ccu.mutation.done = true
+	return n, nil
+}
+
+// CertCacheUpdateOne is the builder for updating a single CertCache entity.
+type CertCacheUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *CertCacheMutation
+}
+
+// SetData sets the "data" field.
+func (ccuo *CertCacheUpdateOne) SetData(b []byte) *CertCacheUpdateOne {
+	ccuo.mutation.SetData(b)
+	return ccuo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ccuo *CertCacheUpdateOne) SetUpdatedAt(t time.Time) *CertCacheUpdateOne {
+	ccuo.mutation.SetUpdatedAt(t)
+	return ccuo
+}
+
+// Mutation returns the CertCacheMutation object of the builder.
+func (ccuo *CertCacheUpdateOne) Mutation() *CertCacheMutation {
+	return ccuo.mutation
+}
+
+// Where appends a list predicates to the CertCacheUpdate builder.
+func (ccuo *CertCacheUpdateOne) Where(ps ...predicate.CertCache) *CertCacheUpdateOne {
+	ccuo.mutation.Where(ps...)
+	return ccuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ccuo *CertCacheUpdateOne) Select(field string, fields ...string) *CertCacheUpdateOne {
+	ccuo.fields = append([]string{field}, fields...)
+	return ccuo
+}
+
+// Save executes the query and returns the updated CertCache entity.
+func (ccuo *CertCacheUpdateOne) Save(ctx context.Context) (*CertCache, error) {
+	ccuo.defaults()
+	return withHooks(ctx, ccuo.sqlSave, ccuo.mutation, ccuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ccuo *CertCacheUpdateOne) SaveX(ctx context.Context) *CertCache {
+	node, err := ccuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ccuo *CertCacheUpdateOne) Exec(ctx context.Context) error {
+	_, err := ccuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ccuo *CertCacheUpdateOne) ExecX(ctx context.Context) {
+	if err := ccuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ccuo *CertCacheUpdateOne) defaults() {
+	if _, ok := ccuo.mutation.UpdatedAt(); !ok {
+		v := certcache.UpdateDefaultUpdatedAt()
+		ccuo.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (ccuo *CertCacheUpdateOne) sqlSave(ctx context.Context) (_node *CertCache, err error) {
+	_spec := sqlgraph.NewUpdateSpec(certcache.Table, certcache.Columns, sqlgraph.NewFieldSpec(certcache.FieldID, field.TypeInt))
+	id, ok := ccuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "CertCache.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ccuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, certcache.FieldID)
+		for _, f := range fields {
+			if !certcache.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != certcache.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ccuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ccuo.mutation.Data(); ok {
+		_spec.SetField(certcache.FieldData, field.TypeBytes, value)
+	}
+	if value, ok := ccuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(certcache.FieldUpdatedAt, field.TypeTime, value)
+	}
+	_node = &CertCache{config: ccuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ccuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{certcache.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ccuo.mutation.done = true
+	return _node, nil
+}
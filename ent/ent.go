@@ -4,7 +4,19 @@ package ent
 
 import (
 	"context"
+	"doghole/ent/certcache"
+	"doghole/ent/featureflag"
+	"doghole/ent/file"
+	"doghole/ent/idempotencykey"
+	"doghole/ent/job"
+	"doghole/ent/notification"
+	"doghole/ent/notificationpreference"
+	"doghole/ent/outbox"
+	"doghole/ent/ratelimitbucket"
+	"doghole/ent/scheduledrun"
 	"doghole/ent/user"
+	"doghole/ent/webhookdelivery"
+	"doghole/ent/webhookendpoint"
 	"errors"
 	"fmt"
 	"reflect"
@@ -73,7 +85,19 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			user.Table: user.ValidColumn,
+			certcache.Table:              certcache.ValidColumn,
+			featureflag.Table:            featureflag.ValidColumn,
+			file.Table:                   file.ValidColumn,
+			idempotencykey.Table:         idempotencykey.ValidColumn,
+			job.Table:                    job.ValidColumn,
+			notification.Table:           notification.ValidColumn,
+			notificationpreference.Table: notificationpreference.ValidColumn,
+			outbox.Table:                 outbox.ValidColumn,
+			ratelimitbucket.Table:        ratelimitbucket.ValidColumn,
+			scheduledrun.Table:           scheduledrun.ValidColumn,
+			user.Table:                   user.ValidColumn,
+			webhookdelivery.Table:        webhookdelivery.ValidColumn,
+			webhookendpoint.Table:        webhookendpoint.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
@@ -0,0 +1,183 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"doghole/ent/featureflag"
+	"doghole/featureflag/rules"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// FeatureFlag is the model entity for the FeatureFlag schema.
+type FeatureFlag struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// 开关名称
+	Key string `json:"key,omitempty"`
+	// 总开关
+	Enabled bool `json:"enabled,omitempty"`
+	// 灰度百分比，为空表示100
+	Percentage *int `json:"percentage,omitempty"`
+	// 定向规则
+	Rules []rules.Rule `json:"rules,omitempty"`
+	// Description holds the value of the "description" field.
+	Description string `json:"description,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*FeatureFlag) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case featureflag.FieldRules:
+			values[i] = new([]byte)
+		case featureflag.FieldEnabled:
+			values[i] = new(sql.NullBool)
+		case featureflag.FieldID, featureflag.FieldPercentage:
+			values[i] = new(sql.NullInt64)
+		case featureflag.FieldKey, featureflag.FieldDescription:
+			values[i] = new(sql.NullString)
+		case featureflag.FieldCreatedAt, featureflag.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the FeatureFlag fields.
+func (ff *FeatureFlag) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case featureflag.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			ff.ID = int(value.Int64)
+		case featureflag.FieldKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key", values[i])
+			} else if value.Valid {
+				ff.Key = value.String
+			}
+		case featureflag.FieldEnabled:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field enabled", values[i])
+			} else if value.Valid {
+				ff.Enabled = value.Bool
+			}
+		case featureflag.FieldPercentage:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field percentage", values[i])
+			} else if value.Valid {
+				ff.Percentage = new(int)
+				*ff.Percentage = int(value.Int64)
+			}
+		case featureflag.FieldRules:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field rules", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ff.Rules); err != nil {
+					return fmt.Errorf("unmarshal field rules: %w", err)
+				}
+			}
+		case featureflag.FieldDescription:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field description", values[i])
+			} else if value.Valid {
+				ff.Description = value.String
+			}
+		case featureflag.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				ff.CreatedAt = value.Time
+			}
+		case featureflag.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				ff.UpdatedAt = value.Time
+			}
+		default:
+			ff.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the FeatureFlag.
+// This includes values selected through modifiers, order, etc.
+func (ff *FeatureFlag) Value(name string) (ent.Value, error) {
+	return ff.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this FeatureFlag.
+// Note that you need to call FeatureFlag.Unwrap() before calling this method if this FeatureFlag
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (ff *FeatureFlag) Update() *FeatureFlagUpdateOne {
+	return NewFeatureFlagClient(ff.config).UpdateOne(ff)
+}
+
+// Unwrap unwraps the FeatureFlag entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (ff *FeatureFlag) Unwrap() *FeatureFlag {
+	_tx, ok := ff.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: FeatureFlag is not a transactional entity")
+	}
+	ff.config.driver = _tx.drv
+	return ff
+}
+
+// String implements the fmt.Stringer.
+func (ff *FeatureFlag) String() string {
+	var builder strings.Builder
+	builder.WriteString("FeatureFlag(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", ff.ID))
+	builder.WriteString("key=")
+	builder.WriteString(ff.Key)
+	builder.WriteString(", ")
+	builder.WriteString("enabled=")
+	builder.WriteString(fmt.Sprintf("%v", ff.Enabled))
+	builder.WriteString(", ")
+	if v := ff.Percentage; v != nil {
+		builder.WriteString("percentage=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("rules=")
+	builder.WriteString(fmt.Sprintf("%v", ff.Rules))
+	builder.WriteString(", ")
+	builder.WriteString("description=")
+	builder.WriteString(ff.Description)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(ff.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(ff.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// FeatureFlags is a parsable slice of FeatureFlag.
+type FeatureFlags []*FeatureFlag
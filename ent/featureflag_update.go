@@ -0,0 +1,456 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/featureflag"
+	"doghole/ent/predicate"
+	"doghole/featureflag/rules"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+)
+
+// FeatureFlagUpdate is the builder for updating FeatureFlag entities.
+type FeatureFlagUpdate struct {
+	config
+	hooks    []Hook
+	mutation *FeatureFlagMutation
+}
+
+// Where appends a list predicates to the FeatureFlagUpdate builder.
+func (ffu *FeatureFlagUpdate) Where(ps ...predicate.FeatureFlag) *FeatureFlagUpdate {
+	ffu.mutation.Where(ps...)
+	return ffu
+}
+
+// SetEnabled sets the "enabled" field.
+func (ffu *FeatureFlagUpdate) SetEnabled(b bool) *FeatureFlagUpdate {
+	ffu.mutation.SetEnabled(b)
+	return ffu
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (ffu *FeatureFlagUpdate) SetNillableEnabled(b *bool) *FeatureFlagUpdate {
+	if b != nil {
+		ffu.SetEnabled(*b)
+	}
+	return ffu
+}
+
+// SetPercentage sets the "percentage" field.
+func (ffu *FeatureFlagUpdate) SetPercentage(i int) *FeatureFlagUpdate {
+	ffu.mutation.ResetPercentage()
+	ffu.mutation.SetPercentage(i)
+	return ffu
+}
+
+// SetNillablePercentage sets the "percentage" field if the given value is not nil.
+func (ffu *FeatureFlagUpdate) SetNillablePercentage(i *int) *FeatureFlagUpdate {
+	if i != nil {
+		ffu.SetPercentage(*i)
+	}
+	return ffu
+}
+
+// AddPercentage adds i to the "percentage" field.
+func (ffu *FeatureFlagUpdate) AddPercentage(i int) *FeatureFlagUpdate {
+	ffu.mutation.AddPercentage(i)
+	return ffu
+}
+
+// ClearPercentage clears the value of the "percentage" field.
+func (ffu *FeatureFlagUpdate) ClearPercentage() *FeatureFlagUpdate {
+	ffu.mutation.ClearPercentage()
+	return ffu
+}
+
+// SetRules sets the "rules" field.
+func (ffu *FeatureFlagUpdate) SetRules(r []rules.Rule) *FeatureFlagUpdate {
+	ffu.mutation.SetRules(r)
+	return ffu
+}
+
+// AppendRules appends r to the "rules" field.
+func (ffu *FeatureFlagUpdate) AppendRules(r []rules.Rule) *FeatureFlagUpdate {
+	ffu.mutation.AppendRules(r)
+	return ffu
+}
+
+// ClearRules clears the value of the "rules" field.
+func (ffu *FeatureFlagUpdate) ClearRules() *FeatureFlagUpdate {
+	ffu.mutation.ClearRules()
+	return ffu
+}
+
+// SetDescription sets the "description" field.
+func (ffu *FeatureFlagUpdate) SetDescription(s string) *FeatureFlagUpdate {
+	ffu.mutation.SetDescription(s)
+	return ffu
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (ffu *FeatureFlagUpdate) SetNillableDescription(s *string) *FeatureFlagUpdate {
+	if s != nil {
+		ffu.SetDescription(*s)
+	}
+	return ffu
+}
+
+// ClearDescription clears the value of the "description" field.
+func (ffu *FeatureFlagUpdate) ClearDescription() *FeatureFlagUpdate {
+	ffu.mutation.ClearDescription()
+	return ffu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ffu *FeatureFlagUpdate) SetUpdatedAt(t time.Time) *FeatureFlagUpdate {
+	ffu.mutation.SetUpdatedAt(t)
+	return ffu
+}
+
+// Mutation returns the FeatureFlagMutation object of the builder.
+func (ffu *FeatureFlagUpdate) Mutation() *FeatureFlagMutation {
+	return ffu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ffu *FeatureFlagUpdate) Save(ctx context.Context) (int, error) {
+	ffu.defaults()
+	return withHooks(ctx, ffu.sqlSave, ffu.mutation, ffu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ffu *FeatureFlagUpdate) SaveX(ctx context.Context) int {
+	affected, err := ffu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ffu *FeatureFlagUpdate) Exec(ctx context.Context) error {
+	_, err := ffu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ffu *FeatureFlagUpdate) ExecX(ctx context.Context) {
+	if err := ffu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ffu *FeatureFlagUpdate) defaults() {
+	if _, ok := ffu.mutation.UpdatedAt(); !ok {
+		v := featureflag.UpdateDefaultUpdatedAt()
+		ffu.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ffu *FeatureFlagUpdate) check() error {
+	if v, ok := ffu.mutation.Percentage(); ok {
+		if err := featureflag.PercentageValidator(v); err != nil {
+			return &ValidationError{Name: "percentage", err: fmt.Errorf(`ent: validator failed for field "FeatureFlag.percentage": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ffu *FeatureFlagUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := ffu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(featureflag.Table, featureflag.Columns, sqlgraph.NewFieldSpec(featureflag.FieldID, field.TypeInt))
+	if ps := ffu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ffu.mutation.Enabled(); ok {
+		_spec.SetField(featureflag.FieldEnabled, field.TypeBool, value)
+	}
+	if value, ok := ffu.mutation.Percentage(); ok {
+		_spec.SetField(featureflag.FieldPercentage, field.TypeInt, value)
+	}
+	if value, ok := ffu.mutation.AddedPercentage(); ok {
+		_spec.AddField(featureflag.FieldPercentage, field.TypeInt, value)
+	}
+	if ffu.mutation.PercentageCleared() {
+		_spec.ClearField(featureflag.FieldPercentage, field.TypeInt)
+	}
+	if value, ok := ffu.mutation.Rules(); ok {
+		_spec.SetField(featureflag.FieldRules, field.TypeJSON, value)
+	}
+	if value, ok := ffu.mutation.AppendedRules(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, featureflag.FieldRules, value)
+		})
+	}
+	if ffu.mutation.RulesCleared() {
+		_spec.ClearField(featureflag.FieldRules, field.TypeJSON)
+	}
+	if value, ok := ffu.mutation.Description(); ok {
+		_spec.SetField(featureflag.FieldDescription, field.TypeString, value)
+	}
+	if ffu.mutation.DescriptionCleared() {
+		_spec.ClearField(featureflag.FieldDescription, field.TypeString)
+	}
+	if value, ok := ffu.mutation.UpdatedAt(); ok {
+		_spec.SetField(featureflag.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ffu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{featureflag.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	ffu.mutation.done = true
+	return n, nil
+}
+
+// FeatureFlagUpdateOne is the builder for updating a single FeatureFlag entity.
+type FeatureFlagUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *FeatureFlagMutation
+}
+
+// SetEnabled sets the "enabled" field.
+func (ffuo *FeatureFlagUpdateOne) SetEnabled(b bool) *FeatureFlagUpdateOne {
+	ffuo.mutation.SetEnabled(b)
+	return ffuo
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (ffuo *FeatureFlagUpdateOne) SetNillableEnabled(b *bool) *FeatureFlagUpdateOne {
+	if b != nil {
+		ffuo.SetEnabled(*b)
+	}
+	return ffuo
+}
+
+// SetPercentage sets the "percentage" field.
+func (ffuo *FeatureFlagUpdateOne) SetPercentage(i int) *FeatureFlagUpdateOne {
+	ffuo.mutation.ResetPercentage()
+	ffuo.mutation.SetPercentage(i)
+	return ffuo
+}
+
+// SetNillablePercentage sets the "percentage" field if the given value is not nil.
+func (ffuo *FeatureFlagUpdateOne) SetNillablePercentage(i *int) *FeatureFlagUpdateOne {
+	if i != nil {
+		ffuo.SetPercentage(*i)
+	}
+	return ffuo
+}
+
+// AddPercentage adds i to the "percentage" field.
+func (ffuo *FeatureFlagUpdateOne) AddPercentage(i int) *FeatureFlagUpdateOne {
+	ffuo.mutation.AddPercentage(i)
+	return ffuo
+}
+
+// ClearPercentage clears the value of the "percentage" field.
+func (ffuo *FeatureFlagUpdateOne) ClearPercentage() *FeatureFlagUpdateOne {
+	ffuo.mutation.ClearPercentage()
+	return ffuo
+}
+
+// SetRules sets the "rules" field.
+func (ffuo *FeatureFlagUpdateOne) SetRules(r []rules.Rule) *FeatureFlagUpdateOne {
+	ffuo.mutation.SetRules(r)
+	return ffuo
+}
+
+// AppendRules appends r to the "rules" field.
+func (ffuo *FeatureFlagUpdateOne) AppendRules(r []rules.Rule) *FeatureFlagUpdateOne {
+	ffuo.mutation.AppendRules(r)
+	return ffuo
+}
+
+// ClearRules clears the value of the "rules" field.
+func (ffuo *FeatureFlagUpdateOne) ClearRules() *FeatureFlagUpdateOne {
+	ffuo.mutation.ClearRules()
+	return ffuo
+}
+
+// SetDescription sets the "description" field.
+func (ffuo *FeatureFlagUpdateOne) SetDescription(s string) *FeatureFlagUpdateOne {
+	ffuo.mutation.SetDescription(s)
+	return ffuo
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (ffuo *FeatureFlagUpdateOne) SetNillableDescription(s *string) *FeatureFlagUpdateOne {
+	if s != nil {
+		ffuo.SetDescription(*s)
+	}
+	return ffuo
+}
+
+// ClearDescription clears the value of the "description" field.
+func (ffuo *FeatureFlagUpdateOne) ClearDescription() *FeatureFlagUpdateOne {
+	ffuo.mutation.ClearDescription()
+	return ffuo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ffuo *FeatureFlagUpdateOne) SetUpdatedAt(t time.Time) *FeatureFlagUpdateOne {
+	ffuo.mutation.SetUpdatedAt(t)
+	return ffuo
+}
+
+// Mutation returns the FeatureFlagMutation object of the builder.
+func (ffuo *FeatureFlagUpdateOne) Mutation() *FeatureFlagMutation {
+	return ffuo.mutation
+}
+
+// Where appends a list predicates to the FeatureFlagUpdate builder.
+func (ffuo *FeatureFlagUpdateOne) Where(ps ...predicate.FeatureFlag) *FeatureFlagUpdateOne {
+	ffuo.mutation.Where(ps...)
+	return ffuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ffuo *FeatureFlagUpdateOne) Select(field string, fields ...string) *FeatureFlagUpdateOne {
+	ffuo.fields = append([]string{field}, fields...)
+	return ffuo
+}
+
+// Save executes the query and returns the updated FeatureFlag entity.
+func (ffuo *FeatureFlagUpdateOne) Save(ctx context.Context) (*FeatureFlag, error) {
+	ffuo.defaults()
+	return withHooks(ctx, ffuo.sqlSave, ffuo.mutation, ffuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ffuo *FeatureFlagUpdateOne) SaveX(ctx context.Context) *FeatureFlag {
+	node, err := ffuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ffuo *FeatureFlagUpdateOne) Exec(ctx context.Context) error {
+	_, err := ffuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ffuo *FeatureFlagUpdateOne) ExecX(ctx context.Context) {
+	if err := ffuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ffuo *FeatureFlagUpdateOne) defaults() {
+	if _, ok := ffuo.mutation.UpdatedAt(); !ok {
+		v := featureflag.UpdateDefaultUpdatedAt()
+		ffuo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ffuo *FeatureFlagUpdateOne) check() error {
+	if v, ok := ffuo.mutation.Percentage(); ok {
+		if err := featureflag.PercentageValidator(v); err != nil {
+			return &ValidationError{Name: "percentage", err: fmt.Errorf(`ent: validator failed for field "FeatureFlag.percentage": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ffuo *FeatureFlagUpdateOne) sqlSave(ctx context.Context) (_node *FeatureFlag, err error) {
+	if err := ffuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(featureflag.Table, featureflag.Columns, sqlgraph.NewFieldSpec(featureflag.FieldID, field.TypeInt))
+	id, ok := ffuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "FeatureFlag.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ffuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, featureflag.FieldID)
+		for _, f := range fields {
+			if !featureflag.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != featureflag.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ffuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ffuo.mutation.Enabled(); ok {
+		_spec.SetField(featureflag.FieldEnabled, field.TypeBool, value)
+	}
+	if value, ok := ffuo.mutation.Percentage(); ok {
+		_spec.SetField(featureflag.FieldPercentage, field.TypeInt, value)
+	}
+	if value, ok := ffuo.mutation.AddedPercentage(); ok {
+		_spec.AddField(featureflag.FieldPercentage, field.TypeInt, value)
+	}
+	if ffuo.mutation.PercentageCleared() {
+		_spec.ClearField(featureflag.FieldPercentage, field.TypeInt)
+	}
+	if value, ok := ffuo.mutation.Rules(); ok {
+		_spec.SetField(featureflag.FieldRules, field.TypeJSON, value)
+	}
+	if value, ok := ffuo.mutation.AppendedRules(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, featureflag.FieldRules, value)
+		})
+	}
+	if ffuo.mutation.RulesCleared() {
+		_spec.ClearField(featureflag.FieldRules, field.TypeJSON)
+	}
+	if value, ok := ffuo.mutation.Description(); ok {
+		_spec.SetField(featureflag.FieldDescription, field.TypeString, value)
+	}
+	if ffuo.mutation.DescriptionCleared() {
+		_spec.ClearField(featureflag.FieldDescription, field.TypeString)
+	}
+	if value, ok := ffuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(featureflag.FieldUpdatedAt, field.TypeTime, value)
+	}
+	_node = &FeatureFlag{config: ffuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ffuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{featureflag.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ffuo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,339 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/file"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// FileCreate is the builder for creating a File entity.
+type FileCreate struct {
+	config
+	mutation *FileMutation
+	hooks    []Hook
+}
+
+// SetKey sets the "key" field.
+func (fc *FileCreate) SetKey(s string) *FileCreate {
+	fc.mutation.SetKey(s)
+	return fc
+}
+
+// SetBackend sets the "backend" field.
+func (fc *FileCreate) SetBackend(s string) *FileCreate {
+	fc.mutation.SetBackend(s)
+	return fc
+}
+
+// SetName sets the "name" field.
+func (fc *FileCreate) SetName(s string) *FileCreate {
+	fc.mutation.SetName(s)
+	return fc
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (fc *FileCreate) SetNillableName(s *string) *FileCreate {
+	if s != nil {
+		fc.SetName(*s)
+	}
+	return fc
+}
+
+// SetContentType sets the "content_type" field.
+func (fc *FileCreate) SetContentType(s string) *FileCreate {
+	fc.mutation.SetContentType(s)
+	return fc
+}
+
+// SetSize sets the "size" field.
+func (fc *FileCreate) SetSize(i int64) *FileCreate {
+	fc.mutation.SetSize(i)
+	return fc
+}
+
+// SetChecksum sets the "checksum" field.
+func (fc *FileCreate) SetChecksum(s string) *FileCreate {
+	fc.mutation.SetChecksum(s)
+	return fc
+}
+
+// SetOwnerID sets the "owner_id" field.
+func (fc *FileCreate) SetOwnerID(s string) *FileCreate {
+	fc.mutation.SetOwnerID(s)
+	return fc
+}
+
+// SetNillableOwnerID sets the "owner_id" field if the given value is not nil.
+func (fc *FileCreate) SetNillableOwnerID(s *string) *FileCreate {
+	if s != nil {
+		fc.SetOwnerID(*s)
+	}
+	return fc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (fc *FileCreate) SetCreatedAt(t time.Time) *FileCreate {
+	fc.mutation.SetCreatedAt(t)
+	return fc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (fc *FileCreate) SetNillableCreatedAt(t *time.Time) *FileCreate {
+	if t != nil {
+		fc.SetCreatedAt(*t)
+	}
+	return fc
+}
+
+// Mutation returns the FileMutation object of the builder.
+func (fc *FileCreate) Mutation() *FileMutation {
+	return fc.mutation
+}
+
+// Save creates the File in the database.
+func (fc *FileCreate) Save(ctx context.Context) (*File, error) {
+	fc.defaults()
+	return withHooks(ctx, fc.sqlSave, fc.mutation, fc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (fc *FileCreate) SaveX(ctx context.Context) *File {
+	v, err := fc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (fc *FileCreate) Exec(ctx context.Context) error {
+	_, err := fc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (fc *FileCreate) ExecX(ctx context.Context) {
+	if err := fc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (fc *FileCreate) defaults() {
+	if _, ok := fc.mutation.Name(); !ok {
+		v := file.DefaultName
+		fc.mutation.SetName(v)
+	}
+	if _, ok := fc.mutation.OwnerID(); !ok {
+		v := file.DefaultOwnerID
+		fc.mutation.SetOwnerID(v)
+	}
+	if _, ok := fc.mutation.CreatedAt(); !ok {
+		v := file.DefaultCreatedAt()
+		fc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (fc *FileCreate) check() error {
+	if _, ok := fc.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "File.key"`)}
+	}
+	if v, ok := fc.mutation.Key(); ok {
+		if err := file.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "File.key": %w`, err)}
+		}
+	}
+	if _, ok := fc.mutation.Backend(); !ok {
+		return &ValidationError{Name: "backend", err: errors.New(`ent: missing required field "File.backend"`)}
+	}
+	if v, ok := fc.mutation.Backend(); ok {
+		if err := file.BackendValidator(v); err != nil {
+			return &ValidationError{Name: "backend", err: fmt.Errorf(`ent: validator failed for field "File.backend": %w`, err)}
+		}
+	}
+	if _, ok := fc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "File.name"`)}
+	}
+	if _, ok := fc.mutation.ContentType(); !ok {
+		return &ValidationError{Name: "content_type", err: errors.New(`ent: missing required field "File.content_type"`)}
+	}
+	if v, ok := fc.mutation.ContentType(); ok {
+		if err := file.ContentTypeValidator(v); err != nil {
+			return &ValidationError{Name: "content_type", err: fmt.Errorf(`ent: validator failed for field "File.content_type": %w`, err)}
+		}
+	}
+	if _, ok := fc.mutation.Size(); !ok {
+		return &ValidationError{Name: "size", err: errors.New(`ent: missing required field "File.size"`)}
+	}
+	if v, ok := fc.mutation.Size(); ok {
+		if err := file.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "File.size": %w`, err)}
+		}
+	}
+	if _, ok := fc.mutation.Checksum(); !ok {
+		return &ValidationError{Name: "checksum", err: errors.New(`ent: missing required field "File.checksum"`)}
+	}
+	if v, ok := fc.mutation.Checksum(); ok {
+		if err := file.ChecksumValidator(v); err != nil {
+			return &ValidationError{Name: "checksum", err: fmt.Errorf(`ent: validator failed for field "File.checksum": %w`, err)}
+		}
+	}
+	if _, ok := fc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "File.created_at"`)}
+	}
+	return nil
+}
+
+func (fc *FileCreate) sqlSave(ctx context.Context) (*File, error) {
+	if err := fc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := fc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, fc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	fc.mutation.id = &_node.ID
+	fc.mutation.done = true
+	return _node, nil
+}
+
+func (fc *FileCreate) createSpec() (*File, *sqlgraph.CreateSpec) {
+	var (
+		_node = &File{config: fc.config}
+		_spec = sqlgraph.NewCreateSpec(file.Table, sqlgraph.NewFieldSpec(file.FieldID, field.TypeInt))
+	)
+	if value, ok := fc.mutation.Key(); ok {
+		_spec.SetField(file.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := fc.mutation.Backend(); ok {
+		_spec.SetField(file.FieldBackend, field.TypeString, value)
+		_node.Backend = value
+	}
+	if value, ok := fc.mutation.Name(); ok {
+		_spec.SetField(file.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := fc.mutation.ContentType(); ok {
+		_spec.SetField(file.FieldContentType, field.TypeString, value)
+		_node.ContentType = value
+	}
+	if value, ok := fc.mutation.Size(); ok {
+		_spec.SetField(file.FieldSize, field.TypeInt64, value)
+		_node.Size = value
+	}
+	if value, ok := fc.mutation.Checksum(); ok {
+		_spec.SetField(file.FieldChecksum, field.TypeString, value)
+		_node.Checksum = value
+	}
+	if value, ok := fc.mutation.OwnerID(); ok {
+		_spec.SetField(file.FieldOwnerID, field.TypeString, value)
+		_node.OwnerID = value
+	}
+	if value, ok := fc.mutation.CreatedAt(); ok {
+		_spec.SetField(file.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// FileCreateBulk is the builder for creating many File entities in bulk.
+type FileCreateBulk struct {
+	config
+	err      error
+	builders []*FileCreate
+}
+
+// Save creates the File entities in the database.
+func (fcb *FileCreateBulk) Save(ctx context.Context) ([]*File, error) {
+	if fcb.err != nil {
+		return nil, fcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(fcb.builders))
+	nodes := make([]*File, len(fcb.builders))
+	mutators := make([]Mutator, len(fcb.builders))
+	for i := range fcb.builders {
+		func(i int, root context.Context) {
+			builder := fcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*FileMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, fcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, fcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, fcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (fcb *FileCreateBulk) SaveX(ctx context.Context) []*File {
+	v, err := fcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (fcb *FileCreateBulk) Exec(ctx context.Context) error {
+	_, err := fcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (fcb *FileCreateBulk) ExecX(ctx context.Context) {
+	if err := fcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,388 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/idempotencykey"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// IdempotencyKeyCreate is the builder for creating a IdempotencyKey entity.
+type IdempotencyKeyCreate struct {
+	config
+	mutation *IdempotencyKeyMutation
+	hooks    []Hook
+}
+
+// SetScope sets the "scope" field.
+func (ikc *IdempotencyKeyCreate) SetScope(s string) *IdempotencyKeyCreate {
+	ikc.mutation.SetScope(s)
+	return ikc
+}
+
+// SetNillableScope sets the "scope" field if the given value is not nil.
+func (ikc *IdempotencyKeyCreate) SetNillableScope(s *string) *IdempotencyKeyCreate {
+	if s != nil {
+		ikc.SetScope(*s)
+	}
+	return ikc
+}
+
+// SetKey sets the "key" field.
+func (ikc *IdempotencyKeyCreate) SetKey(s string) *IdempotencyKeyCreate {
+	ikc.mutation.SetKey(s)
+	return ikc
+}
+
+// SetMethod sets the "method" field.
+func (ikc *IdempotencyKeyCreate) SetMethod(s string) *IdempotencyKeyCreate {
+	ikc.mutation.SetMethod(s)
+	return ikc
+}
+
+// SetPath sets the "path" field.
+func (ikc *IdempotencyKeyCreate) SetPath(s string) *IdempotencyKeyCreate {
+	ikc.mutation.SetPath(s)
+	return ikc
+}
+
+// SetFingerprint sets the "fingerprint" field.
+func (ikc *IdempotencyKeyCreate) SetFingerprint(s string) *IdempotencyKeyCreate {
+	ikc.mutation.SetFingerprint(s)
+	return ikc
+}
+
+// SetState sets the "state" field.
+func (ikc *IdempotencyKeyCreate) SetState(i idempotencykey.State) *IdempotencyKeyCreate {
+	ikc.mutation.SetState(i)
+	return ikc
+}
+
+// SetNillableState sets the "state" field if the given value is not nil.
+func (ikc *IdempotencyKeyCreate) SetNillableState(i *idempotencykey.State) *IdempotencyKeyCreate {
+	if i != nil {
+		ikc.SetState(*i)
+	}
+	return ikc
+}
+
+// SetLockedUntil sets the "locked_until" field.
+func (ikc *IdempotencyKeyCreate) SetLockedUntil(t time.Time) *IdempotencyKeyCreate {
+	ikc.mutation.SetLockedUntil(t)
+	return ikc
+}
+
+// SetNillableLockedUntil sets the "locked_until" field if the given value is not nil.
+func (ikc *IdempotencyKeyCreate) SetNillableLockedUntil(t *time.Time) *IdempotencyKeyCreate {
+	if t != nil {
+		ikc.SetLockedUntil(*t)
+	}
+	return ikc
+}
+
+// SetStatusCode sets the "status_code" field.
+func (ikc *IdempotencyKeyCreate) SetStatusCode(i int) *IdempotencyKeyCreate {
+	ikc.mutation.SetStatusCode(i)
+	return ikc
+}
+
+// SetNillableStatusCode sets the "status_code" field if the given value is not nil.
+func (ikc *IdempotencyKeyCreate) SetNillableStatusCode(i *int) *IdempotencyKeyCreate {
+	if i != nil {
+		ikc.SetStatusCode(*i)
+	}
+	return ikc
+}
+
+// SetHeaders sets the "headers" field.
+func (ikc *IdempotencyKeyCreate) SetHeaders(m map[string][]string) *IdempotencyKeyCreate {
+	ikc.mutation.SetHeaders(m)
+	return ikc
+}
+
+// SetBody sets the "body" field.
+func (ikc *IdempotencyKeyCreate) SetBody(b []byte) *IdempotencyKeyCreate {
+	ikc.mutation.SetBody(b)
+	return ikc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (ikc *IdempotencyKeyCreate) SetCreatedAt(t time.Time) *IdempotencyKeyCreate {
+	ikc.mutation.SetCreatedAt(t)
+	return ikc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (ikc *IdempotencyKeyCreate) SetNillableCreatedAt(t *time.Time) *IdempotencyKeyCreate {
+	if t != nil {
+		ikc.SetCreatedAt(*t)
+	}
+	return ikc
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (ikc *IdempotencyKeyCreate) SetExpiresAt(t time.Time) *IdempotencyKeyCreate {
+	ikc.mutation.SetExpiresAt(t)
+	return ikc
+}
+
+// Mutation returns the IdempotencyKeyMutation object of the builder.
+func (ikc *IdempotencyKeyCreate) Mutation() *IdempotencyKeyMutation {
+	return ikc.mutation
+}
+
+// Save creates the IdempotencyKey in the database.
+func (ikc *IdempotencyKeyCreate) Save(ctx context.Context) (*IdempotencyKey, error) {
+	ikc.defaults()
+	return withHooks(ctx, ikc.sqlSave, ikc.mutation, ikc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ikc *IdempotencyKeyCreate) SaveX(ctx context.Context) *IdempotencyKey {
+	v, err := ikc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ikc *IdempotencyKeyCreate) Exec(ctx context.Context) error {
+	_, err := ikc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ikc *IdempotencyKeyCreate) ExecX(ctx context.Context) {
+	if err := ikc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ikc *IdempotencyKeyCreate) defaults() {
+	if _, ok := ikc.mutation.Scope(); !ok {
+		v := idempotencykey.DefaultScope
+		ikc.mutation.SetScope(v)
+	}
+	if _, ok := ikc.mutation.State(); !ok {
+		v := idempotencykey.DefaultState
+		ikc.mutation.SetState(v)
+	}
+	if _, ok := ikc.mutation.CreatedAt(); !ok {
+		v := idempotencykey.DefaultCreatedAt()
+		ikc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ikc *IdempotencyKeyCreate) check() error {
+	if _, ok := ikc.mutation.Scope(); !ok {
+		return &ValidationError{Name: "scope", err: errors.New(`ent: missing required field "IdempotencyKey.scope"`)}
+	}
+	if v, ok := ikc.mutation.Scope(); ok {
+		if err := idempotencykey.ScopeValidator(v); err != nil {
+			return &ValidationError{Name: "scope", err: fmt.Errorf(`ent: validator failed for field "IdempotencyKey.scope": %w`, err)}
+		}
+	}
+	if _, ok := ikc.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "IdempotencyKey.key"`)}
+	}
+	if v, ok := ikc.mutation.Key(); ok {
+		if err := idempotencykey.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "IdempotencyKey.key": %w`, err)}
+		}
+	}
+	if _, ok := ikc.mutation.Method(); !ok {
+		return &ValidationError{Name: "method", err: errors.New(`ent: missing required field "IdempotencyKey.method"`)}
+	}
+	if _, ok := ikc.mutation.Path(); !ok {
+		return &ValidationError{Name: "path", err: errors.New(`ent: missing required field "IdempotencyKey.path"`)}
+	}
+	if _, ok := ikc.mutation.Fingerprint(); !ok {
+		return &ValidationError{Name: "fingerprint", err: errors.New(`ent: missing required field "IdempotencyKey.fingerprint"`)}
+	}
+	if _, ok := ikc.mutation.State(); !ok {
+		return &ValidationError{Name: "state", err: errors.New(`ent: missing required field "IdempotencyKey.state"`)}
+	}
+	if v, ok := ikc.mutation.State(); ok {
+		if err := idempotencykey.StateValidator(v); err != nil {
+			return &ValidationError{Name: "state", err: fmt.Errorf(`ent: validator failed for field "IdempotencyKey.state": %w`, err)}
+		}
+	}
+	if _, ok := ikc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "IdempotencyKey.created_at"`)}
+	}
+	if _, ok := ikc.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "IdempotencyKey.expires_at"`)}
+	}
+	return nil
+}
+
+func (ikc *IdempotencyKeyCreate) sqlSave(ctx context.Context) (*IdempotencyKey, error) {
+	if err := ikc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := ikc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ikc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	ikc.mutation.id = &_node.ID
+	ikc.mutation.done = true
+	return _node, nil
+}
+
+func (ikc *IdempotencyKeyCreate) createSpec() (*IdempotencyKey, *sqlgraph.CreateSpec) {
+	var (
+		_node = &IdempotencyKey{config: ikc.config}
+		_spec = sqlgraph.NewCreateSpec(idempotencykey.Table, sqlgraph.NewFieldSpec(idempotencykey.FieldID, field.TypeInt))
+	)
+	if value, ok := ikc.mutation.Scope(); ok {
+		_spec.SetField(idempotencykey.FieldScope, field.TypeString, value)
+		_node.Scope = value
+	}
+	if value, ok := ikc.mutation.Key(); ok {
+		_spec.SetField(idempotencykey.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := ikc.mutation.Method(); ok {
+		_spec.SetField(idempotencykey.FieldMethod, field.TypeString, value)
+		_node.Method = value
+	}
+	if value, ok := ikc.mutation.Path(); ok {
+		_spec.SetField(idempotencykey.FieldPath, field.TypeString, value)
+		_node.Path = value
+	}
+	if value, ok := ikc.mutation.Fingerprint(); ok {
+		_spec.SetField(idempotencykey.FieldFingerprint, field.TypeString, value)
+		_node.Fingerprint = value
+	}
+	if value, ok := ikc.mutation.State(); ok {
+		_spec.SetField(idempotencykey.FieldState, field.TypeEnum, value)
+		_node.State = value
+	}
+	if value, ok := ikc.mutation.LockedUntil(); ok {
+		_spec.SetField(idempotencykey.FieldLockedUntil, field.TypeTime, value)
+		_node.LockedUntil = &value
+	}
+	if value, ok := ikc.mutation.StatusCode(); ok {
+		_spec.SetField(idempotencykey.FieldStatusCode, field.TypeInt, value)
+		_node.StatusCode = value
+	}
+	if value, ok := ikc.mutation.Headers(); ok {
+		_spec.SetField(idempotencykey.FieldHeaders, field.TypeJSON, value)
+		_node.Headers = value
+	}
+	if value, ok := ikc.mutation.Body(); ok {
+		_spec.SetField(idempotencykey.FieldBody, field.TypeBytes, value)
+		_node.Body = value
+	}
+	if value, ok := ikc.mutation.CreatedAt(); ok {
+		_spec.SetField(idempotencykey.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := ikc.mutation.ExpiresAt(); ok {
+		_spec.SetField(idempotencykey.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	return _node, _spec
+}
+
+// IdempotencyKeyCreateBulk is the builder for creating many IdempotencyKey entities in bulk.
+type IdempotencyKeyCreateBulk struct {
+	config
+	err      error
+	builders []*IdempotencyKeyCreate
+}
+
+// Save creates the IdempotencyKey entities in the database.
+func (ikcb *IdempotencyKeyCreateBulk) Save(ctx context.Context) ([]*IdempotencyKey, error) {
+	if ikcb.err != nil {
+		return nil, ikcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ikcb.builders))
+	nodes := make([]*IdempotencyKey, len(ikcb.builders))
+	mutators := make([]Mutator, len(ikcb.builders))
+	for i := range ikcb.builders {
+		func(i int, root context.Context) {
+			builder := ikcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*IdempotencyKeyMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ikcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ikcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ikcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ikcb *IdempotencyKeyCreateBulk) SaveX(ctx context.Context) []*IdempotencyKey {
+	v, err := ikcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ikcb *IdempotencyKeyCreateBulk) Exec(ctx context.Context) error {
+	_, err := ikcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ikcb *IdempotencyKeyCreateBulk) ExecX(ctx context.Context) {
+	if err := ikcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,480 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/job"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// JobCreate is the builder for creating a Job entity.
+type JobCreate struct {
+	config
+	mutation *JobMutation
+	hooks    []Hook
+}
+
+// SetQueue sets the "queue" field.
+func (jc *JobCreate) SetQueue(s string) *JobCreate {
+	jc.mutation.SetQueue(s)
+	return jc
+}
+
+// SetNillableQueue sets the "queue" field if the given value is not nil.
+func (jc *JobCreate) SetNillableQueue(s *string) *JobCreate {
+	if s != nil {
+		jc.SetQueue(*s)
+	}
+	return jc
+}
+
+// SetType sets the "type" field.
+func (jc *JobCreate) SetType(s string) *JobCreate {
+	jc.mutation.SetType(s)
+	return jc
+}
+
+// SetPayload sets the "payload" field.
+func (jc *JobCreate) SetPayload(s string) *JobCreate {
+	jc.mutation.SetPayload(s)
+	return jc
+}
+
+// SetNillablePayload sets the "payload" field if the given value is not nil.
+func (jc *JobCreate) SetNillablePayload(s *string) *JobCreate {
+	if s != nil {
+		jc.SetPayload(*s)
+	}
+	return jc
+}
+
+// SetStatus sets the "status" field.
+func (jc *JobCreate) SetStatus(j job.Status) *JobCreate {
+	jc.mutation.SetStatus(j)
+	return jc
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (jc *JobCreate) SetNillableStatus(j *job.Status) *JobCreate {
+	if j != nil {
+		jc.SetStatus(*j)
+	}
+	return jc
+}
+
+// SetAttempts sets the "attempts" field.
+func (jc *JobCreate) SetAttempts(i int) *JobCreate {
+	jc.mutation.SetAttempts(i)
+	return jc
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (jc *JobCreate) SetNillableAttempts(i *int) *JobCreate {
+	if i != nil {
+		jc.SetAttempts(*i)
+	}
+	return jc
+}
+
+// SetMaxAttempts sets the "max_attempts" field.
+func (jc *JobCreate) SetMaxAttempts(i int) *JobCreate {
+	jc.mutation.SetMaxAttempts(i)
+	return jc
+}
+
+// SetNillableMaxAttempts sets the "max_attempts" field if the given value is not nil.
+func (jc *JobCreate) SetNillableMaxAttempts(i *int) *JobCreate {
+	if i != nil {
+		jc.SetMaxAttempts(*i)
+	}
+	return jc
+}
+
+// SetRunAt sets the "run_at" field.
+func (jc *JobCreate) SetRunAt(t time.Time) *JobCreate {
+	jc.mutation.SetRunAt(t)
+	return jc
+}
+
+// SetNillableRunAt sets the "run_at" field if the given value is not nil.
+func (jc *JobCreate) SetNillableRunAt(t *time.Time) *JobCreate {
+	if t != nil {
+		jc.SetRunAt(*t)
+	}
+	return jc
+}
+
+// SetLockedUntil sets the "locked_until" field.
+func (jc *JobCreate) SetLockedUntil(t time.Time) *JobCreate {
+	jc.mutation.SetLockedUntil(t)
+	return jc
+}
+
+// SetNillableLockedUntil sets the "locked_until" field if the given value is not nil.
+func (jc *JobCreate) SetNillableLockedUntil(t *time.Time) *JobCreate {
+	if t != nil {
+		jc.SetLockedUntil(*t)
+	}
+	return jc
+}
+
+// SetLockedBy sets the "locked_by" field.
+func (jc *JobCreate) SetLockedBy(s string) *JobCreate {
+	jc.mutation.SetLockedBy(s)
+	return jc
+}
+
+// SetNillableLockedBy sets the "locked_by" field if the given value is not nil.
+func (jc *JobCreate) SetNillableLockedBy(s *string) *JobCreate {
+	if s != nil {
+		jc.SetLockedBy(*s)
+	}
+	return jc
+}
+
+// SetLastError sets the "last_error" field.
+func (jc *JobCreate) SetLastError(s string) *JobCreate {
+	jc.mutation.SetLastError(s)
+	return jc
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (jc *JobCreate) SetNillableLastError(s *string) *JobCreate {
+	if s != nil {
+		jc.SetLastError(*s)
+	}
+	return jc
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (jc *JobCreate) SetFinishedAt(t time.Time) *JobCreate {
+	jc.mutation.SetFinishedAt(t)
+	return jc
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (jc *JobCreate) SetNillableFinishedAt(t *time.Time) *JobCreate {
+	if t != nil {
+		jc.SetFinishedAt(*t)
+	}
+	return jc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (jc *JobCreate) SetCreatedAt(t time.Time) *JobCreate {
+	jc.mutation.SetCreatedAt(t)
+	return jc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (jc *JobCreate) SetNillableCreatedAt(t *time.Time) *JobCreate {
+	if t != nil {
+		jc.SetCreatedAt(*t)
+	}
+	return jc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (jc *JobCreate) SetUpdatedAt(t time.Time) *JobCreate {
+	jc.mutation.SetUpdatedAt(t)
+	return jc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (jc *JobCreate) SetNillableUpdatedAt(t *time.Time) *JobCreate {
+	if t != nil {
+		jc.SetUpdatedAt(*t)
+	}
+	return jc
+}
+
+// Mutation returns the JobMutation object of the builder.
+func (jc *JobCreate) Mutation() *JobMutation {
+	return jc.mutation
+}
+
+// Save creates the Job in the database.
+func (jc *JobCreate) Save(ctx context.Context) (*Job, error) {
+	jc.defaults()
+	return withHooks(ctx, jc.sqlSave, jc.mutation, jc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (jc *JobCreate) SaveX(ctx context.Context) *Job {
+	v, err := jc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (jc *JobCreate) Exec(ctx context.Context) error {
+	_, err := jc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (jc *JobCreate) ExecX(ctx context.Context) {
+	if err := jc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (jc *JobCreate) defaults() {
+	if _, ok := jc.mutation.Queue(); !ok {
+		v := job.DefaultQueue
+		jc.mutation.SetQueue(v)
+	}
+	if _, ok := jc.mutation.Payload(); !ok {
+		v := job.DefaultPayload
+		jc.mutation.SetPayload(v)
+	}
+	if _, ok := jc.mutation.Status(); !ok {
+		v := job.DefaultStatus
+		jc.mutation.SetStatus(v)
+	}
+	if _, ok := jc.mutation.Attempts(); !ok {
+		v := job.DefaultAttempts
+		jc.mutation.SetAttempts(v)
+	}
+	if _, ok := jc.mutation.MaxAttempts(); !ok {
+		v := job.DefaultMaxAttempts
+		jc.mutation.SetMaxAttempts(v)
+	}
+	if _, ok := jc.mutation.RunAt(); !ok {
+		v := job.DefaultRunAt()
+		jc.mutation.SetRunAt(v)
+	}
+	if _, ok := jc.mutation.LockedBy(); !ok {
+		v := job.DefaultLockedBy
+		jc.mutation.SetLockedBy(v)
+	}
+	if _, ok := jc.mutation.LastError(); !ok {
+		v := job.DefaultLastError
+		jc.mutation.SetLastError(v)
+	}
+	if _, ok := jc.mutation.CreatedAt(); !ok {
+		v := job.DefaultCreatedAt()
+		jc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := jc.mutation.UpdatedAt(); !ok {
+		v := job.DefaultUpdatedAt()
+		jc.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (jc *JobCreate) check() error {
+	if _, ok := jc.mutation.Queue(); !ok {
+		return &ValidationError{Name: "queue", err: errors.New(`ent: missing required field "Job.queue"`)}
+	}
+	if _, ok := jc.mutation.GetType(); !ok {
+		return &ValidationError{Name: "type", err: errors.New(`ent: missing required field "Job.type"`)}
+	}
+	if v, ok := jc.mutation.GetType(); ok {
+		if err := job.TypeValidator(v); err != nil {
+			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "Job.type": %w`, err)}
+		}
+	}
+	if _, ok := jc.mutation.Payload(); !ok {
+		return &ValidationError{Name: "payload", err: errors.New(`ent: missing required field "Job.payload"`)}
+	}
+	if _, ok := jc.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "Job.status"`)}
+	}
+	if v, ok := jc.mutation.Status(); ok {
+		if err := job.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Job.status": %w`, err)}
+		}
+	}
+	if _, ok := jc.mutation.Attempts(); !ok {
+		return &ValidationError{Name: "attempts", err: errors.New(`ent: missing required field "Job.attempts"`)}
+	}
+	if _, ok := jc.mutation.MaxAttempts(); !ok {
+		return &ValidationError{Name: "max_attempts", err: errors.New(`ent: missing required field "Job.max_attempts"`)}
+	}
+	if _, ok := jc.mutation.RunAt(); !ok {
+		return &ValidationError{Name: "run_at", err: errors.New(`ent: missing required field "Job.run_at"`)}
+	}
+	if _, ok := jc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Job.created_at"`)}
+	}
+	if _, ok := jc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Job.updated_at"`)}
+	}
+	return nil
+}
+
+func (jc *JobCreate) sqlSave(ctx context.Context) (*Job, error) {
+	if err := jc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := jc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, jc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	jc.mutation.id = &_node.ID
+	jc.mutation.done = true
+	return _node, nil
+}
+
+func (jc *JobCreate) createSpec() (*Job, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Job{config: jc.config}
+		_spec = sqlgraph.NewCreateSpec(job.Table, sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt))
+	)
+	if value, ok := jc.mutation.Queue(); ok {
+		_spec.SetField(job.FieldQueue, field.TypeString, value)
+		_node.Queue = value
+	}
+	if value, ok := jc.mutation.GetType(); ok {
+		_spec.SetField(job.FieldType, field.TypeString, value)
+		_node.Type = value
+	}
+	if value, ok := jc.mutation.Payload(); ok {
+		_spec.SetField(job.FieldPayload, field.TypeString, value)
+		_node.Payload = value
+	}
+	if value, ok := jc.mutation.Status(); ok {
+		_spec.SetField(job.FieldStatus, field.TypeEnum, value)
+		_node.Status = value
+	}
+	if value, ok := jc.mutation.Attempts(); ok {
+		_spec.SetField(job.FieldAttempts, field.TypeInt, value)
+		_node.Attempts = value
+	}
+	if value, ok := jc.mutation.MaxAttempts(); ok {
+		_spec.SetField(job.FieldMaxAttempts, field.TypeInt, value)
+		_node.MaxAttempts = value
+	}
+	if value, ok := jc.mutation.RunAt(); ok {
+		_spec.SetField(job.FieldRunAt, field.TypeTime, value)
+		_node.RunAt = value
+	}
+	if value, ok := jc.mutation.LockedUntil(); ok {
+		_spec.SetField(job.FieldLockedUntil, field.TypeTime, value)
+		_node.LockedUntil = &value
+	}
+	if value, ok := jc.mutation.LockedBy(); ok {
+		_spec.SetField(job.FieldLockedBy, field.TypeString, value)
+		_node.LockedBy = value
+	}
+	if value, ok := jc.mutation.LastError(); ok {
+		_spec.SetField(job.FieldLastError, field.TypeString, value)
+		_node.LastError = value
+	}
+	if value, ok := jc.mutation.FinishedAt(); ok {
+		_spec.SetField(job.FieldFinishedAt, field.TypeTime, value)
+		_node.FinishedAt = &value
+	}
+	if value, ok := jc.mutation.CreatedAt(); ok {
+		_spec.SetField(job.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := jc.mutation.UpdatedAt(); ok {
+		_spec.SetField(job.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// JobCreateBulk is the builder for creating many Job entities in bulk.
+type JobCreateBulk struct {
+	config
+	err      error
+	builders []*JobCreate
+}
+
+// Save creates the Job entities in the database.
+func (jcb *JobCreateBulk) Save(ctx context.Context) ([]*Job, error) {
+	if jcb.err != nil {
+		return nil, jcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(jcb.builders))
+	nodes := make([]*Job, len(jcb.builders))
+	mutators := make([]Mutator, len(jcb.builders))
+	for i := range jcb.builders {
+		func(i int, root context.Context) {
+			builder := jcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*JobMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, jcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, jcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, jcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (jcb *JobCreateBulk) SaveX(ctx context.Context) []*Job {
+	v, err := jcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (jcb *JobCreateBulk) Exec(ctx context.Context) error {
+	_, err := jcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (jcb *JobCreateBulk) ExecX(ctx context.Context) {
+	if err := jcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
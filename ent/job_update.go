@@ -0,0 +1,622 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/job"
+	"doghole/ent/predicate"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// JobUpdate is the builder for updating Job entities.
+type JobUpdate struct {
+	config
+	hooks    []Hook
+	mutation *JobMutation
+}
+
+// Where appends a list predicates to the JobUpdate builder.
+func (ju *JobUpdate) Where(ps ...predicate.Job) *JobUpdate {
+	ju.mutation.Where(ps...)
+	return ju
+}
+
+// SetStatus sets the "status" field.
+func (ju *JobUpdate) SetStatus(j job.Status) *JobUpdate {
+	ju.mutation.SetStatus(j)
+	return ju
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (ju *JobUpdate) SetNillableStatus(j *job.Status) *JobUpdate {
+	if j != nil {
+		ju.SetStatus(*j)
+	}
+	return ju
+}
+
+// SetAttempts sets the "attempts" field.
+func (ju *JobUpdate) SetAttempts(i int) *JobUpdate {
+	ju.mutation.ResetAttempts()
+	ju.mutation.SetAttempts(i)
+	return ju
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (ju *JobUpdate) SetNillableAttempts(i *int) *JobUpdate {
+	if i != nil {
+		ju.SetAttempts(*i)
+	}
+	return ju
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (ju *JobUpdate) AddAttempts(i int) *JobUpdate {
+	ju.mutation.AddAttempts(i)
+	return ju
+}
+
+// SetMaxAttempts sets the "max_attempts" field.
+func (ju *JobUpdate) SetMaxAttempts(i int) *JobUpdate {
+	ju.mutation.ResetMaxAttempts()
+	ju.mutation.SetMaxAttempts(i)
+	return ju
+}
+
+// SetNillableMaxAttempts sets the "max_attempts" field if the given value is not nil.
+func (ju *JobUpdate) SetNillableMaxAttempts(i *int) *JobUpdate {
+	if i != nil {
+		ju.SetMaxAttempts(*i)
+	}
+	return ju
+}
+
+// AddMaxAttempts adds i to the "max_attempts" field.
+func (ju *JobUpdate) AddMaxAttempts(i int) *JobUpdate {
+	ju.mutation.AddMaxAttempts(i)
+	return ju
+}
+
+// SetRunAt sets the "run_at" field.
+func (ju *JobUpdate) SetRunAt(t time.Time) *JobUpdate {
+	ju.mutation.SetRunAt(t)
+	return ju
+}
+
+// SetNillableRunAt sets the "run_at" field if the given value is not nil.
+func (ju *JobUpdate) SetNillableRunAt(t *time.Time) *JobUpdate {
+	if t != nil {
+		ju.SetRunAt(*t)
+	}
+	return ju
+}
+
+// SetLockedUntil sets the "locked_until" field.
+func (ju *JobUpdate) SetLockedUntil(t time.Time) *JobUpdate {
+	ju.mutation.SetLockedUntil(t)
+	return ju
+}
+
+// SetNillableLockedUntil sets the "locked_until" field if the given value is not nil.
+func (ju *JobUpdate) SetNillableLockedUntil(t *time.Time) *JobUpdate {
+	if t != nil {
+		ju.SetLockedUntil(*t)
+	}
+	return ju
+}
+
+// ClearLockedUntil clears the value of the "locked_until" field.
+func (ju *JobUpdate) ClearLockedUntil() *JobUpdate {
+	ju.mutation.ClearLockedUntil()
+	return ju
+}
+
+// SetLockedBy sets the "locked_by" field.
+func (ju *JobUpdate) SetLockedBy(s string) *JobUpdate {
+	ju.mutation.SetLockedBy(s)
+	return ju
+}
+
+// SetNillableLockedBy sets the "locked_by" field if the given value is not nil.
+func (ju *JobUpdate) SetNillableLockedBy(s *string) *JobUpdate {
+	if s != nil {
+		ju.SetLockedBy(*s)
+	}
+	return ju
+}
+
+// ClearLockedBy clears the value of the "locked_by" field.
+func (ju *JobUpdate) ClearLockedBy() *JobUpdate {
+	ju.mutation.ClearLockedBy()
+	return ju
+}
+
+// SetLastError sets the "last_error" field.
+func (ju *JobUpdate) SetLastError(s string) *JobUpdate {
+	ju.mutation.SetLastError(s)
+	return ju
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (ju *JobUpdate) SetNillableLastError(s *string) *JobUpdate {
+	if s != nil {
+		ju.SetLastError(*s)
+	}
+	return ju
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (ju *JobUpdate) ClearLastError() *JobUpdate {
+	ju.mutation.ClearLastError()
+	return ju
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (ju *JobUpdate) SetFinishedAt(t time.Time) *JobUpdate {
+	ju.mutation.SetFinishedAt(t)
+	return ju
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (ju *JobUpdate) SetNillableFinishedAt(t *time.Time) *JobUpdate {
+	if t != nil {
+		ju.SetFinishedAt(*t)
+	}
+	return ju
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (ju *JobUpdate) ClearFinishedAt() *JobUpdate {
+	ju.mutation.ClearFinishedAt()
+	return ju
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ju *JobUpdate) SetUpdatedAt(t time.Time) *JobUpdate {
+	ju.mutation.SetUpdatedAt(t)
+	return ju
+}
+
+// Mutation returns the JobMutation object of the builder.
+func (ju *JobUpdate) Mutation() *JobMutation {
+	return ju.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ju *JobUpdate) Save(ctx context.Context) (int, error) {
+	ju.defaults()
+	return withHooks(ctx, ju.sqlSave, ju.mutation, ju.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ju *JobUpdate) SaveX(ctx context.Context) int {
+	affected, err := ju.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ju *JobUpdate) Exec(ctx context.Context) error {
+	_, err := ju.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ju *JobUpdate) ExecX(ctx context.Context) {
+	if err := ju.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ju *JobUpdate) defaults() {
+	if _, ok := ju.mutation.UpdatedAt(); !ok {
+		v := job.UpdateDefaultUpdatedAt()
+		ju.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ju *JobUpdate) check() error {
+	if v, ok := ju.mutation.Status(); ok {
+		if err := job.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Job.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ju *JobUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := ju.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(job.Table, job.Columns, sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt))
+	if ps := ju.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ju.mutation.Status(); ok {
+		_spec.SetField(job.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := ju.mutation.Attempts(); ok {
+		_spec.SetField(job.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := ju.mutation.AddedAttempts(); ok {
+		_spec.AddField(job.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := ju.mutation.MaxAttempts(); ok {
+		_spec.SetField(job.FieldMaxAttempts, field.TypeInt, value)
+	}
+	if value, ok := ju.mutation.AddedMaxAttempts(); ok {
+		_spec.AddField(job.FieldMaxAttempts, field.TypeInt, value)
+	}
+	if value, ok := ju.mutation.RunAt(); ok {
+		_spec.SetField(job.FieldRunAt, field.TypeTime, value)
+	}
+	if value, ok := ju.mutation.LockedUntil(); ok {
+		_spec.SetField(job.FieldLockedUntil, field.TypeTime, value)
+	}
+	if ju.mutation.LockedUntilCleared() {
+		_spec.ClearField(job.FieldLockedUntil, field.TypeTime)
+	}
+	if value, ok := ju.mutation.LockedBy(); ok {
+		_spec.SetField(job.FieldLockedBy, field.TypeString, value)
+	}
+	if ju.mutation.LockedByCleared() {
+		_spec.ClearField(job.FieldLockedBy, field.TypeString)
+	}
+	if value, ok := ju.mutation.LastError(); ok {
+		_spec.SetField(job.FieldLastError, field.TypeString, value)
+	}
+	if ju.mutation.LastErrorCleared() {
+		_spec.ClearField(job.FieldLastError, field.TypeString)
+	}
+	if value, ok := ju.mutation.FinishedAt(); ok {
+		_spec.SetField(job.FieldFinishedAt, field.TypeTime, value)
+	}
+	if ju.mutation.FinishedAtCleared() {
+		_spec.ClearField(job.FieldFinishedAt, field.TypeTime)
+	}
+	if value, ok := ju.mutation.UpdatedAt(); ok {
+		_spec.SetField(job.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ju.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{job.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	ju.mutation.done = true
+	return n, nil
+}
+
+// JobUpdateOne is the builder for updating a single Job entity.
+type JobUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *JobMutation
+}
+
+// SetStatus sets the "status" field.
+func (juo *JobUpdateOne) SetStatus(j job.Status) *JobUpdateOne {
+	juo.mutation.SetStatus(j)
+	return juo
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (juo *JobUpdateOne) SetNillableStatus(j *job.Status) *JobUpdateOne {
+	if j != nil {
+		juo.SetStatus(*j)
+	}
+	return juo
+}
+
+// SetAttempts sets the "attempts" field.
+func (juo *JobUpdateOne) SetAttempts(i int) *JobUpdateOne {
+	juo.mutation.ResetAttempts()
+	juo.mutation.SetAttempts(i)
+	return juo
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (juo *JobUpdateOne) SetNillableAttempts(i *int) *JobUpdateOne {
+	if i != nil {
+		juo.SetAttempts(*i)
+	}
+	return juo
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (juo *JobUpdateOne) AddAttempts(i int) *JobUpdateOne {
+	juo.mutation.AddAttempts(i)
+	return juo
+}
+
+// SetMaxAttempts sets the "max_attempts" field.
+func (juo *JobUpdateOne) SetMaxAttempts(i int) *JobUpdateOne {
+	juo.mutation.ResetMaxAttempts()
+	juo.mutation.SetMaxAttempts(i)
+	return juo
+}
+
+// SetNillableMaxAttempts sets the "max_attempts" field if the given value is not nil.
+func (juo *JobUpdateOne) SetNillableMaxAttempts(i *int) *JobUpdateOne {
+	if i != nil {
+		juo.SetMaxAttempts(*i)
+	}
+	return juo
+}
+
+// AddMaxAttempts adds i to the "max_attempts" field.
+func (juo *JobUpdateOne) AddMaxAttempts(i int) *JobUpdateOne {
+	juo.mutation.AddMaxAttempts(i)
+	return juo
+}
+
+// SetRunAt sets the "run_at" field.
+func (juo *JobUpdateOne) SetRunAt(t time.Time) *JobUpdateOne {
+	juo.mutation.SetRunAt(t)
+	return juo
+}
+
+// SetNillableRunAt sets the "run_at" field if the given value is not nil.
+func (juo *JobUpdateOne) SetNillableRunAt(t *time.Time) *JobUpdateOne {
+	if t != nil {
+		juo.SetRunAt(*t)
+	}
+	return juo
+}
+
+// SetLockedUntil sets the "locked_until" field.
+func (juo *JobUpdateOne) SetLockedUntil(t time.Time) *JobUpdateOne {
+	juo.mutation.SetLockedUntil(t)
+	return juo
+}
+
+// SetNillableLockedUntil sets the "locked_until" field if the given value is not nil.
+func (juo *JobUpdateOne) SetNillableLockedUntil(t *time.Time) *JobUpdateOne {
+	if t != nil {
+		juo.SetLockedUntil(*t)
+	}
+	return juo
+}
+
+// ClearLockedUntil clears the value of the "locked_until" field.
+func (juo *JobUpdateOne) ClearLockedUntil() *JobUpdateOne {
+	juo.mutation.ClearLockedUntil()
+	return juo
+}
+
+// SetLockedBy sets the "locked_by" field.
+func (juo *JobUpdateOne) SetLockedBy(s string) *JobUpdateOne {
+	juo.mutation.SetLockedBy(s)
+	return juo
+}
+
+// SetNillableLockedBy sets the "locked_by" field if the given value is not nil.
+func (juo *JobUpdateOne) SetNillableLockedBy(s *string) *JobUpdateOne {
+	if s != nil {
+		juo.SetLockedBy(*s)
+	}
+	return juo
+}
+
+// ClearLockedBy clears the value of the "locked_by" field.
+func (juo *JobUpdateOne) ClearLockedBy() *JobUpdateOne {
+	juo.mutation.ClearLockedBy()
+	return juo
+}
+
+// SetLastError sets the "last_error" field.
+func (juo *JobUpdateOne) SetLastError(s string) *JobUpdateOne {
+	juo.mutation.SetLastError(s)
+	return juo
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (juo *JobUpdateOne) SetNillableLastError(s *string) *JobUpdateOne {
+	if s != nil {
+		juo.SetLastError(*s)
+	}
+	return juo
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (juo *JobUpdateOne) ClearLastError() *JobUpdateOne {
+	juo.mutation.ClearLastError()
+	return juo
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (juo *JobUpdateOne) SetFinishedAt(t time.Time) *JobUpdateOne {
+	juo.mutation.SetFinishedAt(t)
+	return juo
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (juo *JobUpdateOne) SetNillableFinishedAt(t *time.Time) *JobUpdateOne {
+	if t != nil {
+		juo.SetFinishedAt(*t)
+	}
+	return juo
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (juo *JobUpdateOne) ClearFinishedAt() *JobUpdateOne {
+	juo.mutation.ClearFinishedAt()
+	return juo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (juo *JobUpdateOne) SetUpdatedAt(t time.Time) *JobUpdateOne {
+	juo.mutation.SetUpdatedAt(t)
+	return juo
+}
+
+// Mutation returns the JobMutation object of the builder.
+func (juo *JobUpdateOne) Mutation() *JobMutation {
+	return juo.mutation
+}
+
+// Where appends a list predicates to the JobUpdate builder.
+func (juo *JobUpdateOne) Where(ps ...predicate.Job) *JobUpdateOne {
+	juo.mutation.Where(ps...)
+	return juo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (juo *JobUpdateOne) Select(field string, fields ...string) *JobUpdateOne {
+	juo.fields = append([]string{field}, fields...)
+	return juo
+}
+
+// Save executes the query and returns the updated Job entity.
+func (juo *JobUpdateOne) Save(ctx context.Context) (*Job, error) {
+	juo.defaults()
+	return withHooks(ctx, juo.sqlSave, juo.mutation, juo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (juo *JobUpdateOne) SaveX(ctx context.Context) *Job {
+	node, err := juo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (juo *JobUpdateOne) Exec(ctx context.Context) error {
+	_, err := juo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (juo *JobUpdateOne) ExecX(ctx context.Context) {
+	if err := juo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (juo *JobUpdateOne) defaults() {
+	if _, ok := juo.mutation.UpdatedAt(); !ok {
+		v := job.UpdateDefaultUpdatedAt()
+		juo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (juo *JobUpdateOne) check() error {
+	if v, ok := juo.mutation.Status(); ok {
+		if err := job.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Job.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (juo *JobUpdateOne) sqlSave(ctx context.Context) (_node *Job, err error) {
+	if err := juo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(job.Table, job.Columns, sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt))
+	id, ok := juo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Job.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := juo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, job.FieldID)
+		for _, f := range fields {
+			if !job.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != job.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := juo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := juo.mutation.Status(); ok {
+		_spec.SetField(job.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := juo.mutation.Attempts(); ok {
+		_spec.SetField(job.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := juo.mutation.AddedAttempts(); ok {
+		_spec.AddField(job.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := juo.mutation.MaxAttempts(); ok {
+		_spec.SetField(job.FieldMaxAttempts, field.TypeInt, value)
+	}
+	if value, ok := juo.mutation.AddedMaxAttempts(); ok {
+		_spec.AddField(job.FieldMaxAttempts, field.TypeInt, value)
+	}
+	if value, ok := juo.mutation.RunAt(); ok {
+		_spec.SetField(job.FieldRunAt, field.TypeTime, value)
+	}
+	if value, ok := juo.mutation.LockedUntil(); ok {
+		_spec.SetField(job.FieldLockedUntil, field.TypeTime, value)
+	}
+	if juo.mutation.LockedUntilCleared() {
+		_spec.ClearField(job.FieldLockedUntil, field.TypeTime)
+	}
+	if value, ok := juo.mutation.LockedBy(); ok {
+		_spec.SetField(job.FieldLockedBy, field.TypeString, value)
+	}
+	if juo.mutation.LockedByCleared() {
+		_spec.ClearField(job.FieldLockedBy, field.TypeString)
+	}
+	if value, ok := juo.mutation.LastError(); ok {
+		_spec.SetField(job.FieldLastError, field.TypeString, value)
+	}
+	if juo.mutation.LastErrorCleared() {
+		_spec.ClearField(job.FieldLastError, field.TypeString)
+	}
+	if value, ok := juo.mutation.FinishedAt(); ok {
+		_spec.SetField(job.FieldFinishedAt, field.TypeTime, value)
+	}
+	if juo.mutation.FinishedAtCleared() {
+		_spec.ClearField(job.FieldFinishedAt, field.TypeTime)
+	}
+	if value, ok := juo.mutation.UpdatedAt(); ok {
+		_spec.SetField(job.FieldUpdatedAt, field.TypeTime, value)
+	}
+	_node = &Job{config: juo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, juo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{job.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	juo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,276 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/notificationpreference"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// NotificationPreferenceCreate is the builder for creating a NotificationPreference entity.
+type NotificationPreferenceCreate struct {
+	config
+	mutation *NotificationPreferenceMutation
+	hooks    []Hook
+}
+
+// SetUserID sets the "user_id" field.
+func (npc *NotificationPreferenceCreate) SetUserID(s string) *NotificationPreferenceCreate {
+	npc.mutation.SetUserID(s)
+	return npc
+}
+
+// SetChannel sets the "channel" field.
+func (npc *NotificationPreferenceCreate) SetChannel(n notificationpreference.Channel) *NotificationPreferenceCreate {
+	npc.mutation.SetChannel(n)
+	return npc
+}
+
+// SetTopic sets the "topic" field.
+func (npc *NotificationPreferenceCreate) SetTopic(s string) *NotificationPreferenceCreate {
+	npc.mutation.SetTopic(s)
+	return npc
+}
+
+// SetNillableTopic sets the "topic" field if the given value is not nil.
+func (npc *NotificationPreferenceCreate) SetNillableTopic(s *string) *NotificationPreferenceCreate {
+	if s != nil {
+		npc.SetTopic(*s)
+	}
+	return npc
+}
+
+// SetEnabled sets the "enabled" field.
+func (npc *NotificationPreferenceCreate) SetEnabled(b bool) *NotificationPreferenceCreate {
+	npc.mutation.SetEnabled(b)
+	return npc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (npc *NotificationPreferenceCreate) SetUpdatedAt(t time.Time) *NotificationPreferenceCreate {
+	npc.mutation.SetUpdatedAt(t)
+	return npc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (npc *NotificationPreferenceCreate) SetNillableUpdatedAt(t *time.Time) *NotificationPreferenceCreate {
+	if t != nil {
+		npc.SetUpdatedAt(*t)
+	}
+	return npc
+}
+
+// Mutation returns the NotificationPreferenceMutation object of the builder.
+func (npc *NotificationPreferenceCreate) Mutation() *NotificationPreferenceMutation {
+	return npc.mutation
+}
+
+// Save creates the NotificationPreference in the database.
+func (npc *NotificationPreferenceCreate) Save(ctx context.Context) (*NotificationPreference, error) {
+	npc.defaults()
+	return withHooks(ctx, npc.sqlSave, npc.mutation, npc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (npc *NotificationPreferenceCreate) SaveX(ctx context.Context) *NotificationPreference {
+	v, err := npc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (npc *NotificationPreferenceCreate) Exec(ctx context.Context) error {
+	_, err := npc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (npc *NotificationPreferenceCreate) ExecX(ctx context.Context) {
+	if err := npc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (npc *NotificationPreferenceCreate) defaults() {
+	if _, ok := npc.mutation.Topic(); !ok {
+		v := notificationpreference.DefaultTopic
+		npc.mutation.SetTopic(v)
+	}
+	if _, ok := npc.mutation.UpdatedAt(); !ok {
+		v := notificationpreference.DefaultUpdatedAt()
+		npc.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (npc *NotificationPreferenceCreate) check() error {
+	if _, ok := npc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "NotificationPreference.user_id"`)}
+	}
+	if v, ok := npc.mutation.UserID(); ok {
+		if err := notificationpreference.UserIDValidator(v); err != nil {
+			return &ValidationError{Name: "user_id", err: fmt.Errorf(`ent: validator failed for field "NotificationPreference.user_id": %w`, err)}
+		}
+	}
+	if _, ok := npc.mutation.Channel(); !ok {
+		return &ValidationError{Name: "channel", err: errors.New(`ent: missing required field "NotificationPreference.channel"`)}
+	}
+	if v, ok := npc.mutation.Channel(); ok {
+		if err := notificationpreference.ChannelValidator(v); err != nil {
+			return &ValidationError{Name: "channel", err: fmt.Errorf(`ent: validator failed for field "NotificationPreference.channel": %w`, err)}
+		}
+	}
+	if _, ok := npc.mutation.Topic(); !ok {
+		return &ValidationError{Name: "topic", err: errors.New(`ent: missing required field "NotificationPreference.topic"`)}
+	}
+	if _, ok := npc.mutation.Enabled(); !ok {
+		return &ValidationError{Name: "enabled", err: errors.New(`ent: missing required field "NotificationPreference.enabled"`)}
+	}
+	if _, ok := npc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "NotificationPreference.updated_at"`)}
+	}
+	return nil
+}
+
+func (npc *NotificationPreferenceCreate) sqlSave(ctx context.Context) (*NotificationPreference, error) {
+	if err := npc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := npc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, npc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	npc.mutation.id = &_node.ID
+	npc.mutation.done = true
+	return _node, nil
+}
+
+func (npc *NotificationPreferenceCreate) createSpec() (*NotificationPreference, *sqlgraph.CreateSpec) {
+	var (
+		_node = &NotificationPreference{config: npc.config}
+		_spec = sqlgraph.NewCreateSpec(notificationpreference.Table, sqlgraph.NewFieldSpec(notificationpreference.FieldID, field.TypeInt))
+	)
+	if value, ok := npc.mutation.UserID(); ok {
+		_spec.SetField(notificationpreference.FieldUserID, field.TypeString, value)
+		_node.UserID = value
+	}
+	if value, ok := npc.mutation.Channel(); ok {
+		_spec.SetField(notificationpreference.FieldChannel, field.TypeEnum, value)
+		_node.Channel = value
+	}
+	if value, ok := npc.mutation.Topic(); ok {
+		_spec.SetField(notificationpreference.FieldTopic, field.TypeString, value)
+		_node.Topic = value
+	}
+	if value, ok := npc.mutation.Enabled(); ok {
+		_spec.SetField(notificationpreference.FieldEnabled, field.TypeBool, value)
+		_node.Enabled = value
+	}
+	if value, ok := npc.mutation.UpdatedAt(); ok {
+		_spec.SetField(notificationpreference.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// NotificationPreferenceCreateBulk is the builder for creating many NotificationPreference entities in bulk.
+type NotificationPreferenceCreateBulk struct {
+	config
+	err      error
+	builders []*NotificationPreferenceCreate
+}
+
+// Save creates the NotificationPreference entities in the database.
+func (npcb *NotificationPreferenceCreateBulk) Save(ctx context.Context) ([]*NotificationPreference, error) {
+	if npcb.err != nil {
+		return nil, npcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(npcb.builders))
+	nodes := make([]*NotificationPreference, len(npcb.builders))
+	mutators := make([]Mutator, len(npcb.builders))
+	for i := range npcb.builders {
+		func(i int, root context.Context) {
+			builder := npcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*NotificationPreferenceMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, npcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, npcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, npcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (npcb *NotificationPreferenceCreateBulk) SaveX(ctx context.Context) []*NotificationPreference {
+	v, err := npcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (npcb *NotificationPreferenceCreateBulk) Exec(ctx context.Context) error {
+	_, err := npcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (npcb *NotificationPreferenceCreateBulk) ExecX(ctx context.Context) {
+	if err := npcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,213 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"doghole/ent/outbox"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// Outbox is the model entity for the Outbox schema.
+type Outbox struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// 聚合类型，如 User
+	AggregateType string `json:"aggregate_type,omitempty"`
+	// 聚合ID，同一聚合的事件按写入顺序发布
+	AggregateID string `json:"aggregate_id,omitempty"`
+	// 事件类型，如 user.registered
+	EventType string `json:"event_type,omitempty"`
+	// JSON格式的事件数据
+	Payload string `json:"payload,omitempty"`
+	// 事件元数据
+	Headers map[string]string `json:"headers,omitempty"`
+	// 已尝试发布次数
+	Attempts int `json:"attempts,omitempty"`
+	// 下一次尝试发布的时间，发布期间作为占用租约
+	NextAttemptAt time.Time `json:"next_attempt_at,omitempty"`
+	// 最近一次发布失败的错误信息
+	LastError string `json:"last_error,omitempty"`
+	// 发布成功的时间，为空表示尚未发布
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Outbox) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case outbox.FieldHeaders:
+			values[i] = new([]byte)
+		case outbox.FieldID, outbox.FieldAttempts:
+			values[i] = new(sql.NullInt64)
+		case outbox.FieldAggregateType, outbox.FieldAggregateID, outbox.FieldEventType, outbox.FieldPayload, outbox.FieldLastError:
+			values[i] = new(sql.NullString)
+		case outbox.FieldNextAttemptAt, outbox.FieldDeliveredAt, outbox.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Outbox fields.
+func (o *Outbox) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case outbox.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			o.ID = int(value.Int64)
+		case outbox.FieldAggregateType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field aggregate_type", values[i])
+			} else if value.Valid {
+				o.AggregateType = value.String
+			}
+		case outbox.FieldAggregateID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field aggregate_id", values[i])
+			} else if value.Valid {
+				o.AggregateID = value.String
+			}
+		case outbox.FieldEventType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field event_type", values[i])
+			} else if value.Valid {
+				o.EventType = value.String
+			}
+		case outbox.FieldPayload:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field payload", values[i])
+			} else if value.Valid {
+				o.Payload = value.String
+			}
+		case outbox.FieldHeaders:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field headers", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &o.Headers); err != nil {
+					return fmt.Errorf("unmarshal field headers: %w", err)
+				}
+			}
+		case outbox.FieldAttempts:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field attempts", values[i])
+			} else if value.Valid {
+				o.Attempts = int(value.Int64)
+			}
+		case outbox.FieldNextAttemptAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field next_attempt_at", values[i])
+			} else if value.Valid {
+				o.NextAttemptAt = value.Time
+			}
+		case outbox.FieldLastError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field last_error", values[i])
+			} else if value.Valid {
+				o.LastError = value.String
+			}
+		case outbox.FieldDeliveredAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field delivered_at", values[i])
+			} else if value.Valid {
+				o.DeliveredAt = new(time.Time)
+				*o.DeliveredAt = value.Time
+			}
+		case outbox.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				o.CreatedAt = value.Time
+			}
+		default:
+			o.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Outbox.
+// This includes values selected through modifiers, order, etc.
+func (o *Outbox) Value(name string) (ent.Value, error) {
+	return o.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Outbox.
+// Note that you need to call Outbox.Unwrap() before calling this method if this Outbox
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (o *Outbox) Update() *OutboxUpdateOne {
+	return NewOutboxClient(o.config).UpdateOne(o)
+}
+
+// Unwrap unwraps the Outbox entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (o *Outbox) Unwrap() *Outbox {
+	_tx, ok := o.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Outbox is not a transactional entity")
+	}
+	o.config.driver = _tx.drv
+	return o
+}
+
+// String implements the fmt.Stringer.
+func (o *Outbox) String() string {
+	var builder strings.Builder
+	builder.WriteString("Outbox(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", o.ID))
+	builder.WriteString("aggregate_type=")
+	builder.WriteString(o.AggregateType)
+	builder.WriteString(", ")
+	builder.WriteString("aggregate_id=")
+	builder.WriteString(o.AggregateID)
+	builder.WriteString(", ")
+	builder.WriteString("event_type=")
+	builder.WriteString(o.EventType)
+	builder.WriteString(", ")
+	builder.WriteString("payload=")
+	builder.WriteString(o.Payload)
+	builder.WriteString(", ")
+	builder.WriteString("headers=")
+	builder.WriteString(fmt.Sprintf("%v", o.Headers))
+	builder.WriteString(", ")
+	builder.WriteString("attempts=")
+	builder.WriteString(fmt.Sprintf("%v", o.Attempts))
+	builder.WriteString(", ")
+	builder.WriteString("next_attempt_at=")
+	builder.WriteString(o.NextAttemptAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("last_error=")
+	builder.WriteString(o.LastError)
+	builder.WriteString(", ")
+	if v := o.DeliveredAt; v != nil {
+		builder.WriteString("delivered_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(o.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Outboxes is a parsable slice of Outbox.
+type Outboxes []*Outbox
@@ -0,0 +1,381 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/outbox"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// OutboxCreate is the builder for creating a Outbox entity.
+type OutboxCreate struct {
+	config
+	mutation *OutboxMutation
+	hooks    []Hook
+}
+
+// SetAggregateType sets the "aggregate_type" field.
+func (oc *OutboxCreate) SetAggregateType(s string) *OutboxCreate {
+	oc.mutation.SetAggregateType(s)
+	return oc
+}
+
+// SetAggregateID sets the "aggregate_id" field.
+func (oc *OutboxCreate) SetAggregateID(s string) *OutboxCreate {
+	oc.mutation.SetAggregateID(s)
+	return oc
+}
+
+// SetEventType sets the "event_type" field.
+func (oc *OutboxCreate) SetEventType(s string) *OutboxCreate {
+	oc.mutation.SetEventType(s)
+	return oc
+}
+
+// SetPayload sets the "payload" field.
+func (oc *OutboxCreate) SetPayload(s string) *OutboxCreate {
+	oc.mutation.SetPayload(s)
+	return oc
+}
+
+// SetNillablePayload sets the "payload" field if the given value is not nil.
+func (oc *OutboxCreate) SetNillablePayload(s *string) *OutboxCreate {
+	if s != nil {
+		oc.SetPayload(*s)
+	}
+	return oc
+}
+
+// SetHeaders sets the "headers" field.
+func (oc *OutboxCreate) SetHeaders(m map[string]string) *OutboxCreate {
+	oc.mutation.SetHeaders(m)
+	return oc
+}
+
+// SetAttempts sets the "attempts" field.
+func (oc *OutboxCreate) SetAttempts(i int) *OutboxCreate {
+	oc.mutation.SetAttempts(i)
+	return oc
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (oc *OutboxCreate) SetNillableAttempts(i *int) *OutboxCreate {
+	if i != nil {
+		oc.SetAttempts(*i)
+	}
+	return oc
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (oc *OutboxCreate) SetNextAttemptAt(t time.Time) *OutboxCreate {
+	oc.mutation.SetNextAttemptAt(t)
+	return oc
+}
+
+// SetNillableNextAttemptAt sets the "next_attempt_at" field if the given value is not nil.
+func (oc *OutboxCreate) SetNillableNextAttemptAt(t *time.Time) *OutboxCreate {
+	if t != nil {
+		oc.SetNextAttemptAt(*t)
+	}
+	return oc
+}
+
+// SetLastError sets the "last_error" field.
+func (oc *OutboxCreate) SetLastError(s string) *OutboxCreate {
+	oc.mutation.SetLastError(s)
+	return oc
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (oc *OutboxCreate) SetNillableLastError(s *string) *OutboxCreate {
+	if s != nil {
+		oc.SetLastError(*s)
+	}
+	return oc
+}
+
+// SetDeliveredAt sets the "delivered_at" field.
+func (oc *OutboxCreate) SetDeliveredAt(t time.Time) *OutboxCreate {
+	oc.mutation.SetDeliveredAt(t)
+	return oc
+}
+
+// SetNillableDeliveredAt sets the "delivered_at" field if the given value is not nil.
+func (oc *OutboxCreate) SetNillableDeliveredAt(t *time.Time) *OutboxCreate {
+	if t != nil {
+		oc.SetDeliveredAt(*t)
+	}
+	return oc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (oc *OutboxCreate) SetCreatedAt(t time.Time) *OutboxCreate {
+	oc.mutation.SetCreatedAt(t)
+	return oc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (oc *OutboxCreate) SetNillableCreatedAt(t *time.Time) *OutboxCreate {
+	if t != nil {
+		oc.SetCreatedAt(*t)
+	}
+	return oc
+}
+
+// Mutation returns the OutboxMutation object of the builder.
+func (oc *OutboxCreate) Mutation() *OutboxMutation {
+	return oc.mutation
+}
+
+// Save creates the Outbox in the database.
+func (oc *OutboxCreate) Save(ctx context.Context) (*Outbox, error) {
+	oc.defaults()
+	return withHooks(ctx, oc.sqlSave, oc.mutation, oc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (oc *OutboxCreate) SaveX(ctx context.Context) *Outbox {
+	v, err := oc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (oc *OutboxCreate) Exec(ctx context.Context) error {
+	_, err := oc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (oc *OutboxCreate) ExecX(ctx context.Context) {
+	if err := oc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (oc *OutboxCreate) defaults() {
+	if _, ok := oc.mutation.Payload(); !ok {
+		v := outbox.DefaultPayload
+		oc.mutation.SetPayload(v)
+	}
+	if _, ok := oc.mutation.Attempts(); !ok {
+		v := outbox.DefaultAttempts
+		oc.mutation.SetAttempts(v)
+	}
+	if _, ok := oc.mutation.NextAttemptAt(); !ok {
+		v := outbox.DefaultNextAttemptAt()
+		oc.mutation.SetNextAttemptAt(v)
+	}
+	if _, ok := oc.mutation.LastError(); !ok {
+		v := outbox.DefaultLastError
+		oc.mutation.SetLastError(v)
+	}
+	if _, ok := oc.mutation.CreatedAt(); !ok {
+		v := outbox.DefaultCreatedAt()
+		oc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (oc *OutboxCreate) check() error {
+	if _, ok := oc.mutation.AggregateType(); !ok {
+		return &ValidationError{Name: "aggregate_type", err: errors.New(`ent: missing required field "Outbox.aggregate_type"`)}
+	}
+	if v, ok := oc.mutation.AggregateType(); ok {
+		if err := outbox.AggregateTypeValidator(v); err != nil {
+			return &ValidationError{Name: "aggregate_type", err: fmt.Errorf(`ent: validator failed for field "Outbox.aggregate_type": %w`, err)}
+		}
+	}
+	if _, ok := oc.mutation.AggregateID(); !ok {
+		return &ValidationError{Name: "aggregate_id", err: errors.New(`ent: missing required field "Outbox.aggregate_id"`)}
+	}
+	if v, ok := oc.mutation.AggregateID(); ok {
+		if err := outbox.AggregateIDValidator(v); err != nil {
+			return &ValidationError{Name: "aggregate_id", err: fmt.Errorf(`ent: validator failed for field "Outbox.aggregate_id": %w`, err)}
+		}
+	}
+	if _, ok := oc.mutation.EventType(); !ok {
+		return &ValidationError{Name: "event_type", err: errors.New(`ent: missing required field "Outbox.event_type"`)}
+	}
+	if v, ok := oc.mutation.EventType(); ok {
+		if err := outbox.EventTypeValidator(v); err != nil {
+			return &ValidationError{Name: "event_type", err: fmt.Errorf(`ent: validator failed for field "Outbox.event_type": %w`, err)}
+		}
+	}
+	if _, ok := oc.mutation.Payload(); !ok {
+		return &ValidationError{Name: "payload", err: errors.New(`ent: missing required field "Outbox.payload"`)}
+	}
+	if _, ok := oc.mutation.Attempts(); !ok {
+		return &ValidationError{Name: "attempts", err: errors.New(`ent: missing required field "Outbox.attempts"`)}
+	}
+	if _, ok := oc.mutation.NextAttemptAt(); !ok {
+		return &ValidationError{Name: "next_attempt_at", err: errors.New(`ent: missing required field "Outbox.next_attempt_at"`)}
+	}
+	if _, ok := oc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Outbox.created_at"`)}
+	}
+	return nil
+}
+
+func (oc *OutboxCreate) sqlSave(ctx context.Context) (*Outbox, error) {
+	if err := oc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := oc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, oc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	oc.mutation.id = &_node.ID
+	oc.mutation.done = true
+	return _node, nil
+}
+
+func (oc *OutboxCreate) createSpec() (*Outbox, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Outbox{config: oc.config}
+		_spec = sqlgraph.NewCreateSpec(outbox.Table, sqlgraph.NewFieldSpec(outbox.FieldID, field.TypeInt))
+	)
+	if value, ok := oc.mutation.AggregateType(); ok {
+		_spec.SetField(outbox.FieldAggregateType, field.TypeString, value)
+		_node.AggregateType = value
+	}
+	if value, ok := oc.mutation.AggregateID(); ok {
+		_spec.SetField(outbox.FieldAggregateID, field.TypeString, value)
+		_node.AggregateID = value
+	}
+	if value, ok := oc.mutation.EventType(); ok {
+		_spec.SetField(outbox.FieldEventType, field.TypeString, value)
+		_node.EventType = value
+	}
+	if value, ok := oc.mutation.Payload(); ok {
+		_spec.SetField(outbox.FieldPayload, field.TypeString, value)
+		_node.Payload = value
+	}
+	if value, ok := oc.mutation.Headers(); ok {
+		_spec.SetField(outbox.FieldHeaders, field.TypeJSON, value)
+		_node.Headers = value
+	}
+	if value, ok := oc.mutation.Attempts(); ok {
+		_spec.SetField(outbox.FieldAttempts, field.TypeInt, value)
+		_node.Attempts = value
+	}
+	if value, ok := oc.mutation.NextAttemptAt(); ok {
+		_spec.SetField(outbox.FieldNextAttemptAt, field.TypeTime, value)
+		_node.NextAttemptAt = value
+	}
+	if value, ok := oc.mutation.LastError(); ok {
+		_spec.SetField(outbox.FieldLastError, field.TypeString, value)
+		_node.LastError = value
+	}
+	if value, ok := oc.mutation.DeliveredAt(); ok {
+		_spec.SetField(outbox.FieldDeliveredAt, field.TypeTime, value)
+		_node.DeliveredAt = &value
+	}
+	if value, ok := oc.mutation.CreatedAt(); ok {
+		_spec.SetField(outbox.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// OutboxCreateBulk is the builder for creating many Outbox entities in bulk.
+type OutboxCreateBulk struct {
+	config
+	err      error
+	builders []*OutboxCreate
+}
+
+// Save creates the Outbox entities in the database.
+func (ocb *OutboxCreateBulk) Save(ctx context.Context) ([]*Outbox, error) {
+	if ocb.err != nil {
+		return nil, ocb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ocb.builders))
+	nodes := make([]*Outbox, len(ocb.builders))
+	mutators := make([]Mutator, len(ocb.builders))
+	for i := range ocb.builders {
+		func(i int, root context.Context) {
+			builder := ocb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*OutboxMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ocb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ocb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ocb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ocb *OutboxCreateBulk) SaveX(ctx context.Context) []*Outbox {
+	v, err := ocb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ocb *OutboxCreateBulk) Exec(ctx context.Context) error {
+	_, err := ocb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ocb *OutboxCreateBulk) ExecX(ctx context.Context) {
+	if err := ocb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
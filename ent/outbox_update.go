@@ -0,0 +1,374 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/outbox"
+	"doghole/ent/predicate"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// OutboxUpdate is the builder for updating Outbox entities.
+type OutboxUpdate struct {
+	config
+	hooks    []Hook
+	mutation *OutboxMutation
+}
+
+// Where appends a list predicates to the OutboxUpdate builder.
+func (ou *OutboxUpdate) Where(ps ...predicate.Outbox) *OutboxUpdate {
+	ou.mutation.Where(ps...)
+	return ou
+}
+
+// SetAttempts sets the "attempts" field.
+func (ou *OutboxUpdate) SetAttempts(i int) *OutboxUpdate {
+	ou.mutation.ResetAttempts()
+	ou.mutation.SetAttempts(i)
+	return ou
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (ou *OutboxUpdate) SetNillableAttempts(i *int) *OutboxUpdate {
+	if i != nil {
+		ou.SetAttempts(*i)
+	}
+	return ou
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (ou *OutboxUpdate) AddAttempts(i int) *OutboxUpdate {
+	ou.mutation.AddAttempts(i)
+	return ou
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (ou *OutboxUpdate) SetNextAttemptAt(t time.Time) *OutboxUpdate {
+	ou.mutation.SetNextAttemptAt(t)
+	return ou
+}
+
+// SetNillableNextAttemptAt sets the "next_attempt_at" field if the given value is not nil.
+func (ou *OutboxUpdate) SetNillableNextAttemptAt(t *time.Time) *OutboxUpdate {
+	if t != nil {
+		ou.SetNextAttemptAt(*t)
+	}
+	return ou
+}
+
+// SetLastError sets the "last_error" field.
+func (ou *OutboxUpdate) SetLastError(s string) *OutboxUpdate {
+	ou.mutation.SetLastError(s)
+	return ou
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (ou *OutboxUpdate) SetNillableLastError(s *string) *OutboxUpdate {
+	if s != nil {
+		ou.SetLastError(*s)
+	}
+	return ou
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (ou *OutboxUpdate) ClearLastError() *OutboxUpdate {
+	ou.mutation.ClearLastError()
+	return ou
+}
+
+// SetDeliveredAt sets the "delivered_at" field.
+func (ou *OutboxUpdate) SetDeliveredAt(t time.Time) *OutboxUpdate {
+	ou.mutation.SetDeliveredAt(t)
+	return ou
+}
+
+// SetNillableDeliveredAt sets the "delivered_at" field if the given value is not nil.
+func (ou *OutboxUpdate) SetNillableDeliveredAt(t *time.Time) *OutboxUpdate {
+	if t != nil {
+		ou.SetDeliveredAt(*t)
+	}
+	return ou
+}
+
+// ClearDeliveredAt clears the value of the "delivered_at" field.
+func (ou *OutboxUpdate) ClearDeliveredAt() *OutboxUpdate {
+	ou.mutation.ClearDeliveredAt()
+	return ou
+}
+
+// Mutation returns the OutboxMutation object of the builder.
+func (ou *OutboxUpdate) Mutation() *OutboxMutation {
+	return ou.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ou *OutboxUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, ou.sqlSave, ou.mutation, ou.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ou *OutboxUpdate) SaveX(ctx context.Context) int {
+	affected, err := ou.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ou *OutboxUpdate) Exec(ctx context.Context) error {
+	_, err := ou.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ou *OutboxUpdate) ExecX(ctx context.Context) {
+	if err := ou.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (ou *OutboxUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(outbox.Table, outbox.Columns, sqlgraph.NewFieldSpec(outbox.FieldID, field.TypeInt))
+	if ps := ou.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if ou.mutation.HeadersCleared() {
+		_spec.ClearField(outbox.FieldHeaders, field.TypeJSON)
+	}
+	if value, ok := ou.mutation.Attempts(); ok {
+		_spec.SetField(outbox.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := ou.mutation.AddedAttempts(); ok {
+		_spec.AddField(outbox.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := ou.mutation.NextAttemptAt(); ok {
+		_spec.SetField(outbox.FieldNextAttemptAt, field.TypeTime, value)
+	}
+	if value, ok := ou.mutation.LastError(); ok {
+		_spec.SetField(outbox.FieldLastError, field.TypeString, value)
+	}
+	if ou.mutation.LastErrorCleared() {
+		_spec.ClearField(outbox.FieldLastError, field.TypeString)
+	}
+	if value, ok := ou.mutation.DeliveredAt(); ok {
+		_spec.SetField(outbox.FieldDeliveredAt, field.TypeTime, value)
+	}
+	if ou.mutation.DeliveredAtCleared() {
+		_spec.ClearField(outbox.FieldDeliveredAt, field.TypeTime)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ou.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{outbox.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	ou.mutation.done = true
+	return n, nil
+}
+
+// OutboxUpdateOne is the builder for updating a single Outbox entity.
+type OutboxUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *OutboxMutation
+}
+
+// SetAttempts sets the "attempts" field.
+func (ouo *OutboxUpdateOne) SetAttempts(i int) *OutboxUpdateOne {
+	ouo.mutation.ResetAttempts()
+	ouo.mutation.SetAttempts(i)
+	return ouo
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (ouo *OutboxUpdateOne) SetNillableAttempts(i *int) *OutboxUpdateOne {
+	if i != nil {
+		ouo.SetAttempts(*i)
+	}
+	return ouo
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (ouo *OutboxUpdateOne) AddAttempts(i int) *OutboxUpdateOne {
+	ouo.mutation.AddAttempts(i)
+	return ouo
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (ouo *OutboxUpdateOne) SetNextAttemptAt(t time.Time) *OutboxUpdateOne {
+	ouo.mutation.SetNextAttemptAt(t)
+	return ouo
+}
+
+// SetNillableNextAttemptAt sets the "next_attempt_at" field if the given value is not nil.
+func (ouo *OutboxUpdateOne) SetNillableNextAttemptAt(t *time.Time) *OutboxUpdateOne {
+	if t != nil {
+		ouo.SetNextAttemptAt(*t)
+	}
+	return ouo
+}
+
+// SetLastError sets the "last_error" field.
+func (ouo *OutboxUpdateOne) SetLastError(s string) *OutboxUpdateOne {
+	ouo.mutation.SetLastError(s)
+	return ouo
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (ouo *OutboxUpdateOne) SetNillableLastError(s *string) *OutboxUpdateOne {
+	if s != nil {
+		ouo.SetLastError(*s)
+	}
+	return ouo
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (ouo *OutboxUpdateOne) ClearLastError() *OutboxUpdateOne {
+	ouo.mutation.ClearLastError()
+	return ouo
+}
+
+// SetDeliveredAt sets the "delivered_at" field.
+func (ouo *OutboxUpdateOne) SetDeliveredAt(t time.Time) *OutboxUpdateOne {
+	ouo.mutation.SetDeliveredAt(t)
+	return ouo
+}
+
+// SetNillableDeliveredAt sets the "delivered_at" field if the given value is not nil.
+func (ouo *OutboxUpdateOne) SetNillableDeliveredAt(t *time.Time) *OutboxUpdateOne {
+	if t != nil {
+		ouo.SetDeliveredAt(*t)
+	}
+	return ouo
+}
+
+// ClearDeliveredAt clears the value of the "delivered_at" field.
+func (ouo *OutboxUpdateOne) ClearDeliveredAt() *OutboxUpdateOne {
+	ouo.mutation.ClearDeliveredAt()
+	return ouo
+}
+
+// Mutation returns the OutboxMutation object of the builder.
+func (ouo *OutboxUpdateOne) Mutation() *OutboxMutation {
+	return ouo.mutation
+}
+
+// Where appends a list predicates to the OutboxUpdate builder.
+func (ouo *OutboxUpdateOne) Where(ps ...predicate.Outbox) *OutboxUpdateOne {
+	ouo.mutation.Where(ps...)
+	return ouo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ouo *OutboxUpdateOne) Select(field string, fields ...string) *OutboxUpdateOne {
+	ouo.fields = append([]string{field}, fields...)
+	return ouo
+}
+
+// Save executes the query and returns the updated Outbox entity.
+func (ouo *OutboxUpdateOne) Save(ctx context.Context) (*Outbox, error) {
+	return withHooks(ctx, ouo.sqlSave, ouo.mutation, ouo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ouo *OutboxUpdateOne) SaveX(ctx context.Context) *Outbox {
+	node, err := ouo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ouo *OutboxUpdateOne) Exec(ctx context.Context) error {
+	_, err := ouo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ouo *OutboxUpdateOne) ExecX(ctx context.Context) {
+	if err := ouo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (ouo *OutboxUpdateOne) sqlSave(ctx context.Context) (_node *Outbox, err error) {
+	_spec := sqlgraph.NewUpdateSpec(outbox.Table, outbox.Columns, sqlgraph.NewFieldSpec(outbox.FieldID, field.TypeInt))
+	id, ok := ouo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Outbox.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ouo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, outbox.FieldID)
+		for _, f := range fields {
+			if !outbox.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != outbox.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ouo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if ouo.mutation.HeadersCleared() {
+		_spec.ClearField(outbox.FieldHeaders, field.TypeJSON)
+	}
+	if value, ok := ouo.mutation.Attempts(); ok {
+		_spec.SetField(outbox.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := ouo.mutation.AddedAttempts(); ok {
+		_spec.AddField(outbox.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := ouo.mutation.NextAttemptAt(); ok {
+		_spec.SetField(outbox.FieldNextAttemptAt, field.TypeTime, value)
+	}
+	if value, ok := ouo.mutation.LastError(); ok {
+		_spec.SetField(outbox.FieldLastError, field.TypeString, value)
+	}
+	if ouo.mutation.LastErrorCleared() {
+		_spec.ClearField(outbox.FieldLastError, field.TypeString)
+	}
+	if value, ok := ouo.mutation.DeliveredAt(); ok {
+		_spec.SetField(outbox.FieldDeliveredAt, field.TypeTime, value)
+	}
+	if ouo.mutation.DeliveredAtCleared() {
+		_spec.ClearField(outbox.FieldDeliveredAt, field.TypeTime)
+	}
+	_node = &Outbox{config: ouo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ouo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{outbox.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ouo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,174 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"doghole/ent/ratelimitbucket"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// RateLimitBucket is the model entity for the RateLimitBucket schema.
+type RateLimitBucket struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// 限流键，由策略名和客户端标识组成
+	Key string `json:"key,omitempty"`
+	// 令牌桶剩余令牌数
+	Tokens float64 `json:"tokens,omitempty"`
+	// 滑动窗口当前窗口计数
+	Count int64 `json:"count,omitempty"`
+	// 滑动窗口上一窗口计数
+	PrevCount int64 `json:"prev_count,omitempty"`
+	// 滑动窗口当前窗口起始时间
+	WindowStart time.Time `json:"window_start,omitempty"`
+	// 最近一次更新时间
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// 过期时间，过期后状态可被清理
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*RateLimitBucket) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case ratelimitbucket.FieldTokens:
+			values[i] = new(sql.NullFloat64)
+		case ratelimitbucket.FieldID, ratelimitbucket.FieldCount, ratelimitbucket.FieldPrevCount:
+			values[i] = new(sql.NullInt64)
+		case ratelimitbucket.FieldKey:
+			values[i] = new(sql.NullString)
+		case ratelimitbucket.FieldWindowStart, ratelimitbucket.FieldUpdatedAt, ratelimitbucket.FieldExpiresAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the RateLimitBucket fields.
+func (rlb *RateLimitBucket) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case ratelimitbucket.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			rlb.ID = int(value.Int64)
+		case ratelimitbucket.FieldKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key", values[i])
+			} else if value.Valid {
+				rlb.Key = value.String
+			}
+		case ratelimitbucket.FieldTokens:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field tokens", values[i])
+			} else if value.Valid {
+				rlb.Tokens = value.Float64
+			}
+		case ratelimitbucket.FieldCount:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field count", values[i])
+			} else if value.Valid {
+				rlb.Count = value.Int64
+			}
+		case ratelimitbucket.FieldPrevCount:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field prev_count", values[i])
+			} else if value.Valid {
+				rlb.PrevCount = value.Int64
+			}
+		case ratelimitbucket.FieldWindowStart:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field window_start", values[i])
+			} else if value.Valid {
+				rlb.WindowStart = value.Time
+			}
+		case ratelimitbucket.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				rlb.UpdatedAt = value.Time
+			}
+		case ratelimitbucket.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				rlb.ExpiresAt = value.Time
+			}
+		default:
+			rlb.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the RateLimitBucket.
+// This includes values selected through modifiers, order, etc.
+func (rlb *RateLimitBucket) Value(name string) (ent.Value, error) {
+	return rlb.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this RateLimitBucket.
+// Note that you need to call RateLimitBucket.Unwrap() before calling this method if this RateLimitBucket
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (rlb *RateLimitBucket) Update() *RateLimitBucketUpdateOne {
+	return NewRateLimitBucketClient(rlb.config).UpdateOne(rlb)
+}
+
+// Unwrap unwraps the RateLimitBucket entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (rlb *RateLimitBucket) Unwrap() *RateLimitBucket {
+	_tx, ok := rlb.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: RateLimitBucket is not a transactional entity")
+	}
+	rlb.config.driver = _tx.drv
+	return rlb
+}
+
+// String implements the fmt.Stringer.
+func (rlb *RateLimitBucket) String() string {
+	var builder strings.Builder
+	builder.WriteString("RateLimitBucket(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", rlb.ID))
+	builder.WriteString("key=")
+	builder.WriteString(rlb.Key)
+	builder.WriteString(", ")
+	builder.WriteString("tokens=")
+	builder.WriteString(fmt.Sprintf("%v", rlb.Tokens))
+	builder.WriteString(", ")
+	builder.WriteString("count=")
+	builder.WriteString(fmt.Sprintf("%v", rlb.Count))
+	builder.WriteString(", ")
+	builder.WriteString("prev_count=")
+	builder.WriteString(fmt.Sprintf("%v", rlb.PrevCount))
+	builder.WriteString(", ")
+	builder.WriteString("window_start=")
+	builder.WriteString(rlb.WindowStart.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(rlb.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(rlb.ExpiresAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// RateLimitBuckets is a parsable slice of RateLimitBucket.
+type RateLimitBuckets []*RateLimitBucket
@@ -0,0 +1,564 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/predicate"
+	"doghole/ent/ratelimitbucket"
+	"fmt"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// RateLimitBucketQuery is the builder for querying RateLimitBucket entities.
+type RateLimitBucketQuery struct {
+	config
+	ctx        *QueryContext
+	order      []ratelimitbucket.OrderOption
+	inters     []Interceptor
+	predicates []predicate.RateLimitBucket
+	modifiers  []func(*sql.Selector)
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the RateLimitBucketQuery builder.
+func (rlbq *RateLimitBucketQuery) Where(ps ...predicate.RateLimitBucket) *RateLimitBucketQuery {
+	rlbq.predicates = append(rlbq.predicates, ps...)
+	return rlbq
+}
+
+// Limit the number of records to be returned by this query.
+func (rlbq *RateLimitBucketQuery) Limit(limit int) *RateLimitBucketQuery {
+	rlbq.ctx.Limit = &limit
+	return rlbq
+}
+
+// Offset to start from.
+func (rlbq *RateLimitBucketQuery) Offset(offset int) *RateLimitBucketQuery {
+	rlbq.ctx.Offset = &offset
+	return rlbq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (rlbq *RateLimitBucketQuery) Unique(unique bool) *RateLimitBucketQuery {
+	rlbq.ctx.Unique = &unique
+	return rlbq
+}
+
+// Order specifies how the records should be ordered.
+func (rlbq *RateLimitBucketQuery) Order(o ...ratelimitbucket.OrderOption) *RateLimitBucketQuery {
+	rlbq.order = append(rlbq.order, o...)
+	return rlbq
+}
+
+// First returns the first RateLimitBucket entity from the query.
+// Returns a *NotFoundError when no RateLimitBucket was found.
+func (rlbq *RateLimitBucketQuery) First(ctx context.Context) (*RateLimitBucket, error) {
+	nodes, err := rlbq.Limit(1).All(setContextOp(ctx, rlbq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{ratelimitbucket.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (rlbq *RateLimitBucketQuery) FirstX(ctx context.Context) *RateLimitBucket {
+	node, err := rlbq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first RateLimitBucket ID from the query.
+// Returns a *NotFoundError when no RateLimitBucket ID was found.
+func (rlbq *RateLimitBucketQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = rlbq.Limit(1).IDs(setContextOp(ctx, rlbq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{ratelimitbucket.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (rlbq *RateLimitBucketQuery) FirstIDX(ctx context.Context) int {
+	id, err := rlbq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single RateLimitBucket entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one RateLimitBucket entity is found.
+// Returns a *NotFoundError when no RateLimitBucket entities are found.
+func (rlbq *RateLimitBucketQuery) Only(ctx context.Context) (*RateLimitBucket, error) {
+	nodes, err := rlbq.Limit(2).All(setContextOp(ctx, rlbq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{ratelimitbucket.Label}
+	default:
+		return nil, &NotSingularError{ratelimitbucket.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (rlbq *RateLimitBucketQuery) OnlyX(ctx context.Context) *RateLimitBucket {
+	node, err := rlbq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only RateLimitBucket ID in the query.
+// Returns a *NotSingularError when more than one RateLimitBucket ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (rlbq *RateLimitBucketQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = rlbq.Limit(2).IDs(setContextOp(ctx, rlbq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{ratelimitbucket.Label}
+	default:
+		err = &NotSingularError{ratelimitbucket.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (rlbq *RateLimitBucketQuery) OnlyIDX(ctx context.Context) int {
+	id, err := rlbq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of RateLimitBuckets.
+func (rlbq *RateLimitBucketQuery) All(ctx context.Context) ([]*RateLimitBucket, error) {
+	ctx = setContextOp(ctx, rlbq.ctx, ent.OpQueryAll)
+	if err := rlbq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*RateLimitBucket, *RateLimitBucketQuery]()
+	return withInterceptors[[]*RateLimitBucket](ctx, rlbq, qr, rlbq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (rlbq *RateLimitBucketQuery) AllX(ctx context.Context) []*RateLimitBucket {
+	nodes, err := rlbq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of RateLimitBucket IDs.
+func (rlbq *RateLimitBucketQuery) IDs(ctx context.Context) (ids []int, err error) {
+	if rlbq.ctx.Unique == nil && rlbq.path != nil {
+		rlbq.Unique(true)
+	}
+	ctx = setContextOp(ctx, rlbq.ctx, ent.OpQueryIDs)
+	if err = rlbq.Select(ratelimitbucket.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (rlbq *RateLimitBucketQuery) IDsX(ctx context.Context) []int {
+	ids, err := rlbq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (rlbq *RateLimitBucketQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, rlbq.ctx, ent.OpQueryCount)
+	if err := rlbq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, rlbq, querierCount[*RateLimitBucketQuery](), rlbq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (rlbq *RateLimitBucketQuery) CountX(ctx context.Context) int {
+	count, err := rlbq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (rlbq *RateLimitBucketQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, rlbq.ctx, ent.OpQueryExist)
+	switch _, err := rlbq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (rlbq *RateLimitBucketQuery) ExistX(ctx context.Context) bool {
+	exist, err := rlbq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the RateLimitBucketQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (rlbq *RateLimitBucketQuery) Clone() *RateLimitBucketQuery {
+	if rlbq == nil {
+		return nil
+	}
+	return &RateLimitBucketQuery{
+		config:     rlbq.config,
+		ctx:        rlbq.ctx.Clone(),
+		order:      append([]ratelimitbucket.OrderOption{}, rlbq.order...),
+		inters:     append([]Interceptor{}, rlbq.inters...),
+		predicates: append([]predicate.RateLimitBucket{}, rlbq.predicates...),
+		// clone intermediate query.
+		sql:  rlbq.sql.Clone(),
+		path: rlbq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		Key string `json:"key,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.RateLimitBucket.Query().
+//		GroupBy(ratelimitbucket.FieldKey).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (rlbq *RateLimitBucketQuery) GroupBy(field string, fields ...string) *RateLimitBucketGroupBy {
+	rlbq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &RateLimitBucketGroupBy{build: rlbq}
+	grbuild.flds = &rlbq.ctx.Fields
+	grbuild.label = ratelimitbucket.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		Key string `json:"key,omitempty"`
+//	}
+//
+//	client.RateLimitBucket.Query().
+//		Select(ratelimitbucket.FieldKey).
+//		Scan(ctx, &v)
+func (rlbq *RateLimitBucketQuery) Select(fields ...string) *RateLimitBucketSelect {
+	rlbq.ctx.Fields = append(rlbq.ctx.Fields, fields...)
+	sbuild := &RateLimitBucketSelect{RateLimitBucketQuery: rlbq}
+	sbuild.label = ratelimitbucket.Label
+	sbuild.flds, sbuild.scan = &rlbq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a RateLimitBucketSelect configured with the given aggregations.
+func (rlbq *RateLimitBucketQuery) Aggregate(fns ...AggregateFunc) *RateLimitBucketSelect {
+	return rlbq.Select().Aggregate(fns...)
+}
+
+func (rlbq *RateLimitBucketQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range rlbq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, rlbq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range rlbq.ctx.Fields {
+		if !ratelimitbucket.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if rlbq.path != nil {
+		prev, err := rlbq.path(ctx)
+		if err != nil {
+			return err
+		}
+		rlbq.sql = prev
+	}
+	return nil
+}
+
+func (rlbq *RateLimitBucketQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*RateLimitBucket, error) {
+	var (
+		nodes = []*RateLimitBucket{}
+		_spec = rlbq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*RateLimitBucket).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &RateLimitBucket{config: rlbq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(rlbq.modifiers) > 0 {
+		_spec.Modifiers = rlbq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, rlbq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (rlbq *RateLimitBucketQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := rlbq.querySpec()
+	if len(rlbq.modifiers) > 0 {
+		_spec.Modifiers = rlbq.modifiers
+	}
+	_spec.Node.Columns = rlbq.ctx.Fields
+	if len(rlbq.ctx.Fields) > 0 {
+		_spec.Unique = rlbq.ctx.Unique != nil && *rlbq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, rlbq.driver, _spec)
+}
+
+func (rlbq *RateLimitBucketQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(ratelimitbucket.Table, ratelimitbucket.Columns, sqlgraph.NewFieldSpec(ratelimitbucket.FieldID, field.TypeInt))
+	_spec.From = rlbq.sql
+	if unique := rlbq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if rlbq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := rlbq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, ratelimitbucket.FieldID)
+		for i := range fields {
+			if fields[i] != ratelimitbucket.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := rlbq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := rlbq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := rlbq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := rlbq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (rlbq *RateLimitBucketQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(rlbq.driver.Dialect())
+	t1 := builder.Table(ratelimitbucket.Table)
+	columns := rlbq.ctx.Fields
+	if len(columns) == 0 {
+		columns = ratelimitbucket.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if rlbq.sql != nil {
+		selector = rlbq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if rlbq.ctx.Unique != nil && *rlbq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, m := range rlbq.modifiers {
+		m(selector)
+	}
+	for _, p := range rlbq.predicates {
+		p(selector)
+	}
+	for _, p := range rlbq.order {
+		p(selector)
+	}
+	if offset := rlbq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := rlbq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ForUpdate locks the selected rows against concurrent updates, and prevent them from being
+// updated, deleted or "selected ... for update" by other sessions, until the transaction is
+// either committed or rolled-back.
+func (rlbq *RateLimitBucketQuery) ForUpdate(opts ...sql.LockOption) *RateLimitBucketQuery {
+	if rlbq.driver.Dialect() == dialect.Postgres {
+		rlbq.Unique(false)
+	}
+	rlbq.modifiers = append(rlbq.modifiers, func(s *sql.Selector) {
+		s.ForUpdate(opts...)
+	})
+	return rlbq
+}
+
+// ForShare behaves similarly to ForUpdate, except that it acquires a shared mode lock
+// on any rows that are read. Other sessions can read the rows, but cannot modify them
+// until your transaction commits.
+func (rlbq *RateLimitBucketQuery) ForShare(opts ...sql.LockOption) *RateLimitBucketQuery {
+	if rlbq.driver.Dialect() == dialect.Postgres {
+		rlbq.Unique(false)
+	}
+	rlbq.modifiers = append(rlbq.modifiers, func(s *sql.Selector) {
+		s.ForShare(opts...)
+	})
+	return rlbq
+}
+
+// RateLimitBucketGroupBy is the group-by builder for RateLimitBucket entities.
+type RateLimitBucketGroupBy struct {
+	selector
+	build *RateLimitBucketQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (rlbgb *RateLimitBucketGroupBy) Aggregate(fns ...AggregateFunc) *RateLimitBucketGroupBy {
+	rlbgb.fns = append(rlbgb.fns, fns...)
+	return rlbgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (rlbgb *RateLimitBucketGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, rlbgb.build.ctx, ent.OpQueryGroupBy)
+	if err := rlbgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*RateLimitBucketQuery, *RateLimitBucketGroupBy](ctx, rlbgb.build, rlbgb, rlbgb.build.inters, v)
+}
+
+func (rlbgb *RateLimitBucketGroupBy) sqlScan(ctx context.Context, root *RateLimitBucketQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(rlbgb.fns))
+	for _, fn := range rlbgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*rlbgb.flds)+len(rlbgb.fns))
+		for _, f := range *rlbgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*rlbgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := rlbgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// RateLimitBucketSelect is the builder for selecting fields of RateLimitBucket entities.
+type RateLimitBucketSelect struct {
+	*RateLimitBucketQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (rlbs *RateLimitBucketSelect) Aggregate(fns ...AggregateFunc) *RateLimitBucketSelect {
+	rlbs.fns = append(rlbs.fns, fns...)
+	return rlbs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (rlbs *RateLimitBucketSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, rlbs.ctx, ent.OpQuerySelect)
+	if err := rlbs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*RateLimitBucketQuery, *RateLimitBucketSelect](ctx, rlbs.RateLimitBucketQuery, rlbs, rlbs.inters, v)
+}
+
+func (rlbs *RateLimitBucketSelect) sqlScan(ctx context.Context, root *RateLimitBucketQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(rlbs.fns))
+	for _, fn := range rlbs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*rlbs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := rlbs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -2,8 +2,405 @@
 
 package ent
 
+import (
+	"doghole/ent/certcache"
+	"doghole/ent/featureflag"
+	"doghole/ent/file"
+	"doghole/ent/idempotencykey"
+	"doghole/ent/job"
+	"doghole/ent/notification"
+	"doghole/ent/notificationpreference"
+	"doghole/ent/outbox"
+	"doghole/ent/ratelimitbucket"
+	"doghole/ent/scheduledrun"
+	"doghole/ent/schema"
+	"doghole/ent/webhookdelivery"
+	"doghole/ent/webhookendpoint"
+	"time"
+)
+
 // The init function reads all schema descriptors with runtime code
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	certcacheFields := schema.CertCache{}.Fields()
+	_ = certcacheFields
+	// certcacheDescKey is the schema descriptor for key field.
+	certcacheDescKey := certcacheFields[0].Descriptor()
+	// certcache.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	certcache.KeyValidator = func() func(string) error {
+		validators := certcacheDescKey.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(key string) error {
+			for _, fn := range fns {
+				if err := fn(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// certcacheDescUpdatedAt is the schema descriptor for updated_at field.
+	certcacheDescUpdatedAt := certcacheFields[2].Descriptor()
+	// certcache.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	certcache.DefaultUpdatedAt = certcacheDescUpdatedAt.Default.(func() time.Time)
+	// certcache.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	certcache.UpdateDefaultUpdatedAt = certcacheDescUpdatedAt.UpdateDefault.(func() time.Time)
+	featureflagFields := schema.FeatureFlag{}.Fields()
+	_ = featureflagFields
+	// featureflagDescKey is the schema descriptor for key field.
+	featureflagDescKey := featureflagFields[0].Descriptor()
+	// featureflag.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	featureflag.KeyValidator = func() func(string) error {
+		validators := featureflagDescKey.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(key string) error {
+			for _, fn := range fns {
+				if err := fn(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// featureflagDescEnabled is the schema descriptor for enabled field.
+	featureflagDescEnabled := featureflagFields[1].Descriptor()
+	// featureflag.DefaultEnabled holds the default value on creation for the enabled field.
+	featureflag.DefaultEnabled = featureflagDescEnabled.Default.(bool)
+	// featureflagDescPercentage is the schema descriptor for percentage field.
+	featureflagDescPercentage := featureflagFields[2].Descriptor()
+	// featureflag.PercentageValidator is a validator for the "percentage" field. It is called by the builders before save.
+	featureflag.PercentageValidator = featureflagDescPercentage.Validators[0].(func(int) error)
+	// featureflagDescDescription is the schema descriptor for description field.
+	featureflagDescDescription := featureflagFields[4].Descriptor()
+	// featureflag.DefaultDescription holds the default value on creation for the description field.
+	featureflag.DefaultDescription = featureflagDescDescription.Default.(string)
+	// featureflagDescCreatedAt is the schema descriptor for created_at field.
+	featureflagDescCreatedAt := featureflagFields[5].Descriptor()
+	// featureflag.DefaultCreatedAt holds the default value on creation for the created_at field.
+	featureflag.DefaultCreatedAt = featureflagDescCreatedAt.Default.(func() time.Time)
+	// featureflagDescUpdatedAt is the schema descriptor for updated_at field.
+	featureflagDescUpdatedAt := featureflagFields[6].Descriptor()
+	// featureflag.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	featureflag.DefaultUpdatedAt = featureflagDescUpdatedAt.Default.(func() time.Time)
+	// featureflag.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	featureflag.UpdateDefaultUpdatedAt = featureflagDescUpdatedAt.UpdateDefault.(func() time.Time)
+	fileFields := schema.File{}.Fields()
+	_ = fileFields
+	// fileDescKey is the schema descriptor for key field.
+	fileDescKey := fileFields[0].Descriptor()
+	// file.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	file.KeyValidator = fileDescKey.Validators[0].(func(string) error)
+	// fileDescBackend is the schema descriptor for backend field.
+	fileDescBackend := fileFields[1].Descriptor()
+	// file.BackendValidator is a validator for the "backend" field. It is called by the builders before save.
+	file.BackendValidator = fileDescBackend.Validators[0].(func(string) error)
+	// fileDescName is the schema descriptor for name field.
+	fileDescName := fileFields[2].Descriptor()
+	// file.DefaultName holds the default value on creation for the name field.
+	file.DefaultName = fileDescName.Default.(string)
+	// fileDescContentType is the schema descriptor for content_type field.
+	fileDescContentType := fileFields[3].Descriptor()
+	// file.ContentTypeValidator is a validator for the "content_type" field. It is called by the builders before save.
+	file.ContentTypeValidator = fileDescContentType.Validators[0].(func(string) error)
+	// fileDescSize is the schema descriptor for size field.
+	fileDescSize := fileFields[4].Descriptor()
+	// file.SizeValidator is a validator for the "size" field. It is called by the builders before save.
+	file.SizeValidator = fileDescSize.Validators[0].(func(int64) error)
+	// fileDescChecksum is the schema descriptor for checksum field.
+	fileDescChecksum := fileFields[5].Descriptor()
+	// file.ChecksumValidator is a validator for the "checksum" field. It is called by the builders before save.
+	file.ChecksumValidator = fileDescChecksum.Validators[0].(func(string) error)
+	// fileDescOwnerID is the schema descriptor for owner_id field.
+	fileDescOwnerID := fileFields[6].Descriptor()
+	// file.DefaultOwnerID holds the default value on creation for the owner_id field.
+	file.DefaultOwnerID = fileDescOwnerID.Default.(string)
+	// fileDescCreatedAt is the schema descriptor for created_at field.
+	fileDescCreatedAt := fileFields[7].Descriptor()
+	// file.DefaultCreatedAt holds the default value on creation for the created_at field.
+	file.DefaultCreatedAt = fileDescCreatedAt.Default.(func() time.Time)
+	idempotencykeyFields := schema.IdempotencyKey{}.Fields()
+	_ = idempotencykeyFields
+	// idempotencykeyDescScope is the schema descriptor for scope field.
+	idempotencykeyDescScope := idempotencykeyFields[0].Descriptor()
+	// idempotencykey.DefaultScope holds the default value on creation for the scope field.
+	idempotencykey.DefaultScope = idempotencykeyDescScope.Default.(string)
+	// idempotencykey.ScopeValidator is a validator for the "scope" field. It is called by the builders before save.
+	idempotencykey.ScopeValidator = idempotencykeyDescScope.Validators[0].(func(string) error)
+	// idempotencykeyDescKey is the schema descriptor for key field.
+	idempotencykeyDescKey := idempotencykeyFields[1].Descriptor()
+	// idempotencykey.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	idempotencykey.KeyValidator = func() func(string) error {
+		validators := idempotencykeyDescKey.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(key string) error {
+			for _, fn := range fns {
+				if err := fn(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// idempotencykeyDescCreatedAt is the schema descriptor for created_at field.
+	idempotencykeyDescCreatedAt := idempotencykeyFields[10].Descriptor()
+	// idempotencykey.DefaultCreatedAt holds the default value on creation for the created_at field.
+	idempotencykey.DefaultCreatedAt = idempotencykeyDescCreatedAt.Default.(func() time.Time)
+	jobFields := schema.Job{}.Fields()
+	_ = jobFields
+	// jobDescQueue is the schema descriptor for queue field.
+	jobDescQueue := jobFields[0].Descriptor()
+	// job.DefaultQueue holds the default value on creation for the queue field.
+	job.DefaultQueue = jobDescQueue.Default.(string)
+	// jobDescType is the schema descriptor for type field.
+	jobDescType := jobFields[1].Descriptor()
+	// job.TypeValidator is a validator for the "type" field. It is called by the builders before save.
+	job.TypeValidator = jobDescType.Validators[0].(func(string) error)
+	// jobDescPayload is the schema descriptor for payload field.
+	jobDescPayload := jobFields[2].Descriptor()
+	// job.DefaultPayload holds the default value on creation for the payload field.
+	job.DefaultPayload = jobDescPayload.Default.(string)
+	// jobDescAttempts is the schema descriptor for attempts field.
+	jobDescAttempts := jobFields[4].Descriptor()
+	// job.DefaultAttempts holds the default value on creation for the attempts field.
+	job.DefaultAttempts = jobDescAttempts.Default.(int)
+	// jobDescMaxAttempts is the schema descriptor for max_attempts field.
+	jobDescMaxAttempts := jobFields[5].Descriptor()
+	// job.DefaultMaxAttempts holds the default value on creation for the max_attempts field.
+	job.DefaultMaxAttempts = jobDescMaxAttempts.Default.(int)
+	// jobDescRunAt is the schema descriptor for run_at field.
+	jobDescRunAt := jobFields[6].Descriptor()
+	// job.DefaultRunAt holds the default value on creation for the run_at field.
+	job.DefaultRunAt = jobDescRunAt.Default.(func() time.Time)
+	// jobDescLockedBy is the schema descriptor for locked_by field.
+	jobDescLockedBy := jobFields[8].Descriptor()
+	// job.DefaultLockedBy holds the default value on creation for the locked_by field.
+	job.DefaultLockedBy = jobDescLockedBy.Default.(string)
+	// jobDescLastError is the schema descriptor for last_error field.
+	jobDescLastError := jobFields[9].Descriptor()
+	// job.DefaultLastError holds the default value on creation for the last_error field.
+	job.DefaultLastError = jobDescLastError.Default.(string)
+	// jobDescCreatedAt is the schema descriptor for created_at field.
+	jobDescCreatedAt := jobFields[11].Descriptor()
+	// job.DefaultCreatedAt holds the default value on creation for the created_at field.
+	job.DefaultCreatedAt = jobDescCreatedAt.Default.(func() time.Time)
+	// jobDescUpdatedAt is the schema descriptor for updated_at field.
+	jobDescUpdatedAt := jobFields[12].Descriptor()
+	// job.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	job.DefaultUpdatedAt = jobDescUpdatedAt.Default.(func() time.Time)
+	// job.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	job.UpdateDefaultUpdatedAt = jobDescUpdatedAt.UpdateDefault.(func() time.Time)
+	notificationFields := schema.Notification{}.Fields()
+	_ = notificationFields
+	// notificationDescUserID is the schema descriptor for user_id field.
+	notificationDescUserID := notificationFields[0].Descriptor()
+	// notification.UserIDValidator is a validator for the "user_id" field. It is called by the builders before save.
+	notification.UserIDValidator = notificationDescUserID.Validators[0].(func(string) error)
+	// notificationDescTopic is the schema descriptor for topic field.
+	notificationDescTopic := notificationFields[1].Descriptor()
+	// notification.TopicValidator is a validator for the "topic" field. It is called by the builders before save.
+	notification.TopicValidator = notificationDescTopic.Validators[0].(func(string) error)
+	// notificationDescCreatedAt is the schema descriptor for created_at field.
+	notificationDescCreatedAt := notificationFields[7].Descriptor()
+	// notification.DefaultCreatedAt holds the default value on creation for the created_at field.
+	notification.DefaultCreatedAt = notificationDescCreatedAt.Default.(func() time.Time)
+	notificationpreferenceFields := schema.NotificationPreference{}.Fields()
+	_ = notificationpreferenceFields
+	// notificationpreferenceDescUserID is the schema descriptor for user_id field.
+	notificationpreferenceDescUserID := notificationpreferenceFields[0].Descriptor()
+	// notificationpreference.UserIDValidator is a validator for the "user_id" field. It is called by the builders before save.
+	notificationpreference.UserIDValidator = notificationpreferenceDescUserID.Validators[0].(func(string) error)
+	// notificationpreferenceDescTopic is the schema descriptor for topic field.
+	notificationpreferenceDescTopic := notificationpreferenceFields[2].Descriptor()
+	// notificationpreference.DefaultTopic holds the default value on creation for the topic field.
+	notificationpreference.DefaultTopic = notificationpreferenceDescTopic.Default.(string)
+	// notificationpreferenceDescUpdatedAt is the schema descriptor for updated_at field.
+	notificationpreferenceDescUpdatedAt := notificationpreferenceFields[4].Descriptor()
+	// notificationpreference.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	notificationpreference.DefaultUpdatedAt = notificationpreferenceDescUpdatedAt.Default.(func() time.Time)
+	// notificationpreference.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	notificationpreference.UpdateDefaultUpdatedAt = notificationpreferenceDescUpdatedAt.UpdateDefault.(func() time.Time)
+	outboxFields := schema.Outbox{}.Fields()
+	_ = outboxFields
+	// outboxDescAggregateType is the schema descriptor for aggregate_type field.
+	outboxDescAggregateType := outboxFields[0].Descriptor()
+	// outbox.AggregateTypeValidator is a validator for the "aggregate_type" field. It is called by the builders before save.
+	outbox.AggregateTypeValidator = outboxDescAggregateType.Validators[0].(func(string) error)
+	// outboxDescAggregateID is the schema descriptor for aggregate_id field.
+	outboxDescAggregateID := outboxFields[1].Descriptor()
+	// outbox.AggregateIDValidator is a validator for the "aggregate_id" field. It is called by the builders before save.
+	outbox.AggregateIDValidator = outboxDescAggregateID.Validators[0].(func(string) error)
+	// outboxDescEventType is the schema descriptor for event_type field.
+	outboxDescEventType := outboxFields[2].Descriptor()
+	// outbox.EventTypeValidator is a validator for the "event_type" field. It is called by the builders before save.
+	outbox.EventTypeValidator = outboxDescEventType.Validators[0].(func(string) error)
+	// outboxDescPayload is the schema descriptor for payload field.
+	outboxDescPayload := outboxFields[3].Descriptor()
+	// outbox.DefaultPayload holds the default value on creation for the payload field.
+	outbox.DefaultPayload = outboxDescPayload.Default.(string)
+	// outboxDescAttempts is the schema descriptor for attempts field.
+	outboxDescAttempts := outboxFields[5].Descriptor()
+	// outbox.DefaultAttempts holds the default value on creation for the attempts field.
+	outbox.DefaultAttempts = outboxDescAttempts.Default.(int)
+	// outboxDescNextAttemptAt is the schema descriptor for next_attempt_at field.
+	outboxDescNextAttemptAt := outboxFields[6].Descriptor()
+	// outbox.DefaultNextAttemptAt holds the default value on creation for the next_attempt_at field.
+	outbox.DefaultNextAttemptAt = outboxDescNextAttemptAt.Default.(func() time.Time)
+	// outboxDescLastError is the schema descriptor for last_error field.
+	outboxDescLastError := outboxFields[7].Descriptor()
+	// outbox.DefaultLastError holds the default value on creation for the last_error field.
+	outbox.DefaultLastError = outboxDescLastError.Default.(string)
+	// outboxDescCreatedAt is the schema descriptor for created_at field.
+	outboxDescCreatedAt := outboxFields[9].Descriptor()
+	// outbox.DefaultCreatedAt holds the default value on creation for the created_at field.
+	outbox.DefaultCreatedAt = outboxDescCreatedAt.Default.(func() time.Time)
+	ratelimitbucketFields := schema.RateLimitBucket{}.Fields()
+	_ = ratelimitbucketFields
+	// ratelimitbucketDescKey is the schema descriptor for key field.
+	ratelimitbucketDescKey := ratelimitbucketFields[0].Descriptor()
+	// ratelimitbucket.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	ratelimitbucket.KeyValidator = func() func(string) error {
+		validators := ratelimitbucketDescKey.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(key string) error {
+			for _, fn := range fns {
+				if err := fn(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// ratelimitbucketDescTokens is the schema descriptor for tokens field.
+	ratelimitbucketDescTokens := ratelimitbucketFields[1].Descriptor()
+	// ratelimitbucket.DefaultTokens holds the default value on creation for the tokens field.
+	ratelimitbucket.DefaultTokens = ratelimitbucketDescTokens.Default.(float64)
+	// ratelimitbucketDescCount is the schema descriptor for count field.
+	ratelimitbucketDescCount := ratelimitbucketFields[2].Descriptor()
+	// ratelimitbucket.DefaultCount holds the default value on creation for the count field.
+	ratelimitbucket.DefaultCount = ratelimitbucketDescCount.Default.(int64)
+	// ratelimitbucketDescPrevCount is the schema descriptor for prev_count field.
+	ratelimitbucketDescPrevCount := ratelimitbucketFields[3].Descriptor()
+	// ratelimitbucket.DefaultPrevCount holds the default value on creation for the prev_count field.
+	ratelimitbucket.DefaultPrevCount = ratelimitbucketDescPrevCount.Default.(int64)
+	// ratelimitbucketDescWindowStart is the schema descriptor for window_start field.
+	ratelimitbucketDescWindowStart := ratelimitbucketFields[4].Descriptor()
+	// ratelimitbucket.DefaultWindowStart holds the default value on creation for the window_start field.
+	ratelimitbucket.DefaultWindowStart = ratelimitbucketDescWindowStart.Default.(func() time.Time)
+	// ratelimitbucketDescUpdatedAt is the schema descriptor for updated_at field.
+	ratelimitbucketDescUpdatedAt := ratelimitbucketFields[5].Descriptor()
+	// ratelimitbucket.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	ratelimitbucket.DefaultUpdatedAt = ratelimitbucketDescUpdatedAt.Default.(func() time.Time)
+	scheduledrunFields := schema.ScheduledRun{}.Fields()
+	_ = scheduledrunFields
+	// scheduledrunDescTask is the schema descriptor for task field.
+	scheduledrunDescTask := scheduledrunFields[0].Descriptor()
+	// scheduledrun.TaskValidator is a validator for the "task" field. It is called by the builders before save.
+	scheduledrun.TaskValidator = func() func(string) error {
+		validators := scheduledrunDescTask.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(task string) error {
+			for _, fn := range fns {
+				if err := fn(task); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// scheduledrunDescStartedAt is the schema descriptor for started_at field.
+	scheduledrunDescStartedAt := scheduledrunFields[4].Descriptor()
+	// scheduledrun.DefaultStartedAt holds the default value on creation for the started_at field.
+	scheduledrun.DefaultStartedAt = scheduledrunDescStartedAt.Default.(func() time.Time)
+	// scheduledrunDescError is the schema descriptor for error field.
+	scheduledrunDescError := scheduledrunFields[6].Descriptor()
+	// scheduledrun.DefaultError holds the default value on creation for the error field.
+	scheduledrun.DefaultError = scheduledrunDescError.Default.(string)
+	webhookdeliveryFields := schema.WebhookDelivery{}.Fields()
+	_ = webhookdeliveryFields
+	// webhookdeliveryDescAttempts is the schema descriptor for attempts field.
+	webhookdeliveryDescAttempts := webhookdeliveryFields[5].Descriptor()
+	// webhookdelivery.DefaultAttempts holds the default value on creation for the attempts field.
+	webhookdelivery.DefaultAttempts = webhookdeliveryDescAttempts.Default.(int)
+	// webhookdeliveryDescNextAttemptAt is the schema descriptor for next_attempt_at field.
+	webhookdeliveryDescNextAttemptAt := webhookdeliveryFields[6].Descriptor()
+	// webhookdelivery.DefaultNextAttemptAt holds the default value on creation for the next_attempt_at field.
+	webhookdelivery.DefaultNextAttemptAt = webhookdeliveryDescNextAttemptAt.Default.(func() time.Time)
+	// webhookdeliveryDescResponseBody is the schema descriptor for response_body field.
+	webhookdeliveryDescResponseBody := webhookdeliveryFields[9].Descriptor()
+	// webhookdelivery.DefaultResponseBody holds the default value on creation for the response_body field.
+	webhookdelivery.DefaultResponseBody = webhookdeliveryDescResponseBody.Default.(string)
+	// webhookdeliveryDescError is the schema descriptor for error field.
+	webhookdeliveryDescError := webhookdeliveryFields[10].Descriptor()
+	// webhookdelivery.DefaultError holds the default value on creation for the error field.
+	webhookdelivery.DefaultError = webhookdeliveryDescError.Default.(string)
+	// webhookdeliveryDescCreatedAt is the schema descriptor for created_at field.
+	webhookdeliveryDescCreatedAt := webhookdeliveryFields[12].Descriptor()
+	// webhookdelivery.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webhookdelivery.DefaultCreatedAt = webhookdeliveryDescCreatedAt.Default.(func() time.Time)
+	webhookendpointFields := schema.WebhookEndpoint{}.Fields()
+	_ = webhookendpointFields
+	// webhookendpointDescURL is the schema descriptor for url field.
+	webhookendpointDescURL := webhookendpointFields[0].Descriptor()
+	// webhookendpoint.URLValidator is a validator for the "url" field. It is called by the builders before save.
+	webhookendpoint.URLValidator = func() func(string) error {
+		validators := webhookendpointDescURL.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(url string) error {
+			for _, fn := range fns {
+				if err := fn(url); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// webhookendpointDescSecret is the schema descriptor for secret field.
+	webhookendpointDescSecret := webhookendpointFields[1].Descriptor()
+	// webhookendpoint.SecretValidator is a validator for the "secret" field. It is called by the builders before save.
+	webhookendpoint.SecretValidator = webhookendpointDescSecret.Validators[0].(func(string) error)
+	// webhookendpointDescDescription is the schema descriptor for description field.
+	webhookendpointDescDescription := webhookendpointFields[2].Descriptor()
+	// webhookendpoint.DefaultDescription holds the default value on creation for the description field.
+	webhookendpoint.DefaultDescription = webhookendpointDescDescription.Default.(string)
+	// webhookendpointDescOwnerID is the schema descriptor for owner_id field.
+	webhookendpointDescOwnerID := webhookendpointFields[4].Descriptor()
+	// webhookendpoint.DefaultOwnerID holds the default value on creation for the owner_id field.
+	webhookendpoint.DefaultOwnerID = webhookendpointDescOwnerID.Default.(string)
+	// webhookendpointDescEnabled is the schema descriptor for enabled field.
+	webhookendpointDescEnabled := webhookendpointFields[5].Descriptor()
+	// webhookendpoint.DefaultEnabled holds the default value on creation for the enabled field.
+	webhookendpoint.DefaultEnabled = webhookendpointDescEnabled.Default.(bool)
+	// webhookendpointDescFailureCount is the schema descriptor for failure_count field.
+	webhookendpointDescFailureCount := webhookendpointFields[6].Descriptor()
+	// webhookendpoint.DefaultFailureCount holds the default value on creation for the failure_count field.
+	webhookendpoint.DefaultFailureCount = webhookendpointDescFailureCount.Default.(int)
+	// webhookendpointDescCreatedAt is the schema descriptor for created_at field.
+	webhookendpointDescCreatedAt := webhookendpointFields[8].Descriptor()
+	// webhookendpoint.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webhookendpoint.DefaultCreatedAt = webhookendpointDescCreatedAt.Default.(func() time.Time)
+	// webhookendpointDescUpdatedAt is the schema descriptor for updated_at field.
+	webhookendpointDescUpdatedAt := webhookendpointFields[9].Descriptor()
+	// webhookendpoint.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	webhookendpoint.DefaultUpdatedAt = webhookendpointDescUpdatedAt.Default.(func() time.Time)
+	// webhookendpoint.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	webhookendpoint.UpdateDefaultUpdatedAt = webhookendpointDescUpdatedAt.UpdateDefault.(func() time.Time)
 }
@@ -0,0 +1,175 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"doghole/ent/scheduledrun"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// ScheduledRun is the model entity for the ScheduledRun schema.
+type ScheduledRun struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// 任务名称
+	Task string `json:"task,omitempty"`
+	// 计划触发时间
+	ScheduledAt time.Time `json:"scheduled_at,omitempty"`
+	// 执行任务的实例
+	Instance string `json:"instance,omitempty"`
+	// Status holds the value of the "status" field.
+	Status scheduledrun.Status `json:"status,omitempty"`
+	// StartedAt holds the value of the "started_at" field.
+	StartedAt time.Time `json:"started_at,omitempty"`
+	// FinishedAt holds the value of the "finished_at" field.
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	// Error holds the value of the "error" field.
+	Error        string `json:"error,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ScheduledRun) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case scheduledrun.FieldID:
+			values[i] = new(sql.NullInt64)
+		case scheduledrun.FieldTask, scheduledrun.FieldInstance, scheduledrun.FieldStatus, scheduledrun.FieldError:
+			values[i] = new(sql.NullString)
+		case scheduledrun.FieldScheduledAt, scheduledrun.FieldStartedAt, scheduledrun.FieldFinishedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ScheduledRun fields.
+func (sr *ScheduledRun) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case scheduledrun.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			sr.ID = int(value.Int64)
+		case scheduledrun.FieldTask:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field task", values[i])
+			} else if value.Valid {
+				sr.Task = value.String
+			}
+		case scheduledrun.FieldScheduledAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field scheduled_at", values[i])
+			} else if value.Valid {
+				sr.ScheduledAt = value.Time
+			}
+		case scheduledrun.FieldInstance:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field instance", values[i])
+			} else if value.Valid {
+				sr.Instance = value.String
+			}
+		case scheduledrun.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				sr.Status = scheduledrun.Status(value.String)
+			}
+		case scheduledrun.FieldStartedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field started_at", values[i])
+			} else if value.Valid {
+				sr.StartedAt = value.Time
+			}
+		case scheduledrun.FieldFinishedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field finished_at", values[i])
+			} else if value.Valid {
+				sr.FinishedAt = new(time.Time)
+				*sr.FinishedAt = value.Time
+			}
+		case scheduledrun.FieldError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field error", values[i])
+			} else if value.Valid {
+				sr.Error = value.String
+			}
+		default:
+			sr.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ScheduledRun.
+// This includes values selected through modifiers, order, etc.
+func (sr *ScheduledRun) Value(name string) (ent.Value, error) {
+	return sr.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this ScheduledRun.
+// Note that you need to call ScheduledRun.Unwrap() before calling this method if this ScheduledRun
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (sr *ScheduledRun) Update() *ScheduledRunUpdateOne {
+	return NewScheduledRunClient(sr.config).UpdateOne(sr)
+}
+
+// Unwrap unwraps the ScheduledRun entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (sr *ScheduledRun) Unwrap() *ScheduledRun {
+	_tx, ok := sr.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ScheduledRun is not a transactional entity")
+	}
+	sr.config.driver = _tx.drv
+	return sr
+}
+
+// String implements the fmt.Stringer.
+func (sr *ScheduledRun) String() string {
+	var builder strings.Builder
+	builder.WriteString("ScheduledRun(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", sr.ID))
+	builder.WriteString("task=")
+	builder.WriteString(sr.Task)
+	builder.WriteString(", ")
+	builder.WriteString("scheduled_at=")
+	builder.WriteString(sr.ScheduledAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("instance=")
+	builder.WriteString(sr.Instance)
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", sr.Status))
+	builder.WriteString(", ")
+	builder.WriteString("started_at=")
+	builder.WriteString(sr.StartedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := sr.FinishedAt; v != nil {
+		builder.WriteString("finished_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("error=")
+	builder.WriteString(sr.Error)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ScheduledRuns is a parsable slice of ScheduledRun.
+type ScheduledRuns []*ScheduledRun
@@ -0,0 +1,316 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/scheduledrun"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ScheduledRunCreate is the builder for creating a ScheduledRun entity.
+type ScheduledRunCreate struct {
+	config
+	mutation *ScheduledRunMutation
+	hooks    []Hook
+}
+
+// SetTask sets the "task" field.
+func (src *ScheduledRunCreate) SetTask(s string) *ScheduledRunCreate {
+	src.mutation.SetTask(s)
+	return src
+}
+
+// SetScheduledAt sets the "scheduled_at" field.
+func (src *ScheduledRunCreate) SetScheduledAt(t time.Time) *ScheduledRunCreate {
+	src.mutation.SetScheduledAt(t)
+	return src
+}
+
+// SetInstance sets the "instance" field.
+func (src *ScheduledRunCreate) SetInstance(s string) *ScheduledRunCreate {
+	src.mutation.SetInstance(s)
+	return src
+}
+
+// SetStatus sets the "status" field.
+func (src *ScheduledRunCreate) SetStatus(s scheduledrun.Status) *ScheduledRunCreate {
+	src.mutation.SetStatus(s)
+	return src
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (src *ScheduledRunCreate) SetNillableStatus(s *scheduledrun.Status) *ScheduledRunCreate {
+	if s != nil {
+		src.SetStatus(*s)
+	}
+	return src
+}
+
+// SetStartedAt sets the "started_at" field.
+func (src *ScheduledRunCreate) SetStartedAt(t time.Time) *ScheduledRunCreate {
+	src.mutation.SetStartedAt(t)
+	return src
+}
+
+// SetNillableStartedAt sets the "started_at" field if the given value is not nil.
+func (src *ScheduledRunCreate) SetNillableStartedAt(t *time.Time) *ScheduledRunCreate {
+	if t != nil {
+		src.SetStartedAt(*t)
+	}
+	return src
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (src *ScheduledRunCreate) SetFinishedAt(t time.Time) *ScheduledRunCreate {
+	src.mutation.SetFinishedAt(t)
+	return src
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (src *ScheduledRunCreate) SetNillableFinishedAt(t *time.Time) *ScheduledRunCreate {
+	if t != nil {
+		src.SetFinishedAt(*t)
+	}
+	return src
+}
+
+// SetError sets the "error" field.
+func (src *ScheduledRunCreate) SetError(s string) *ScheduledRunCreate {
+	src.mutation.SetError(s)
+	return src
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (src *ScheduledRunCreate) SetNillableError(s *string) *ScheduledRunCreate {
+	if s != nil {
+		src.SetError(*s)
+	}
+	return src
+}
+
+// Mutation returns the ScheduledRunMutation object of the builder.
+func (src *ScheduledRunCreate) Mutation() *ScheduledRunMutation {
+	return src.mutation
+}
+
+// Save creates the ScheduledRun in the database.
+func (src *ScheduledRunCreate) Save(ctx context.Context) (*ScheduledRun, error) {
+	src.defaults()
+	return withHooks(ctx, src.sqlSave, src.mutation, src.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (src *ScheduledRunCreate) SaveX(ctx context.Context) *ScheduledRun {
+	v, err := src.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (src *ScheduledRunCreate) Exec(ctx context.Context) error {
+	_, err := src.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (src *ScheduledRunCreate) ExecX(ctx context.Context) {
+	if err := src.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (src *ScheduledRunCreate) defaults() {
+	if _, ok := src.mutation.Status(); !ok {
+		v := scheduledrun.DefaultStatus
+		src.mutation.SetStatus(v)
+	}
+	if _, ok := src.mutation.StartedAt(); !ok {
+		v := scheduledrun.DefaultStartedAt()
+		src.mutation.SetStartedAt(v)
+	}
+	if _, ok := src.mutation.Error(); !ok {
+		v := scheduledrun.DefaultError
+		src.mutation.SetError(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (src *ScheduledRunCreate) check() error {
+	if _, ok := src.mutation.Task(); !ok {
+		return &ValidationError{Name: "task", err: errors.New(`ent: missing required field "ScheduledRun.task"`)}
+	}
+	if v, ok := src.mutation.Task(); ok {
+		if err := scheduledrun.TaskValidator(v); err != nil {
+			return &ValidationError{Name: "task", err: fmt.Errorf(`ent: validator failed for field "ScheduledRun.task": %w`, err)}
+		}
+	}
+	if _, ok := src.mutation.ScheduledAt(); !ok {
+		return &ValidationError{Name: "scheduled_at", err: errors.New(`ent: missing required field "ScheduledRun.scheduled_at"`)}
+	}
+	if _, ok := src.mutation.Instance(); !ok {
+		return &ValidationError{Name: "instance", err: errors.New(`ent: missing required field "ScheduledRun.instance"`)}
+	}
+	if _, ok := src.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "ScheduledRun.status"`)}
+	}
+	if v, ok := src.mutation.Status(); ok {
+		if err := scheduledrun.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "ScheduledRun.status": %w`, err)}
+		}
+	}
+	if _, ok := src.mutation.StartedAt(); !ok {
+		return &ValidationError{Name: "started_at", err: errors.New(`ent: missing required field "ScheduledRun.started_at"`)}
+	}
+	return nil
+}
+
+func (src *ScheduledRunCreate) sqlSave(ctx context.Context) (*ScheduledRun, error) {
+	if err := src.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := src.createSpec()
+	if err := sqlgraph.CreateNode(ctx, src.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	src.mutation.id = &_node.ID
+	src.mutation.done = true
+	return _node, nil
+}
+
+func (src *ScheduledRunCreate) createSpec() (*ScheduledRun, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ScheduledRun{config: src.config}
+		_spec = sqlgraph.NewCreateSpec(scheduledrun.Table, sqlgraph.NewFieldSpec(scheduledrun.FieldID, field.TypeInt))
+	)
+	if value, ok := src.mutation.Task(); ok {
+		_spec.SetField(scheduledrun.FieldTask, field.TypeString, value)
+		_node.Task = value
+	}
+	if value, ok := src.mutation.ScheduledAt(); ok {
+		_spec.SetField(scheduledrun.FieldScheduledAt, field.TypeTime, value)
+		_node.ScheduledAt = value
+	}
+	if value, ok := src.mutation.Instance(); ok {
+		_spec.SetField(scheduledrun.FieldInstance, field.TypeString, value)
+		_node.Instance = value
+	}
+	if value, ok := src.mutation.Status(); ok {
+		_spec.SetField(scheduledrun.FieldStatus, field.TypeEnum, value)
+		_node.Status = value
+	}
+	if value, ok := src.mutation.StartedAt(); ok {
+		_spec.SetField(scheduledrun.FieldStartedAt, field.TypeTime, value)
+		_node.StartedAt = value
+	}
+	if value, ok := src.mutation.FinishedAt(); ok {
+		_spec.SetField(scheduledrun.FieldFinishedAt, field.TypeTime, value)
+		_node.FinishedAt = &value
+	}
+	if value, ok := src.mutation.Error(); ok {
+		_spec.SetField(scheduledrun.FieldError, field.TypeString, value)
+		_node.Error = value
+	}
+	return _node, _spec
+}
+
+// ScheduledRunCreateBulk is the builder for creating many ScheduledRun entities in bulk.
+type ScheduledRunCreateBulk struct {
+	config
+	err      error
+	builders []*ScheduledRunCreate
+}
+
+// Save creates the ScheduledRun entities in the database.
+func (srcb *ScheduledRunCreateBulk) Save(ctx context.Context) ([]*ScheduledRun, error) {
+	if srcb.err != nil {
+		return nil, srcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(srcb.builders))
+	nodes := make([]*ScheduledRun, len(srcb.builders))
+	mutators := make([]Mutator, len(srcb.builders))
+	for i := range srcb.builders {
+		func(i int, root context.Context) {
+			builder := srcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ScheduledRunMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, srcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, srcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, srcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (srcb *ScheduledRunCreateBulk) SaveX(ctx context.Context) []*ScheduledRun {
+	v, err := srcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (srcb *ScheduledRunCreateBulk) Exec(ctx context.Context) error {
+	_, err := srcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (srcb *ScheduledRunCreateBulk) ExecX(ctx context.Context) {
+	if err := srcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
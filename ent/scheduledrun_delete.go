@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/predicate"
+	"doghole/ent/scheduledrun"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ScheduledRunDelete is the builder for deleting a ScheduledRun entity.
+type ScheduledRunDelete struct {
+	config
+	hooks    []Hook
+	mutation *ScheduledRunMutation
+}
+
+// Where appends a list predicates to the ScheduledRunDelete builder.
+func (srd *ScheduledRunDelete) Where(ps ...predicate.ScheduledRun) *ScheduledRunDelete {
+	srd.mutation.Where(ps...)
+	return srd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (srd *ScheduledRunDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, srd.sqlExec, srd.mutation, srd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (srd *ScheduledRunDelete) ExecX(ctx context.Context) int {
+	n, err := srd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (srd *ScheduledRunDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(scheduledrun.Table, sqlgraph.NewFieldSpec(scheduledrun.FieldID, field.TypeInt))
+	if ps := srd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, srd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	srd.mutation.done = true
+	return affected, err
+}
+
+// ScheduledRunDeleteOne is the builder for deleting a single ScheduledRun entity.
+type ScheduledRunDeleteOne struct {
+	srd *ScheduledRunDelete
+}
+
+// Where appends a list predicates to the ScheduledRunDelete builder.
+func (srdo *ScheduledRunDeleteOne) Where(ps ...predicate.ScheduledRun) *ScheduledRunDeleteOne {
+	srdo.srd.mutation.Where(ps...)
+	return srdo
+}
+
+// Exec executes the deletion query.
+func (srdo *ScheduledRunDeleteOne) Exec(ctx context.Context) error {
+	n, err := srdo.srd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{scheduledrun.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (srdo *ScheduledRunDeleteOne) ExecX(ctx context.Context) {
+	if err := srdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
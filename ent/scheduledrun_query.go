@@ -0,0 +1,564 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/predicate"
+	"doghole/ent/scheduledrun"
+	"fmt"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ScheduledRunQuery is the builder for querying ScheduledRun entities.
+type ScheduledRunQuery struct {
+	config
+	ctx        *QueryContext
+	order      []scheduledrun.OrderOption
+	inters     []Interceptor
+	predicates []predicate.ScheduledRun
+	modifiers  []func(*sql.Selector)
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the ScheduledRunQuery builder.
+func (srq *ScheduledRunQuery) Where(ps ...predicate.ScheduledRun) *ScheduledRunQuery {
+	srq.predicates = append(srq.predicates, ps...)
+	return srq
+}
+
+// Limit the number of records to be returned by this query.
+func (srq *ScheduledRunQuery) Limit(limit int) *ScheduledRunQuery {
+	srq.ctx.Limit = &limit
+	return srq
+}
+
+// Offset to start from.
+func (srq *ScheduledRunQuery) Offset(offset int) *ScheduledRunQuery {
+	srq.ctx.Offset = &offset
+	return srq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (srq *ScheduledRunQuery) Unique(unique bool) *ScheduledRunQuery {
+	srq.ctx.Unique = &unique
+	return srq
+}
+
+// Order specifies how the records should be ordered.
+func (srq *ScheduledRunQuery) Order(o ...scheduledrun.OrderOption) *ScheduledRunQuery {
+	srq.order = append(srq.order, o...)
+	return srq
+}
+
+// First returns the first ScheduledRun entity from the query.
+// Returns a *NotFoundError when no ScheduledRun was found.
+func (srq *ScheduledRunQuery) First(ctx context.Context) (*ScheduledRun, error) {
+	nodes, err := srq.Limit(1).All(setContextOp(ctx, srq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{scheduledrun.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (srq *ScheduledRunQuery) FirstX(ctx context.Context) *ScheduledRun {
+	node, err := srq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first ScheduledRun ID from the query.
+// Returns a *NotFoundError when no ScheduledRun ID was found.
+func (srq *ScheduledRunQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = srq.Limit(1).IDs(setContextOp(ctx, srq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{scheduledrun.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (srq *ScheduledRunQuery) FirstIDX(ctx context.Context) int {
+	id, err := srq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single ScheduledRun entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one ScheduledRun entity is found.
+// Returns a *NotFoundError when no ScheduledRun entities are found.
+func (srq *ScheduledRunQuery) Only(ctx context.Context) (*ScheduledRun, error) {
+	nodes, err := srq.Limit(2).All(setContextOp(ctx, srq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{scheduledrun.Label}
+	default:
+		return nil, &NotSingularError{scheduledrun.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (srq *ScheduledRunQuery) OnlyX(ctx context.Context) *ScheduledRun {
+	node, err := srq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only ScheduledRun ID in the query.
+// Returns a *NotSingularError when more than one ScheduledRun ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (srq *ScheduledRunQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = srq.Limit(2).IDs(setContextOp(ctx, srq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{scheduledrun.Label}
+	default:
+		err = &NotSingularError{scheduledrun.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (srq *ScheduledRunQuery) OnlyIDX(ctx context.Context) int {
+	id, err := srq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of ScheduledRuns.
+func (srq *ScheduledRunQuery) All(ctx context.Context) ([]*ScheduledRun, error) {
+	ctx = setContextOp(ctx, srq.ctx, ent.OpQueryAll)
+	if err := srq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*ScheduledRun, *ScheduledRunQuery]()
+	return withInterceptors[[]*ScheduledRun](ctx, srq, qr, srq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (srq *ScheduledRunQuery) AllX(ctx context.Context) []*ScheduledRun {
+	nodes, err := srq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of ScheduledRun IDs.
+func (srq *ScheduledRunQuery) IDs(ctx context.Context) (ids []int, err error) {
+	if srq.ctx.Unique == nil && srq.path != nil {
+		srq.Unique(true)
+	}
+	ctx = setContextOp(ctx, srq.ctx, ent.OpQueryIDs)
+	if err = srq.Select(scheduledrun.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (srq *ScheduledRunQuery) IDsX(ctx context.Context) []int {
+	ids, err := srq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (srq *ScheduledRunQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, srq.ctx, ent.OpQueryCount)
+	if err := srq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, srq, querierCount[*ScheduledRunQuery](), srq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (srq *ScheduledRunQuery) CountX(ctx context.Context) int {
+	count, err := srq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (srq *ScheduledRunQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, srq.ctx, ent.OpQueryExist)
+	switch _, err := srq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (srq *ScheduledRunQuery) ExistX(ctx context.Context) bool {
+	exist, err := srq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the ScheduledRunQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (srq *ScheduledRunQuery) Clone() *ScheduledRunQuery {
+	if srq == nil {
+		return nil
+	}
+	return &ScheduledRunQuery{
+		config:     srq.config,
+		ctx:        srq.ctx.Clone(),
+		order:      append([]scheduledrun.OrderOption{}, srq.order...),
+		inters:     append([]Interceptor{}, srq.inters...),
+		predicates: append([]predicate.ScheduledRun{}, srq.predicates...),
+		// clone intermediate query.
+		sql:  srq.sql.Clone(),
+		path: srq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		Task string `json:"task,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.ScheduledRun.Query().
+//		GroupBy(scheduledrun.FieldTask).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (srq *ScheduledRunQuery) GroupBy(field string, fields ...string) *ScheduledRunGroupBy {
+	srq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &ScheduledRunGroupBy{build: srq}
+	grbuild.flds = &srq.ctx.Fields
+	grbuild.label = scheduledrun.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		Task string `json:"task,omitempty"`
+//	}
+//
+//	client.ScheduledRun.Query().
+//		Select(scheduledrun.FieldTask).
+//		Scan(ctx, &v)
+func (srq *ScheduledRunQuery) Select(fields ...string) *ScheduledRunSelect {
+	srq.ctx.Fields = append(srq.ctx.Fields, fields...)
+	sbuild := &ScheduledRunSelect{ScheduledRunQuery: srq}
+	sbuild.label = scheduledrun.Label
+	sbuild.flds, sbuild.scan = &srq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a ScheduledRunSelect configured with the given aggregations.
+func (srq *ScheduledRunQuery) Aggregate(fns ...AggregateFunc) *ScheduledRunSelect {
+	return srq.Select().Aggregate(fns...)
+}
+
+func (srq *ScheduledRunQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range srq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, srq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range srq.ctx.Fields {
+		if !scheduledrun.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if srq.path != nil {
+		prev, err := srq.path(ctx)
+		if err != nil {
+			return err
+		}
+		srq.sql = prev
+	}
+	return nil
+}
+
+func (srq *ScheduledRunQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*ScheduledRun, error) {
+	var (
+		nodes = []*ScheduledRun{}
+		_spec = srq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*ScheduledRun).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &ScheduledRun{config: srq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(srq.modifiers) > 0 {
+		_spec.Modifiers = srq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, srq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (srq *ScheduledRunQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := srq.querySpec()
+	if len(srq.modifiers) > 0 {
+		_spec.Modifiers = srq.modifiers
+	}
+	_spec.Node.Columns = srq.ctx.Fields
+	if len(srq.ctx.Fields) > 0 {
+		_spec.Unique = srq.ctx.Unique != nil && *srq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, srq.driver, _spec)
+}
+
+func (srq *ScheduledRunQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(scheduledrun.Table, scheduledrun.Columns, sqlgraph.NewFieldSpec(scheduledrun.FieldID, field.TypeInt))
+	_spec.From = srq.sql
+	if unique := srq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if srq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := srq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, scheduledrun.FieldID)
+		for i := range fields {
+			if fields[i] != scheduledrun.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := srq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := srq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := srq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := srq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (srq *ScheduledRunQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(srq.driver.Dialect())
+	t1 := builder.Table(scheduledrun.Table)
+	columns := srq.ctx.Fields
+	if len(columns) == 0 {
+		columns = scheduledrun.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if srq.sql != nil {
+		selector = srq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if srq.ctx.Unique != nil && *srq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, m := range srq.modifiers {
+		m(selector)
+	}
+	for _, p := range srq.predicates {
+		p(selector)
+	}
+	for _, p := range srq.order {
+		p(selector)
+	}
+	if offset := srq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := srq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ForUpdate locks the selected rows against concurrent updates, and prevent them from being
+// updated, deleted or "selected ... for update" by other sessions, until the transaction is
+// either committed or rolled-back.
+func (srq *ScheduledRunQuery) ForUpdate(opts ...sql.LockOption) *ScheduledRunQuery {
+	if srq.driver.Dialect() == dialect.Postgres {
+		srq.Unique(false)
+	}
+	srq.modifiers = append(srq.modifiers, func(s *sql.Selector) {
+		s.ForUpdate(opts...)
+	})
+	return srq
+}
+
+// ForShare behaves similarly to ForUpdate, except that it acquires a shared mode lock
+// on any rows that are read. Other sessions can read the rows, but cannot modify them
+// until your transaction commits.
+func (srq *ScheduledRunQuery) ForShare(opts ...sql.LockOption) *ScheduledRunQuery {
+	if srq.driver.Dialect() == dialect.Postgres {
+		srq.Unique(false)
+	}
+	srq.modifiers = append(srq.modifiers, func(s *sql.Selector) {
+		s.ForShare(opts...)
+	})
+	return srq
+}
+
+// ScheduledRunGroupBy is the group-by builder for ScheduledRun entities.
+type ScheduledRunGroupBy struct {
+	selector
+	build *ScheduledRunQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (srgb *ScheduledRunGroupBy) Aggregate(fns ...AggregateFunc) *ScheduledRunGroupBy {
+	srgb.fns = append(srgb.fns, fns...)
+	return srgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (srgb *ScheduledRunGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, srgb.build.ctx, ent.OpQueryGroupBy)
+	if err := srgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ScheduledRunQuery, *ScheduledRunGroupBy](ctx, srgb.build, srgb, srgb.build.inters, v)
+}
+
+func (srgb *ScheduledRunGroupBy) sqlScan(ctx context.Context, root *ScheduledRunQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(srgb.fns))
+	for _, fn := range srgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*srgb.flds)+len(srgb.fns))
+		for _, f := range *srgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*srgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := srgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// ScheduledRunSelect is the builder for selecting fields of ScheduledRun entities.
+type ScheduledRunSelect struct {
+	*ScheduledRunQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (srs *ScheduledRunSelect) Aggregate(fns ...AggregateFunc) *ScheduledRunSelect {
+	srs.fns = append(srs.fns, fns...)
+	return srs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (srs *ScheduledRunSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, srs.ctx, ent.OpQuerySelect)
+	if err := srs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ScheduledRunQuery, *ScheduledRunSelect](ctx, srs.ScheduledRunQuery, srs, srs.inters, v)
+}
+
+func (srs *ScheduledRunSelect) sqlScan(ctx context.Context, root *ScheduledRunQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(srs.fns))
+	for _, fn := range srs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*srs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := srs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,340 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/predicate"
+	"doghole/ent/scheduledrun"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ScheduledRunUpdate is the builder for updating ScheduledRun entities.
+type ScheduledRunUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ScheduledRunMutation
+}
+
+// Where appends a list predicates to the ScheduledRunUpdate builder.
+func (sru *ScheduledRunUpdate) Where(ps ...predicate.ScheduledRun) *ScheduledRunUpdate {
+	sru.mutation.Where(ps...)
+	return sru
+}
+
+// SetStatus sets the "status" field.
+func (sru *ScheduledRunUpdate) SetStatus(s scheduledrun.Status) *ScheduledRunUpdate {
+	sru.mutation.SetStatus(s)
+	return sru
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (sru *ScheduledRunUpdate) SetNillableStatus(s *scheduledrun.Status) *ScheduledRunUpdate {
+	if s != nil {
+		sru.SetStatus(*s)
+	}
+	return sru
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (sru *ScheduledRunUpdate) SetFinishedAt(t time.Time) *ScheduledRunUpdate {
+	sru.mutation.SetFinishedAt(t)
+	return sru
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (sru *ScheduledRunUpdate) SetNillableFinishedAt(t *time.Time) *ScheduledRunUpdate {
+	if t != nil {
+		sru.SetFinishedAt(*t)
+	}
+	return sru
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (sru *ScheduledRunUpdate) ClearFinishedAt() *ScheduledRunUpdate {
+	sru.mutation.ClearFinishedAt()
+	return sru
+}
+
+// SetError sets the "error" field.
+func (sru *ScheduledRunUpdate) SetError(s string) *ScheduledRunUpdate {
+	sru.mutation.SetError(s)
+	return sru
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (sru *ScheduledRunUpdate) SetNillableError(s *string) *ScheduledRunUpdate {
+	if s != nil {
+		sru.SetError(*s)
+	}
+	return sru
+}
+
+// ClearError clears the value of the "error" field.
+func (sru *ScheduledRunUpdate) ClearError() *ScheduledRunUpdate {
+	sru.mutation.ClearError()
+	return sru
+}
+
+// Mutation returns the ScheduledRunMutation object of the builder.
+func (sru *ScheduledRunUpdate) Mutation() *ScheduledRunMutation {
+	return sru.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (sru *ScheduledRunUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, sru.sqlSave, sru.mutation, sru.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (sru *ScheduledRunUpdate) SaveX(ctx context.Context) int {
+	affected, err := sru.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (sru *ScheduledRunUpdate) Exec(ctx context.Context) error {
+	_, err := sru.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sru *ScheduledRunUpdate) ExecX(ctx context.Context) {
+	if err := sru.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (sru *ScheduledRunUpdate) check() error {
+	if v, ok := sru.mutation.Status(); ok {
+		if err := scheduledrun.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "ScheduledRun.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (sru *ScheduledRunUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := sru.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(scheduledrun.Table, scheduledrun.Columns, sqlgraph.NewFieldSpec(scheduledrun.FieldID, field.TypeInt))
+	if ps := sru.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := sru.mutation.Status(); ok {
+		_spec.SetField(scheduledrun.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := sru.mutation.FinishedAt(); ok {
+		_spec.SetField(scheduledrun.FieldFinishedAt, field.TypeTime, value)
+	}
+	if sru.mutation.FinishedAtCleared() {
+		_spec.ClearField(scheduledrun.FieldFinishedAt, field.TypeTime)
+	}
+	if value, ok := sru.mutation.Error(); ok {
+		_spec.SetField(scheduledrun.FieldError, field.TypeString, value)
+	}
+	if sru.mutation.ErrorCleared() {
+		_spec.ClearField(scheduledrun.FieldError, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, sru.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{scheduledrun.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	sru.mutation.done = true
+	return n, nil
+}
+
+// ScheduledRunUpdateOne is the builder for updating a single ScheduledRun entity.
+type ScheduledRunUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ScheduledRunMutation
+}
+
+// SetStatus sets the "status" field.
+func (sruo *ScheduledRunUpdateOne) SetStatus(s scheduledrun.Status) *ScheduledRunUpdateOne {
+	sruo.mutation.SetStatus(s)
+	return sruo
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (sruo *ScheduledRunUpdateOne) SetNillableStatus(s *scheduledrun.Status) *ScheduledRunUpdateOne {
+	if s != nil {
+		sruo.SetStatus(*s)
+	}
+	return sruo
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (sruo *ScheduledRunUpdateOne) SetFinishedAt(t time.Time) *ScheduledRunUpdateOne {
+	sruo.mutation.SetFinishedAt(t)
+	return sruo
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (sruo *ScheduledRunUpdateOne) SetNillableFinishedAt(t *time.Time) *ScheduledRunUpdateOne {
+	if t != nil {
+		sruo.SetFinishedAt(*t)
+	}
+	return sruo
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (sruo *ScheduledRunUpdateOne) ClearFinishedAt() *ScheduledRunUpdateOne {
+	sruo.mutation.ClearFinishedAt()
+	return sruo
+}
+
+// SetError sets the "error" field.
+func (sruo *ScheduledRunUpdateOne) SetError(s string) *ScheduledRunUpdateOne {
+	sruo.mutation.SetError(s)
+	return sruo
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (sruo *ScheduledRunUpdateOne) SetNillableError(s *string) *ScheduledRunUpdateOne {
+	if s != nil {
+		sruo.SetError(*s)
+	}
+	return sruo
+}
+
+// ClearError clears the value of the "error" field.
+func (sruo *ScheduledRunUpdateOne) ClearError() *ScheduledRunUpdateOne {
+	sruo.mutation.ClearError()
+	return sruo
+}
+
+// Mutation returns the ScheduledRunMutation object of the builder.
+func (sruo *ScheduledRunUpdateOne) Mutation() *ScheduledRunMutation {
+	return sruo.mutation
+}
+
+// Where appends a list predicates to the ScheduledRunUpdate builder.
+func (sruo *ScheduledRunUpdateOne) Where(ps ...predicate.ScheduledRun) *ScheduledRunUpdateOne {
+	sruo.mutation.Where(ps...)
+	return sruo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (sruo *ScheduledRunUpdateOne) Select(field string, fields ...string) *ScheduledRunUpdateOne {
+	sruo.fields = append([]string{field}, fields...)
+	return sruo
+}
+
+// Save executes the query and returns the updated ScheduledRun entity.
+func (sruo *ScheduledRunUpdateOne) Save(ctx context.Context) (*ScheduledRun, error) {
+	return withHooks(ctx, sruo.sqlSave, sruo.mutation, sruo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (sruo *ScheduledRunUpdateOne) SaveX(ctx context.Context) *ScheduledRun {
+	node, err := sruo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (sruo *ScheduledRunUpdateOne) Exec(ctx context.Context) error {
+	_, err := sruo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sruo *ScheduledRunUpdateOne) ExecX(ctx context.Context) {
+	if err := sruo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (sruo *ScheduledRunUpdateOne) check() error {
+	if v, ok := sruo.mutation.Status(); ok {
+		if err := scheduledrun.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "ScheduledRun.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (sruo *ScheduledRunUpdateOne) sqlSave(ctx context.Context) (_node *ScheduledRun, err error) {
+	if err := sruo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(scheduledrun.Table, scheduledrun.Columns, sqlgraph.NewFieldSpec(scheduledrun.FieldID, field.TypeInt))
+	id, ok := sruo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ScheduledRun.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := sruo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, scheduledrun.FieldID)
+		for _, f := range fields {
+			if !scheduledrun.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != scheduledrun.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := sruo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := sruo.mutation.Status(); ok {
+		_spec.SetField(scheduledrun.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := sruo.mutation.FinishedAt(); ok {
+		_spec.SetField(scheduledrun.FieldFinishedAt, field.TypeTime, value)
+	}
+	if sruo.mutation.FinishedAtCleared() {
+		_spec.ClearField(scheduledrun.FieldFinishedAt, field.TypeTime)
+	}
+	if value, ok := sruo.mutation.Error(); ok {
+		_spec.SetField(scheduledrun.FieldError, field.TypeString, value)
+	}
+	if sruo.mutation.ErrorCleared() {
+		_spec.ClearField(scheduledrun.FieldError, field.TypeString)
+	}
+	_node = &ScheduledRun{config: sruo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, sruo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{scheduledrun.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	sruo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,37 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// CertCache holds the schema definition for the CertCache entity.
+// ACME证书缓存，保存账号私钥、证书和证书私钥，多实例部署时共享。
+type CertCache struct {
+	ent.Schema
+}
+
+// Fields of the CertCache.
+func (CertCache) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("key").
+			NotEmpty().
+			MaxLen(255).
+			Unique().
+			Immutable().
+			Comment("缓存键，通常为域名"),
+		field.Bytes("data").
+			Sensitive().
+			Comment("PEM编码的证书链和私钥"),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the CertCache.
+func (CertCache) Edges() []ent.Edge {
+	return nil
+}
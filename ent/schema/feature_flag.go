@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"time"
+
+	"doghole/featureflag/rules"
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// FeatureFlag holds the schema definition for the FeatureFlag entity.
+// 功能开关的数据库覆盖，存在时整体替换配置文件中的同名开关。
+type FeatureFlag struct {
+	ent.Schema
+}
+
+// Fields of the FeatureFlag.
+func (FeatureFlag) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("key").
+			NotEmpty().
+			MaxLen(191).
+			Unique().
+			Immutable().
+			Comment("开关名称"),
+		field.Bool("enabled").
+			Default(false).
+			Comment("总开关"),
+		field.Int("percentage").
+			Optional().
+			Nillable().
+			Range(0, 100).
+			Comment("灰度百分比，为空表示100"),
+		field.JSON("rules", []rules.Rule{}).
+			Optional().
+			Comment("定向规则"),
+		field.String("description").
+			Optional().
+			Default(""),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the FeatureFlag.
+func (FeatureFlag) Edges() []ent.Edge {
+	return nil
+}
@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// File holds the schema definition for the File entity.
+// 已上传文件的元数据，文件内容保存在存储后端中。
+type File struct {
+	ent.Schema
+}
+
+// Fields of the File.
+func (File) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("key").
+			NotEmpty().
+			Unique().
+			Immutable().
+			Comment("存储后端中的对象键"),
+		field.String("backend").
+			NotEmpty().
+			Immutable().
+			Comment("保存文件的存储后端"),
+		field.String("name").
+			Default("").
+			Comment("上传时的原始文件名"),
+		field.String("content_type").
+			NotEmpty().
+			Immutable().
+			Comment("根据文件内容识别的MIME类型"),
+		field.Int64("size").
+			NonNegative().
+			Immutable().
+			Comment("文件字节数"),
+		field.String("checksum").
+			NotEmpty().
+			Immutable().
+			Comment("文件内容的SHA-256摘要，十六进制"),
+		field.String("owner_id").
+			Optional().
+			Default("").
+			Immutable().
+			Comment("上传者的用户ID"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the File.
+func (File) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the File.
+func (File) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("owner_id", "created_at"),
+		index.Fields("checksum"),
+	}
+}
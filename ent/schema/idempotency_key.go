@@ -0,0 +1,75 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// IdempotencyKey holds the schema definition for the IdempotencyKey entity.
+// 记录携带 Idempotency-Key 请求头的非安全请求及其响应，用于客户端重试时重放响应。
+type IdempotencyKey struct {
+	ent.Schema
+}
+
+// Fields of the IdempotencyKey.
+func (IdempotencyKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("scope").
+			Default("").
+			MaxLen(255).
+			Immutable().
+			Comment("幂等键的作用域，已认证请求为用户或租户，匿名请求为空"),
+		field.String("key").
+			NotEmpty().
+			MaxLen(255).
+			Immutable().
+			Comment("客户端提供的幂等键"),
+		field.String("method").
+			Immutable().
+			Comment("请求方法"),
+		field.String("path").
+			Immutable().
+			Comment("请求路径"),
+		field.String("fingerprint").
+			Immutable().
+			Comment("请求指纹，由方法、路径和请求体计算"),
+		field.Enum("state").
+			Values("processing", "completed").
+			Default("processing").
+			Comment("处理状态"),
+		field.Time("locked_until").
+			Optional().
+			Nillable().
+			Comment("处理中状态的租约到期时间，进程崩溃遗留的记录到期后可被新请求接管"),
+		field.Int("status_code").
+			Optional().
+			Comment("已保存的响应状态码"),
+		field.JSON("headers", map[string][]string{}).
+			Optional().
+			Comment("已保存的响应头"),
+		field.Bytes("body").
+			Optional().
+			Comment("已保存的响应体"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("expires_at").
+			Comment("过期时间，过期后该键可被重新使用"),
+	}
+}
+
+// Edges of the IdempotencyKey.
+func (IdempotencyKey) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the IdempotencyKey.
+func (IdempotencyKey) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("scope", "key").Unique(),
+		index.Fields("expires_at"),
+	}
+}
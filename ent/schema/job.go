@@ -0,0 +1,80 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Job holds the schema definition for the Job entity.
+// 后台任务队列中的任务。
+type Job struct {
+	ent.Schema
+}
+
+// Fields of the Job.
+func (Job) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("queue").
+			Default("default").
+			Immutable().
+			Comment("所属队列"),
+		field.String("type").
+			NotEmpty().
+			Immutable().
+			Comment("任务类型，对应注册的处理函数"),
+		field.Text("payload").
+			Default("null").
+			Immutable().
+			Comment("JSON格式的任务参数"),
+		field.Enum("status").
+			Values("pending", "running", "succeeded", "dead").
+			Default("pending").
+			Comment("任务状态，dead 表示重试耗尽进入死信"),
+		field.Int("attempts").
+			Default(0).
+			Comment("已执行次数"),
+		field.Int("max_attempts").
+			Default(5).
+			Comment("最大执行次数"),
+		field.Time("run_at").
+			Default(time.Now).
+			Comment("最早可执行时间"),
+		field.Time("locked_until").
+			Optional().
+			Nillable().
+			Comment("可见性超时，执行中的任务超过该时间未完成会被重新投递"),
+		field.String("locked_by").
+			Optional().
+			Default("").
+			Comment("正在执行任务的工作进程"),
+		field.String("last_error").
+			Optional().
+			Default("").
+			Comment("最近一次执行失败的错误信息"),
+		field.Time("finished_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Job.
+func (Job) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the Job.
+func (Job) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("queue", "status", "run_at"),
+		index.Fields("status", "locked_until"),
+	}
+}
@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Notification holds the schema definition for the Notification entity.
+// 用户站内信收件箱中的通知。
+type Notification struct {
+	ent.Schema
+}
+
+// Fields of the Notification.
+func (Notification) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("user_id").
+			NotEmpty().
+			Immutable().
+			Comment("接收通知的用户ID"),
+		field.String("topic").
+			NotEmpty().
+			Immutable().
+			Comment("通知主题，如 export.ready，对应通知模板"),
+		field.String("locale").
+			Immutable().
+			Comment("渲染通知使用的语言"),
+		field.String("title").
+			Immutable().
+			Comment("渲染后的标题"),
+		field.Text("body").
+			Immutable().
+			Comment("渲染后的正文"),
+		field.JSON("data", map[string]any{}).
+			Optional().
+			Immutable().
+			Comment("渲染模板使用的数据，供客户端跳转等用途"),
+		field.Time("read_at").
+			Optional().
+			Nillable().
+			Comment("已读时间，为空表示未读"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Notification.
+func (Notification) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the Notification.
+func (Notification) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "created_at"),
+		index.Fields("user_id", "read_at"),
+	}
+}
@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// NotificationPreference holds the schema definition for the NotificationPreference entity.
+// 用户按通知渠道和主题设置的接收偏好。
+type NotificationPreference struct {
+	ent.Schema
+}
+
+// Fields of the NotificationPreference.
+func (NotificationPreference) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("user_id").
+			NotEmpty().
+			Immutable(),
+		field.Enum("channel").
+			Values("in_app", "email", "webhook").
+			Immutable().
+			Comment("通知渠道"),
+		field.String("topic").
+			Default("*").
+			Immutable().
+			Comment("通知主题，* 表示该渠道的默认设置"),
+		field.Bool("enabled").
+			StructTag(`json:"enabled"`).
+			Comment("是否接收"),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the NotificationPreference.
+func (NotificationPreference) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the NotificationPreference.
+func (NotificationPreference) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "channel", "topic").Unique(),
+	}
+}
@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Outbox holds the schema definition for the Outbox entity.
+// 事务发件箱中的领域事件，与业务数据在同一事务中写入，由中继进程发布。
+type Outbox struct {
+	ent.Schema
+}
+
+// Fields of the Outbox.
+func (Outbox) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("aggregate_type").
+			NotEmpty().
+			Immutable().
+			Comment("聚合类型，如 User"),
+		field.String("aggregate_id").
+			NotEmpty().
+			Immutable().
+			Comment("聚合ID，同一聚合的事件按写入顺序发布"),
+		field.String("event_type").
+			NotEmpty().
+			Immutable().
+			Comment("事件类型，如 user.registered"),
+		field.Text("payload").
+			Default("null").
+			Immutable().
+			Comment("JSON格式的事件数据"),
+		field.JSON("headers", map[string]string{}).
+			Optional().
+			Immutable().
+			Comment("事件元数据"),
+		field.Int("attempts").
+			Default(0).
+			Comment("已尝试发布次数"),
+		field.Time("next_attempt_at").
+			Default(time.Now).
+			Comment("下一次尝试发布的时间，发布期间作为占用租约"),
+		field.String("last_error").
+			Optional().
+			Default("").
+			Comment("最近一次发布失败的错误信息"),
+		field.Time("delivered_at").
+			Optional().
+			Nillable().
+			Comment("发布成功的时间，为空表示尚未发布"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Outbox.
+func (Outbox) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the Outbox.
+func (Outbox) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("delivered_at"),
+		index.Fields("aggregate_type", "aggregate_id"),
+	}
+}
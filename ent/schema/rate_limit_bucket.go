@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// RateLimitBucket holds the schema definition for the RateLimitBucket entity.
+// 限流状态的数据库存储，供多实例部署共享限流计数。
+type RateLimitBucket struct {
+	ent.Schema
+}
+
+// Fields of the RateLimitBucket.
+func (RateLimitBucket) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("key").
+			NotEmpty().
+			MaxLen(255).
+			Immutable().
+			Comment("限流键，由策略名和客户端标识组成"),
+		field.Float("tokens").
+			Default(0).
+			Comment("令牌桶剩余令牌数"),
+		field.Int64("count").
+			Default(0).
+			Comment("滑动窗口当前窗口计数"),
+		field.Int64("prev_count").
+			Default(0).
+			Comment("滑动窗口上一窗口计数"),
+		field.Time("window_start").
+			Default(time.Now).
+			Comment("滑动窗口当前窗口起始时间"),
+		field.Time("updated_at").
+			Default(time.Now).
+			Comment("最近一次更新时间"),
+		field.Time("expires_at").
+			Comment("过期时间，过期后状态可被清理"),
+	}
+}
+
+// Edges of the RateLimitBucket.
+func (RateLimitBucket) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the RateLimitBucket.
+func (RateLimitBucket) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("key").Unique(),
+		index.Fields("expires_at"),
+	}
+}
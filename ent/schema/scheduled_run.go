@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ScheduledRun holds the schema definition for the ScheduledRun entity.
+// 定时任务的运行记录，(task, scheduled_at) 唯一约束保证多实例部署下每个触发点只运行一次。
+type ScheduledRun struct {
+	ent.Schema
+}
+
+// Fields of the ScheduledRun.
+func (ScheduledRun) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("task").
+			NotEmpty().
+			MaxLen(191).
+			Immutable().
+			Comment("任务名称"),
+		field.Time("scheduled_at").
+			Immutable().
+			Comment("计划触发时间"),
+		field.String("instance").
+			Immutable().
+			Comment("执行任务的实例"),
+		field.Enum("status").
+			Values("running", "succeeded", "failed").
+			Default("running"),
+		field.Time("started_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("finished_at").
+			Optional().
+			Nillable(),
+		field.String("error").
+			Optional().
+			Default(""),
+	}
+}
+
+// Edges of the ScheduledRun.
+func (ScheduledRun) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the ScheduledRun.
+func (ScheduledRun) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("task", "scheduled_at").Unique(),
+		index.Fields("started_at"),
+	}
+}
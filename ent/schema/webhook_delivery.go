@@ -0,0 +1,85 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// WebhookDelivery holds the schema definition for the WebhookDelivery entity.
+// 单次事件投递记录，同时作为投递日志。
+type WebhookDelivery struct {
+	ent.Schema
+}
+
+// Fields of the WebhookDelivery.
+func (WebhookDelivery) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("endpoint_id").
+			Immutable(),
+		field.String("event_id").
+			Immutable().
+			Comment("事件ID，接收方可用于去重"),
+		field.String("event_type").
+			Immutable(),
+		field.Text("payload").
+			Immutable().
+			Comment("投递的请求体"),
+		field.Enum("status").
+			Values("pending", "succeeded", "failed", "dead").
+			Default("pending").
+			Comment("投递状态，failed 表示等待重试，dead 表示已放弃"),
+		field.Int("attempts").
+			Default(0).
+			Comment("已尝试次数"),
+		field.Time("next_attempt_at").
+			Default(time.Now).
+			Comment("下一次尝试时间"),
+		field.Time("last_attempt_at").
+			Optional().
+			Nillable(),
+		field.Int("response_code").
+			Optional().
+			Nillable().
+			Comment("最近一次尝试的响应状态码"),
+		field.String("response_body").
+			Optional().
+			Default("").
+			Comment("最近一次尝试的响应体（截断）"),
+		field.String("error").
+			Optional().
+			Default("").
+			Comment("最近一次尝试的错误信息"),
+		field.Int("redelivery_of").
+			Optional().
+			Nillable().
+			Immutable().
+			Comment("手动重新投递时指向原投递记录"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the WebhookDelivery.
+func (WebhookDelivery) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("endpoint", WebhookEndpoint.Type).
+			Ref("deliveries").
+			Field("endpoint_id").
+			Unique().
+			Required().
+			Immutable(),
+	}
+}
+
+// Indexes of the WebhookDelivery.
+func (WebhookDelivery) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("status", "next_attempt_at"),
+		index.Fields("endpoint_id", "created_at"),
+	}
+}
@@ -0,0 +1,73 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// WebhookEndpoint holds the schema definition for the WebhookEndpoint entity.
+// 客户订阅的Webhook地址及其事件过滤条件。
+type WebhookEndpoint struct {
+	ent.Schema
+}
+
+// Fields of the WebhookEndpoint.
+func (WebhookEndpoint) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("url").
+			NotEmpty().
+			MaxLen(2048).
+			Comment("接收事件的地址"),
+		field.String("secret").
+			NotEmpty().
+			Sensitive().
+			Comment("用于 HMAC-SHA256 签名的密钥"),
+		field.String("description").
+			Optional().
+			Default(""),
+		field.JSON("event_types", []string{}).
+			Optional().
+			Comment("订阅的事件类型，为空表示全部，支持 user.* 形式的通配"),
+		field.String("owner_id").
+			Optional().
+			Default("").
+			Immutable().
+			Comment("所属用户ID，为空表示接收系统事件的订阅，否则只接收该用户的通知"),
+		field.Bool("enabled").
+			Default(true).
+			Comment("是否启用"),
+		field.Int("failure_count").
+			Default(0).
+			Comment("连续投递失败次数"),
+		field.Time("disabled_at").
+			Optional().
+			Nillable().
+			Comment("因连续失败被自动停用的时间"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the WebhookEndpoint.
+func (WebhookEndpoint) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("deliveries", WebhookDelivery.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Indexes of the WebhookEndpoint.
+func (WebhookEndpoint) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("owner_id"),
+	}
+}
@@ -0,0 +1,417 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"doghole/ent/webhookdelivery"
+	"doghole/ent/webhookendpoint"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// WebhookEndpointCreate is the builder for creating a WebhookEndpoint entity.
+type WebhookEndpointCreate struct {
+	config
+	mutation *WebhookEndpointMutation
+	hooks    []Hook
+}
+
+// SetURL sets the "url" field.
+func (wec *WebhookEndpointCreate) SetURL(s string) *WebhookEndpointCreate {
+	wec.mutation.SetURL(s)
+	return wec
+}
+
+// SetSecret sets the "secret" field.
+func (wec *WebhookEndpointCreate) SetSecret(s string) *WebhookEndpointCreate {
+	wec.mutation.SetSecret(s)
+	return wec
+}
+
+// SetDescription sets the "description" field.
+func (wec *WebhookEndpointCreate) SetDescription(s string) *WebhookEndpointCreate {
+	wec.mutation.SetDescription(s)
+	return wec
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableDescription(s *string) *WebhookEndpointCreate {
+	if s != nil {
+		wec.SetDescription(*s)
+	}
+	return wec
+}
+
+// SetEventTypes sets the "event_types" field.
+func (wec *WebhookEndpointCreate) SetEventTypes(s []string) *WebhookEndpointCreate {
+	wec.mutation.SetEventTypes(s)
+	return wec
+}
+
+// SetOwnerID sets the "owner_id" field.
+func (wec *WebhookEndpointCreate) SetOwnerID(s string) *WebhookEndpointCreate {
+	wec.mutation.SetOwnerID(s)
+	return wec
+}
+
+// SetNillableOwnerID sets the "owner_id" field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableOwnerID(s *string) *WebhookEndpointCreate {
+	if s != nil {
+		wec.SetOwnerID(*s)
+	}
+	return wec
+}
+
+// SetEnabled sets the "enabled" field.
+func (wec *WebhookEndpointCreate) SetEnabled(b bool) *WebhookEndpointCreate {
+	wec.mutation.SetEnabled(b)
+	return wec
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableEnabled(b *bool) *WebhookEndpointCreate {
+	if b != nil {
+		wec.SetEnabled(*b)
+	}
+	return wec
+}
+
+// SetFailureCount sets the "failure_count" field.
+func (wec *WebhookEndpointCreate) SetFailureCount(i int) *WebhookEndpointCreate {
+	wec.mutation.SetFailureCount(i)
+	return wec
+}
+
+// SetNillableFailureCount sets the "failure_count" field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableFailureCount(i *int) *WebhookEndpointCreate {
+	if i != nil {
+		wec.SetFailureCount(*i)
+	}
+	return wec
+}
+
+// SetDisabledAt sets the "disabled_at" field.
+func (wec *WebhookEndpointCreate) SetDisabledAt(t time.Time) *WebhookEndpointCreate {
+	wec.mutation.SetDisabledAt(t)
+	return wec
+}
+
+// SetNillableDisabledAt sets the "disabled_at" field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableDisabledAt(t *time.Time) *WebhookEndpointCreate {
+	if t != nil {
+		wec.SetDisabledAt(*t)
+	}
+	return wec
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (wec *WebhookEndpointCreate) SetCreatedAt(t time.Time) *WebhookEndpointCreate {
+	wec.mutation.SetCreatedAt(t)
+	return wec
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableCreatedAt(t *time.Time) *WebhookEndpointCreate {
+	if t != nil {
+		wec.SetCreatedAt(*t)
+	}
+	return wec
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (wec *WebhookEndpointCreate) SetUpdatedAt(t time.Time) *WebhookEndpointCreate {
+	wec.mutation.SetUpdatedAt(t)
+	return wec
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (wec *WebhookEndpointCreate) SetNillableUpdatedAt(t *time.Time) *WebhookEndpointCreate {
+	if t != nil {
+		wec.SetUpdatedAt(*t)
+	}
+	return wec
+}
+
+// AddDeliveryIDs adds the "deliveries" edge to the WebhookDelivery entity by IDs.
+func (wec *WebhookEndpointCreate) AddDeliveryIDs(ids ...int) *WebhookEndpointCreate {
+	wec.mutation.AddDeliveryIDs(ids...)
+	return wec
+}
+
+// AddDeliveries adds the "deliveries" edges to the WebhookDelivery entity.
+func (wec *WebhookEndpointCreate) AddDeliveries(w ...*WebhookDelivery) *WebhookEndpointCreate {
+	ids := make([]int, len(w))
+	for i := range w {
+		ids[i] = w[i].ID
+	}
+	return wec.AddDeliveryIDs(ids...)
+}
+
+// Mutation returns the WebhookEndpointMutation object of the builder.
+func (wec *WebhookEndpointCreate) Mutation() *WebhookEndpointMutation {
+	return wec.mutation
+}
+
+// Save creates the WebhookEndpoint in the database.
+func (wec *WebhookEndpointCreate) Save(ctx context.Context) (*WebhookEndpoint, error) {
+	wec.defaults()
+	return withHooks(ctx, wec.sqlSave, wec.mutation, wec.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (wec *WebhookEndpointCreate) SaveX(ctx context.Context) *WebhookEndpoint {
+	v, err := wec.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (wec *WebhookEndpointCreate) Exec(ctx context.Context) error {
+	_, err := wec.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (wec *WebhookEndpointCreate) ExecX(ctx context.Context) {
+	if err := wec.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (wec *WebhookEndpointCreate) defaults() {
+	if _, ok := wec.mutation.Description(); !ok {
+		v := webhookendpoint.DefaultDescription
+		wec.mutation.SetDescription(v)
+	}
+	if _, ok := wec.mutation.OwnerID(); !ok {
+		v := webhookendpoint.DefaultOwnerID
+		wec.mutation.SetOwnerID(v)
+	}
+	if _, ok := wec.mutation.Enabled(); !ok {
+		v := webhookendpoint.DefaultEnabled
+		wec.mutation.SetEnabled(v)
+	}
+	if _, ok := wec.mutation.FailureCount(); !ok {
+		v := webhookendpoint.DefaultFailureCount
+		wec.mutation.SetFailureCount(v)
+	}
+	if _, ok := wec.mutation.CreatedAt(); !ok {
+		v := webhookendpoint.DefaultCreatedAt()
+		wec.mutation.SetCreatedAt(v)
+	}
+	if _, ok := wec.mutation.UpdatedAt(); !ok {
+		v := webhookendpoint.DefaultUpdatedAt()
+		wec.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (wec *WebhookEndpointCreate) check() error {
+	if _, ok := wec.mutation.URL(); !ok {
+		return &ValidationError{Name: "url", err: errors.New(`ent: missing required field "WebhookEndpoint.url"`)}
+	}
+	if v, ok := wec.mutation.URL(); ok {
+		if err := webhookendpoint.URLValidator(v); err != nil {
+			return &ValidationError{Name: "url", err: fmt.Errorf(`ent: validator failed for field "WebhookEndpoint.url": %w`, err)}
+		}
+	}
+	if _, ok := wec.mutation.Secret(); !ok {
+		return &ValidationError{Name: "secret", err: errors.New(`ent: missing required field "WebhookEndpoint.secret"`)}
+	}
+	if v, ok := wec.mutation.Secret(); ok {
+		if err := webhookendpoint.SecretValidator(v); err != nil {
+			return &ValidationError{Name: "secret", err: fmt.Errorf(`ent: validator failed for field "WebhookEndpoint.secret": %w`, err)}
+		}
+	}
+	if _, ok := wec.mutation.Enabled(); !ok {
+		return &ValidationError{Name: "enabled", err: errors.New(`ent: missing required field "WebhookEndpoint.enabled"`)}
+	}
+	if _, ok := wec.mutation.FailureCount(); !ok {
+		return &ValidationError{Name: "failure_count", err: errors.New(`ent: missing required field "WebhookEndpoint.failure_count"`)}
+	}
+	if _, ok := wec.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "WebhookEndpoint.created_at"`)}
+	}
+	if _, ok := wec.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "WebhookEndpoint.updated_at"`)}
+	}
+	return nil
+}
+
+func (wec *WebhookEndpointCreate) sqlSave(ctx context.Context) (*WebhookEndpoint, error) {
+	if err := wec.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := wec.createSpec()
+	if err := sqlgraph.CreateNode(ctx, wec.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	wec.mutation.id = &_node.ID
+	wec.mutation.done = true
+	return _node, nil
+}
+
+func (wec *WebhookEndpointCreate) createSpec() (*WebhookEndpoint, *sqlgraph.CreateSpec) {
+	var (
+		_node = &WebhookEndpoint{config: wec.config}
+		_spec = sqlgraph.NewCreateSpec(webhookendpoint.Table, sqlgraph.NewFieldSpec(webhookendpoint.FieldID, field.TypeInt))
+	)
+	if value, ok := wec.mutation.URL(); ok {
+		_spec.SetField(webhookendpoint.FieldURL, field.TypeString, value)
+		_node.URL = value
+	}
+	if value, ok := wec.mutation.Secret(); ok {
+		_spec.SetField(webhookendpoint.FieldSecret, field.TypeString, value)
+		_node.Secret = value
+	}
+	if value, ok := wec.mutation.Description(); ok {
+		_spec.SetField(webhookendpoint.FieldDescription, field.TypeString, value)
+		_node.Description = value
+	}
+	if value, ok := wec.mutation.EventTypes(); ok {
+		_spec.SetField(webhookendpoint.FieldEventTypes, field.TypeJSON, value)
+		_node.EventTypes = value
+	}
+	if value, ok := wec.mutation.OwnerID(); ok {
+		_spec.SetField(webhookendpoint.FieldOwnerID, field.TypeString, value)
+		_node.OwnerID = value
+	}
+	if value, ok := wec.mutation.Enabled(); ok {
+		_spec.SetField(webhookendpoint.FieldEnabled, field.TypeBool, value)
+		_node.Enabled = value
+	}
+	if value, ok := wec.mutation.FailureCount(); ok {
+		_spec.SetField(webhookendpoint.FieldFailureCount, field.TypeInt, value)
+		_node.FailureCount = value
+	}
+	if value, ok := wec.mutation.DisabledAt(); ok {
+		_spec.SetField(webhookendpoint.FieldDisabledAt, field.TypeTime, value)
+		_node.DisabledAt = &value
+	}
+	if value, ok := wec.mutation.CreatedAt(); ok {
+		_spec.SetField(webhookendpoint.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := wec.mutation.UpdatedAt(); ok {
+		_spec.SetField(webhookendpoint.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if nodes := wec.mutation.DeliveriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   webhookendpoint.DeliveriesTable,
+			Columns: []string{webhookendpoint.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(webhookdelivery.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// WebhookEndpointCreateBulk is the builder for creating many WebhookEndpoint entities in bulk.
+type WebhookEndpointCreateBulk struct {
+	config
+	err      error
+	builders []*WebhookEndpointCreate
+}
+
+// Save creates the WebhookEndpoint entities in the database.
+func (wecb *WebhookEndpointCreateBulk) Save(ctx context.Context) ([]*WebhookEndpoint, error) {
+	if wecb.err != nil {
+		return nil, wecb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(wecb.builders))
+	nodes := make([]*WebhookEndpoint, len(wecb.builders))
+	mutators := make([]Mutator, len(wecb.builders))
+	for i := range wecb.builders {
+		func(i int, root context.Context) {
+			builder := wecb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*WebhookEndpointMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, wecb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, wecb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, wecb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (wecb *WebhookEndpointCreateBulk) SaveX(ctx context.Context) []*WebhookEndpoint {
+	v, err := wecb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (wecb *WebhookEndpointCreateBulk) Exec(ctx context.Context) error {
+	_, err := wecb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (wecb *WebhookEndpointCreateBulk) ExecX(ctx context.Context) {
+	if err := wecb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,260 @@
+package eventbus
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"doghole/ent"
+	"go.uber.org/zap"
+)
+
+// Handler 类型化的事件处理函数
+type Handler[T any] func(ctx context.Context, event T) error
+
+// subscription 事件订阅
+type subscription struct {
+	name  string
+	async bool
+	call  func(ctx context.Context, event any) error
+}
+
+// Bus 进程内类型化事件总线，按事件的Go类型分发给订阅者
+// 模块之间通过共享事件类型通信，无需互相导入
+type Bus struct {
+	mu     sync.RWMutex
+	subs   map[reflect.Type][]*subscription
+	sem    chan struct{}
+	wg     sync.WaitGroup
+	logger *zap.Logger
+}
+
+// New 创建事件总线
+func New(options ...func(*Bus)) *Bus {
+	b := &Bus{
+		subs:   make(map[reflect.Type][]*subscription),
+		sem:    make(chan struct{}, 64),
+		logger: zap.L(),
+	}
+
+	for _, option := range options {
+		option(b)
+	}
+
+	return b
+}
+
+// WithAsyncWorkers 设置异步订阅者的最大并发数，达到上限时发布方会等待
+func WithAsyncWorkers(n int) func(*Bus) {
+	return func(b *Bus) {
+		if n > 0 {
+			b.sem = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithLogger 设置日志记录器
+func WithLogger(logger *zap.Logger) func(*Bus) {
+	return func(b *Bus) {
+		b.logger = logger
+	}
+}
+
+// subscribeOptions 订阅选项
+type subscribeOptions struct {
+	name  string
+	async bool
+}
+
+// SubscribeOption 订阅选项函数
+type SubscribeOption func(*subscribeOptions)
+
+// Async 异步执行订阅者，发布方不等待执行结果，错误仅记录日志
+func Async() SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.async = true
+	}
+}
+
+// Named 设置订阅者名称，用于日志
+func Named(name string) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.name = name
+	}
+}
+
+// Subscribe 订阅类型为T的事件，返回取消订阅的函数
+//
+//	eventbus.Subscribe(eventbus.Default(), func(ctx context.Context, e user.Created) error {
+//		return sendWelcomeMail(ctx, e.UserID)
+//	}, eventbus.Async(), eventbus.Named("mail.welcome"))
+func Subscribe[T any](b *Bus, fn Handler[T], opts ...SubscribeOption) func() {
+	typ := reflect.TypeFor[T]()
+	o := subscribeOptions{name: typ.String()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	sub := &subscription{
+		name:  o.name,
+		async: o.async,
+		call: func(ctx context.Context, event any) error {
+			return fn(ctx, event.(T))
+		},
+	}
+
+	b.mu.Lock()
+	b.subs[typ] = append(b.subs[typ], sub)
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		subs := b.subs[typ]
+		for i, s := range subs {
+			if s == sub {
+				// 复制切片，避免影响正在分发的快照
+				b.subs[typ] = append(subs[:i:i], subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// publishOptions 发布选项
+type publishOptions struct {
+	tx *ent.Tx
+}
+
+// PublishOption 发布选项函数
+type PublishOption func(*publishOptions)
+
+// AfterCommit 延迟到事务提交成功后再分发，事务回滚时事件被丢弃
+// 延迟分发时同步订阅者的错误无法返回给发布方，仅记录日志
+func AfterCommit(tx *ent.Tx) PublishOption {
+	return func(o *publishOptions) {
+		o.tx = tx
+	}
+}
+
+// Publish 发布类型为T的事件
+// 同步订阅者按订阅顺序依次执行，返回所有同步订阅者的错误；单个订阅者失败或panic不影响其他订阅者
+func Publish[T any](ctx context.Context, b *Bus, event T, opts ...PublishOption) error {
+	var o publishOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	typ := reflect.TypeFor[T]()
+	if o.tx == nil {
+		return b.dispatch(ctx, typ, event)
+	}
+
+	o.tx.OnCommit(func(next ent.Committer) ent.Committer {
+		return ent.CommitFunc(func(c context.Context, tx *ent.Tx) error {
+			if err := next.Commit(c, tx); err != nil {
+				return err
+			}
+			if err := b.dispatch(ctx, typ, event); err != nil {
+				b.logger.Error("事务提交后分发事件失败", zap.String("event", typ.String()), zap.Error(err))
+			}
+			return nil
+		})
+	})
+	return nil
+}
+
+// dispatch 将事件分发给订阅者
+func (b *Bus) dispatch(ctx context.Context, typ reflect.Type, event any) error {
+	b.mu.RLock()
+	subs := b.subs[typ]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subs {
+		if !sub.async {
+			if err := b.invoke(ctx, typ, sub, event); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		// 异步订阅者不受发布方context取消的影响，但保留其中的请求上下文信息
+		asyncCtx := context.WithoutCancel(ctx)
+		b.sem <- struct{}{}
+		b.wg.Add(1)
+		go func() {
+			defer func() {
+				<-b.sem
+				b.wg.Done()
+			}()
+			_ = b.invoke(asyncCtx, typ, sub, event)
+		}()
+	}
+
+	return stderrors.Join(errs...)
+}
+
+// invoke 执行单个订阅者并将panic转换为错误
+func (b *Bus) invoke(ctx context.Context, typ reflect.Type, sub *subscription, event any) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("事件订阅者 %s panic: %v", sub.name, v)
+		}
+		if err != nil {
+			md := MetadataFrom(ctx)
+			b.logger.Error("事件订阅者执行失败",
+				zap.String("event", typ.String()),
+				zap.String("subscriber", sub.name),
+				zap.Bool("async", sub.async),
+				zap.String("requestID", md.RequestID),
+				zap.String("actor", md.Actor),
+				zap.Error(err),
+			)
+		}
+	}()
+
+	return sub.call(ctx, event)
+}
+
+// Wait 等待所有异步订阅者执行结束，用于优雅退出
+func (b *Bus) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+var (
+	_defaultBus     *Bus
+	_defaultBusOnce sync.Once
+)
+
+// Default 返回全局事件总线
+func Default() *Bus {
+	_defaultBusOnce.Do(func() {
+		if _defaultBus == nil {
+			_defaultBus = New()
+		}
+	})
+	return _defaultBus
+}
+
+// SetDefault 设置全局事件总线，需在首次调用 Default 之前设置
+func SetDefault(b *Bus) {
+	if b == nil {
+		panic("无法设置全局事件总线为nil")
+	}
+	_defaultBus = b
+}
@@ -0,0 +1,155 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"doghole/ent/enttest"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+// userCreated 测试用事件
+type userCreated struct {
+	UserID int
+}
+
+// orderPaid 测试用事件，与 userCreated 互不影响
+type orderPaid struct {
+	OrderID int
+}
+
+func TestPublish(t *testing.T) {
+	ctx := context.Background()
+	b := New(WithLogger(zap.NewNop()))
+
+	var calls []string
+	Subscribe(b, func(ctx context.Context, e userCreated) error {
+		calls = append(calls, fmt.Sprintf("first:%d", e.UserID))
+		return nil
+	})
+	Subscribe(b, func(ctx context.Context, e userCreated) error {
+		panic("boom")
+	}, Named("panicky"))
+	Subscribe(b, func(ctx context.Context, e userCreated) error {
+		return errors.New("failed")
+	})
+	unsubscribe := Subscribe(b, func(ctx context.Context, e userCreated) error {
+		calls = append(calls, fmt.Sprintf("last:%d", e.UserID))
+		return nil
+	})
+	Subscribe(b, func(ctx context.Context, e orderPaid) error {
+		calls = append(calls, "order")
+		return nil
+	})
+
+	// 同步订阅者按订阅顺序执行，单个订阅者失败或panic不影响其他订阅者，所有错误返回给发布方
+	err := Publish(ctx, b, userCreated{UserID: 1})
+	if got := strings.Join(calls, ","); got != "first:1,last:1" {
+		t.Fatalf("执行顺序 = %s", got)
+	}
+	if err == nil || !strings.Contains(err.Error(), "panicky panic: boom") || !strings.Contains(err.Error(), "failed") {
+		t.Fatalf("err = %v", err)
+	}
+
+	calls = nil
+	unsubscribe()
+	Publish(ctx, b, userCreated{UserID: 2})
+	if got := strings.Join(calls, ","); got != "first:2" {
+		t.Fatalf("取消订阅后 = %s", got)
+	}
+
+	// 没有订阅者的事件类型直接返回
+	if err := Publish(ctx, b, struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPublishAsync(t *testing.T) {
+	b := New(WithLogger(zap.NewNop()), WithAsyncWorkers(2))
+
+	var (
+		mu  sync.Mutex
+		got []Metadata
+	)
+	release := make(chan struct{})
+	Subscribe(b, func(ctx context.Context, e userCreated) error {
+		<-release
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		mu.Lock()
+		got = append(got, MetadataFrom(ctx))
+		mu.Unlock()
+		return errors.New("异步订阅者的错误只记录日志")
+	}, Async())
+
+	// 异步订阅者不阻塞发布方，也不受发布方context取消的影响，请求上下文信息随事件传播
+	ctx, cancel := context.WithCancel(WithMetadata(context.Background(), Metadata{RequestID: "req-1", Actor: "alice"}))
+	if err := Publish(ctx, b, userCreated{UserID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer waitCancel()
+	if err := b.Wait(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("异步订阅者未结束时 Wait = %v", err)
+	}
+
+	close(release)
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != (Metadata{RequestID: "req-1", Actor: "alice"}) {
+		t.Fatalf("订阅者收到的上下文信息 = %+v", got)
+	}
+}
+
+func TestAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", strings.ReplaceAll(t.Name(), "/", "_")))
+	t.Cleanup(func() { client.Close() })
+
+	b := New(WithLogger(zap.NewNop()))
+	var received []int
+	Subscribe(b, func(ctx context.Context, e userCreated) error {
+		received = append(received, e.UserID)
+		return nil
+	})
+
+	// 事务回滚时事件被丢弃
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Publish(ctx, b, userCreated{UserID: 1}, AfterCommit(tx)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 事务提交后才分发
+	tx, err = client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Publish(ctx, b, userCreated{UserID: 2}, AfterCommit(tx)); err != nil {
+		t.Fatal(err)
+	}
+	if len(received) != 0 {
+		t.Fatalf("事务提交前分发了事件 %v", received)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if len(received) != 1 || received[0] != 2 {
+		t.Fatalf("收到的事件 = %v", received)
+	}
+}
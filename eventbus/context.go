@@ -0,0 +1,23 @@
+package eventbus
+
+import "context"
+
+// Metadata 随事件传播的请求上下文信息
+type Metadata struct {
+	RequestID string // 触发事件的请求ID
+	Actor     string // 触发事件的操作者，如用户ID
+}
+
+// metadataKey context中保存 Metadata 的键
+type metadataKey struct{}
+
+// WithMetadata 将请求上下文信息保存到ctx中，订阅者通过 MetadataFrom 读取
+func WithMetadata(ctx context.Context, md Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, md)
+}
+
+// MetadataFrom 读取ctx中的请求上下文信息
+func MetadataFrom(ctx context.Context) Metadata {
+	md, _ := ctx.Value(metadataKey{}).(Metadata)
+	return md
+}
@@ -0,0 +1,199 @@
+package featureflag
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"doghole/config"
+	"doghole/ent"
+	"doghole/featureflag/rules"
+	"doghole/logger"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// 开关来源
+const (
+	SourceConfig   = "config"
+	SourceDatabase = "database"
+)
+
+// State 生效中的开关
+type State struct {
+	Key    string `json:"key"`
+	Source string `json:"source"` // 来源: config, database
+	rules.Flag
+}
+
+// Store 功能开关存储，合并配置文件和数据库覆盖，数据库中的同名开关整体替换配置文件中的开关
+type Store struct {
+	client       *ent.Client
+	pollInterval time.Duration
+	logger       *zap.Logger
+
+	mu        sync.Mutex
+	fromConf  map[string]rules.Flag
+	overrides map[string]rules.Flag
+	states    atomic.Pointer[map[string]State]
+}
+
+// NewStore 创建功能开关存储，client 为空时只使用配置文件中的开关
+func NewStore(client *ent.Client, conf config.FeatureFlagConfig, options ...func(*Store)) *Store {
+	if conf.PollInterval <= 0 {
+		conf.PollInterval = 10 * time.Second
+	}
+
+	s := &Store{
+		client:       client,
+		pollInterval: conf.PollInterval,
+		logger:       zap.L(),
+		overrides:    make(map[string]rules.Flag),
+	}
+	if !conf.Enabled {
+		s.client = nil
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	s.Reload(conf)
+	return s
+}
+
+// WithLogger 设置日志记录器
+func WithLogger(logger *zap.Logger) func(*Store) {
+	return func(s *Store) {
+		s.logger = logger
+	}
+}
+
+// Reload 使用新的配置文件重新加载开关，规则无效的开关保持关闭
+func (s *Store) Reload(conf config.FeatureFlagConfig) {
+	flags := make(map[string]rules.Flag, len(conf.Flags))
+	for key, flag := range conf.Flags {
+		if err := flag.Validate(); err != nil {
+			s.logger.Error("功能开关配置无效，已关闭", zap.String("flag", key), zap.Error(err))
+			flag = rules.Flag{Description: flag.Description}
+		}
+		flags[key] = flag
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fromConf = flags
+	s.publishLocked()
+}
+
+// Refresh 从数据库重新加载开关覆盖
+func (s *Store) Refresh(ctx context.Context) error {
+	if s.client == nil {
+		return nil
+	}
+
+	rows, err := s.client.FeatureFlag.Query().All(ctx)
+	if err != nil {
+		return errors.Wrap(err, "查询功能开关失败")
+	}
+
+	overrides := make(map[string]rules.Flag, len(rows))
+	for _, row := range rows {
+		overrides[row.Key] = rules.Flag{
+			Enabled:     row.Enabled,
+			Percentage:  row.Percentage,
+			Rules:       row.Rules,
+			Description: row.Description,
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrides = overrides
+	s.publishLocked()
+	return nil
+}
+
+// Run 定期从数据库重新加载开关覆盖，直到ctx被取消
+func (s *Store) Run(ctx context.Context) {
+	if s.client == nil {
+		return
+	}
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Refresh(ctx); err != nil && ctx.Err() == nil {
+			logger.WithContext(ctx, s.logger).Error("刷新功能开关失败", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishLocked 合并配置文件和数据库覆盖并发布新的快照，调用方需持有锁
+func (s *Store) publishLocked() {
+	states := make(map[string]State, len(s.fromConf)+len(s.overrides))
+	for key, flag := range s.fromConf {
+		states[key] = State{Key: key, Source: SourceConfig, Flag: flag}
+	}
+	for key, flag := range s.overrides {
+		states[key] = State{Key: key, Source: SourceDatabase, Flag: flag}
+	}
+	s.states.Store(&states)
+}
+
+// IsEnabled 判断开关对指定属性是否开启，未定义的开关视为关闭
+func (s *Store) IsEnabled(key string, attrs rules.Attributes) bool {
+	state, ok := s.Get(key)
+	return ok && state.Evaluate(key, attrs)
+}
+
+// Get 返回生效中的开关
+func (s *Store) Get(key string) (State, bool) {
+	states := s.states.Load()
+	if states == nil {
+		return State{}, false
+	}
+	state, ok := (*states)[key]
+	return state, ok
+}
+
+// All 返回所有生效中的开关
+func (s *Store) All() map[string]State {
+	states := s.states.Load()
+	if states == nil {
+		return nil
+	}
+	return maps.Clone(*states)
+}
+
+var (
+	_defaultStore     *Store
+	_defaultStoreOnce sync.Once
+)
+
+// Default 返回全局功能开关存储，未设置时只使用全局配置中的开关
+func Default() *Store {
+	_defaultStoreOnce.Do(func() {
+		if _defaultStore == nil {
+			_defaultStore = NewStore(nil, config.GetGlobalConfig().FeatureFlag)
+		}
+	})
+	return _defaultStore
+}
+
+// SetDefault 设置全局功能开关存储，需在首次调用 Default 之前设置
+func SetDefault(s *Store) {
+	if s == nil {
+		panic("无法设置全局功能开关存储为nil")
+	}
+	_defaultStore = s
+}
@@ -0,0 +1,166 @@
+package featureflag
+
+import (
+	"sort"
+	"strings"
+
+	"doghole/domain/conn"
+	"doghole/ent"
+	entflag "doghole/ent/featureflag"
+	"doghole/featureflag/rules"
+	"doghole/logger"
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+// RegisterAdminRoutes 注册功能开关管理路由，只能挂载在管理端口上
+// 开关规则中包含被定向的用户ID、租户ID和未上线功能的名称，查询路由同样不能对外公开
+// 写入的是数据库覆盖，删除覆盖后恢复为配置文件中的定义
+func RegisterAdminRoutes(router fiber.Router) {
+	router.Get("/", listFlags)
+	router.Get("/:key", getFlag)
+	router.Get("/:key/evaluate", evaluateFlag)
+	router.Put("/:key", putFlag)
+	router.Delete("/:key", deleteFlag)
+}
+
+// listFlags 列出所有生效中的开关
+func listFlags(c fiber.Ctx) error {
+	states := Default().All()
+	data := make([]State, 0, len(states))
+	for _, state := range states {
+		data = append(data, state)
+	}
+	sort.Slice(data, func(i, j int) bool { return data[i].Key < data[j].Key })
+
+	return c.JSON(fiber.Map{"data": data})
+}
+
+// getFlag 获取单个生效中的开关
+func getFlag(c fiber.Ctx) error {
+	state, ok := Default().Get(c.Params("key"))
+	if !ok {
+		return fiber.ErrNotFound
+	}
+	return c.JSON(fiber.Map{"data": state})
+}
+
+// putFlag 创建或替换开关的数据库覆盖
+func putFlag(c fiber.Ctx) error {
+	key, err := paramKey(c)
+	if err != nil {
+		return err
+	}
+
+	var flag rules.Flag
+	if err := c.Bind().JSON(&flag); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "请求体格式错误")
+	}
+	if err := flag.Validate(); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Context()
+	client := conn.Writer()
+
+	update := client.FeatureFlag.Update().
+		Where(entflag.Key(key)).
+		SetEnabled(flag.Enabled).
+		SetRules(flag.Rules).
+		SetDescription(flag.Description)
+	if flag.Percentage != nil {
+		update.SetPercentage(*flag.Percentage)
+	} else {
+		update.ClearPercentage()
+	}
+
+	n, err := update.Save(ctx)
+	if err != nil {
+		return err
+	}
+
+	status := fiber.StatusOK
+	if n == 0 {
+		err := client.FeatureFlag.Create().
+			SetKey(key).
+			SetEnabled(flag.Enabled).
+			SetNillablePercentage(flag.Percentage).
+			SetRules(flag.Rules).
+			SetDescription(flag.Description).
+			Exec(ctx)
+		if ent.IsConstraintError(err) {
+			return fiber.NewError(fiber.StatusConflict, "开关正在被并发修改，请重试")
+		}
+		if err != nil {
+			return err
+		}
+		status = fiber.StatusCreated
+	}
+
+	// 本实例立即生效，其他实例在下一次轮询时生效
+	if err := Default().Refresh(ctx); err != nil {
+		return err
+	}
+
+	logger.Ctx(ctx).Info("功能开关已修改", zap.String("flag", key), zap.Bool("enabled", flag.Enabled))
+
+	state, _ := Default().Get(key)
+	return c.Status(status).JSON(fiber.Map{"data": state})
+}
+
+// deleteFlag 删除开关的数据库覆盖
+func deleteFlag(c fiber.Ctx) error {
+	key, err := paramKey(c)
+	if err != nil {
+		return err
+	}
+
+	n, err := conn.Writer().FeatureFlag.Delete().
+		Where(entflag.Key(key)).
+		Exec(c.Context())
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fiber.ErrNotFound
+	}
+
+	if err := Default().Refresh(c.Context()); err != nil {
+		return err
+	}
+	logger.Ctx(c.Context()).Info("功能开关覆盖已删除", zap.String("flag", key))
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// evaluateFlag 按查询参数中的属性计算开关结果，用于排查定向和灰度规则
+// 如 GET /feature-flags/new_checkout/evaluate?user_id=42&tenant_id=acme
+func evaluateFlag(c fiber.Ctx) error {
+	key := c.Params("key")
+	state, ok := Default().Get(key)
+	if !ok {
+		return fiber.ErrNotFound
+	}
+
+	attrs := make(rules.Attributes)
+	for k, v := range c.Queries() {
+		attrs[k] = v
+	}
+
+	result := fiber.Map{
+		"key":     key,
+		"enabled": state.Evaluate(key, attrs),
+	}
+	if userID := attrs[rules.AttrUserID]; userID != "" {
+		result["bucket"] = rules.Bucket(key, userID)
+	}
+	return c.JSON(fiber.Map{"data": result})
+}
+
+// paramKey 解析并校验路径中的开关名称
+func paramKey(c fiber.Ctx) (string, error) {
+	key := c.Params("key")
+	if key == "" || len(key) > 191 || strings.ContainsAny(key, ". /") {
+		return "", fiber.NewError(fiber.StatusBadRequest, "无效的开关名称")
+	}
+	return key, nil
+}
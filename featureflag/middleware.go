@@ -0,0 +1,43 @@
+package featureflag
+
+import (
+	"doghole/auth"
+	"doghole/featureflag/rules"
+	"github.com/gofiber/fiber/v3"
+)
+
+// AttributesFrom 从请求中提取定向属性
+// 用户ID和租户ID只取自认证中间件写入的 Locals，不信任调用方自行传递的请求头，
+// 否则匿名调用方可以冒充被定向的租户打开未上线的接口
+func AttributesFrom(c fiber.Ctx) rules.Attributes {
+	attrs := make(rules.Attributes, 2)
+	if id := auth.UserID(c); id != "" {
+		attrs[rules.AttrUserID] = id
+	}
+	if id := auth.TenantID(c); id != "" {
+		attrs[rules.AttrTenantID] = id
+	}
+	return attrs
+}
+
+// Enabled 判断开关对当前请求是否开启
+//
+//	if featureflag.Enabled(c, "new_checkout") {
+//		return newCheckout(c)
+//	}
+func Enabled(c fiber.Ctx, key string) bool {
+	return Default().IsEnabled(key, AttributesFrom(c))
+}
+
+// Gate 返回按开关控制路由的中间件，开关关闭时返回404，使未上线的接口对调用方不可见
+// Fiber v3 中路由中间件位于处理函数之后：
+//
+//	router.Get("/reports", reportsHandler, featureflag.Gate("reports_v2"))
+func Gate(key string) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if !Enabled(c, key) {
+			return fiber.ErrNotFound
+		}
+		return c.Next()
+	}
+}
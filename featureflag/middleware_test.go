@@ -0,0 +1,62 @@
+package featureflag
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"doghole/auth"
+	"doghole/config"
+	"doghole/featureflag/rules"
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestGateTargeting(t *testing.T) {
+	zero := 0
+	prev := Default()
+	SetDefault(NewStore(nil, config.FeatureFlagConfig{
+		Flags: map[string]rules.Flag{
+			"reports_v2": {
+				Enabled:    true,
+				Percentage: &zero,
+				Rules:      []rules.Rule{{Attribute: rules.AttrTenantID, Operator: rules.OpIn, Values: []string{"acme"}}},
+			},
+		},
+	}))
+	t.Cleanup(func() { SetDefault(prev) })
+
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error {
+		if id := c.Get("X-Test-Tenant"); id != "" {
+			c.Locals(auth.LocalsTenantID, id)
+		}
+		return c.Next()
+	})
+	app.Get("/reports", func(c fiber.Ctx) error { return c.SendString("ok") }, Gate("reports_v2"))
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		status int
+	}{
+		{"已认证的定向租户", "X-Test-Tenant", "acme", fiber.StatusOK},
+		{"已认证的其他租户", "X-Test-Tenant", "other", fiber.StatusNotFound},
+		{"匿名请求冒充租户", auth.HeaderTenantID, "acme", fiber.StatusNotFound},
+		{"匿名请求", "", "", fiber.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/reports", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d，期望 %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
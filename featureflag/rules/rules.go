@@ -0,0 +1,120 @@
+package rules
+
+import (
+	"hash/fnv"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// 常用的定向属性
+const (
+	AttrUserID   = "user_id"
+	AttrTenantID = "tenant_id"
+)
+
+// 定向规则的匹配方式
+const (
+	OpIn    = "in"
+	OpNotIn = "not_in"
+)
+
+// Attributes 用于定向和灰度的请求属性
+type Attributes map[string]string
+
+// Flag 功能开关规则
+// 关闭时对所有人关闭；开启时按顺序匹配定向规则，均未匹配时按 Percentage 灰度
+type Flag struct {
+	Enabled     bool   `json:"enabled" mapstructure:"enabled"`         // 总开关
+	Percentage  *int   `json:"percentage" mapstructure:"percentage"`   // 灰度百分比(0-100)，按用户ID稳定分桶，为空表示100
+	Rules       []Rule `json:"rules" mapstructure:"rules"`             // 定向规则，命中第一条规则后不再继续匹配
+	Description string `json:"description" mapstructure:"description"` // 说明
+}
+
+// Rule 功能开关的定向规则
+type Rule struct {
+	Attribute  string   `json:"attribute" mapstructure:"attribute"`   // 属性名，如 user_id, tenant_id
+	Operator   string   `json:"operator" mapstructure:"operator"`     // 匹配方式: in, not_in
+	Values     []string `json:"values" mapstructure:"values"`         // 属性值列表
+	Percentage *int     `json:"percentage" mapstructure:"percentage"` // 命中规则后的灰度百分比，为空表示100
+}
+
+// Evaluate 计算开关对指定属性是否开启，key 为开关名称，用于使各开关的灰度分桶相互独立
+func (f Flag) Evaluate(key string, attrs Attributes) bool {
+	if !f.Enabled {
+		return false
+	}
+
+	for _, r := range f.Rules {
+		if r.Match(attrs) {
+			return rollout(key, attrs, r.Percentage)
+		}
+	}
+
+	return rollout(key, attrs, f.Percentage)
+}
+
+// Match 判断属性是否满足定向规则
+func (r Rule) Match(attrs Attributes) bool {
+	value, ok := attrs[r.Attribute]
+	switch r.Operator {
+	case OpIn, "":
+		return ok && slices.Contains(r.Values, value)
+	case OpNotIn:
+		return !ok || !slices.Contains(r.Values, value)
+	default:
+		return false
+	}
+}
+
+// Validate 校验开关规则
+func (f Flag) Validate() error {
+	if err := validatePercentage(f.Percentage); err != nil {
+		return err
+	}
+	for _, r := range f.Rules {
+		if r.Attribute == "" {
+			return errors.New("定向规则的属性名不能为空")
+		}
+		if r.Operator != "" && r.Operator != OpIn && r.Operator != OpNotIn {
+			return errors.Errorf("不支持的匹配方式: %s", r.Operator)
+		}
+		if err := validatePercentage(r.Percentage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollout 按用户ID稳定分桶判断是否落在灰度范围内，没有用户ID的请求只在100%时开启
+func rollout(key string, attrs Attributes, percentage *int) bool {
+	if percentage == nil || *percentage >= 100 {
+		return true
+	}
+	if *percentage <= 0 {
+		return false
+	}
+
+	userID := attrs[AttrUserID]
+	if userID == "" {
+		return false
+	}
+	return Bucket(key, userID) < *percentage
+}
+
+// Bucket 返回用户在指定开关下的分桶(0-99)，同一用户在同一开关下的分桶固定不变
+func Bucket(key, userID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	h.Write([]byte{':'})
+	h.Write([]byte(userID))
+	return int(h.Sum32() % 100)
+}
+
+// validatePercentage 校验灰度百分比
+func validatePercentage(p *int) error {
+	if p != nil && (*p < 0 || *p > 100) {
+		return errors.New("灰度百分比必须在0到100之间")
+	}
+	return nil
+}
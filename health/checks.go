@@ -0,0 +1,60 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// Ping 检查数据库连接池能否连通，db 在每次检查时调用，以便使用当前的连接
+func Ping(db func() *sql.DB) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		pool := db()
+		if pool == nil {
+			return errors.New("数据库连接未初始化")
+		}
+		return pool.PingContext(ctx)
+	})
+}
+
+// DiskSpace 检查目录所在文件系统的可用空间不低于 minFree 字节，目录不存在时检查最近的上级目录
+func DiskSpace(dir string, minFree uint64) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		path, err := existingDir(dir)
+		if err != nil {
+			return err
+		}
+
+		var st syscall.Statfs_t
+		if err := syscall.Statfs(path, &st); err != nil {
+			return errors.Wrapf(err, "读取磁盘空间失败: %s", path)
+		}
+		free := uint64(st.Bavail) * uint64(st.Bsize)
+		if free < minFree {
+			return errors.Errorf("磁盘可用空间不足: %s 剩余 %d 字节，低于 %d 字节", path, free, minFree)
+		}
+		return nil
+	})
+}
+
+// existingDir 返回 dir 或其最近的已存在的上级目录
+func existingDir(dir string) (string, error) {
+	path, err := filepath.Abs(dir)
+	if err != nil {
+		return "", errors.Wrapf(err, "无效的目录: %s", dir)
+	}
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return "", errors.Errorf("目录不存在: %s", dir)
+		}
+		path = parent
+	}
+}
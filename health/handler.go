@@ -0,0 +1,36 @@
+package health
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// Handler 返回探针的处理函数，通过时返回200，失败时返回503
+// verbose 为true时返回每个检查的结果，否则只返回整体状态，用于对外开放的端口
+func Handler(registry *Registry, kind Kind, verbose bool) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		report := registry.Run(c.Context(), kind)
+
+		status := fiber.StatusOK
+		if !report.OK() {
+			status = fiber.StatusServiceUnavailable
+		}
+		c.Set(fiber.HeaderCacheControl, "no-store")
+
+		if !verbose {
+			return c.Status(status).JSON(fiber.Map{
+				"status": report.Status,
+				"time":   time.Now().Format(time.RFC3339),
+			})
+		}
+		return c.Status(status).JSON(report)
+	}
+}
+
+// RegisterRoutes 注册 /livez、/readyz 和 /startupz 探针
+func RegisterRoutes(router fiber.Router, registry *Registry, verbose bool) {
+	router.Get("/"+string(Liveness), Handler(registry, Liveness, verbose))
+	router.Get("/"+string(Readiness), Handler(registry, Readiness, verbose))
+	router.Get("/"+string(Startup), Handler(registry, Startup, verbose))
+}
@@ -0,0 +1,288 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"doghole/config"
+	"github.com/pkg/errors"
+)
+
+// Kind 探针类型
+type Kind string
+
+const (
+	Liveness  Kind = "livez"    // 存活探针，失败时进程应被重启，只应包含进程自身的检查
+	Readiness Kind = "readyz"   // 就绪探针，失败时不应再接收流量
+	Startup   Kind = "startupz" // 启动探针，启动完成前失败，期间不执行存活探针
+)
+
+// 检查状态
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Checker 健康检查，返回nil表示健康
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc 将函数适配为 Checker
+type CheckerFunc func(ctx context.Context) error
+
+// Check 执行检查
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Check 已命名的健康检查
+type Check struct {
+	Name     string
+	Checker  Checker
+	Kinds    []Kind        // 参与的探针，为空时只参与就绪探针
+	Timeout  time.Duration // 单次检查超时，0 表示使用注册表的默认值
+	CacheTTL time.Duration // 检查结果的缓存时长，0 表示使用注册表的默认值
+}
+
+// Result 单个检查的结果
+type Result struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Duration  string    `json:"duration"`
+	CheckedAt time.Time `json:"checked_at"`
+	Cached    bool      `json:"cached"`
+}
+
+// Report 探针的检查结果，任一检查失败时整体失败
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+// OK 返回探针是否通过
+func (r Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+// entry 已注册的检查及其缓存的结果，mu 保证同一检查不会并发执行
+type entry struct {
+	Check
+	mu   sync.Mutex
+	last Result
+}
+
+// Registry 健康检查注册表
+type Registry struct {
+	mu       sync.RWMutex
+	entries  []*entry
+	timeout  time.Duration
+	cacheTTL time.Duration
+	started  func() bool
+	ready    func() bool
+}
+
+// NewRegistry 创建健康检查注册表
+func NewRegistry(options ...func(*Registry)) *Registry {
+	r := &Registry{
+		timeout:  2 * time.Second,
+		cacheTTL: time.Second,
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// WithTimeout 设置检查的默认超时
+func WithTimeout(timeout time.Duration) func(*Registry) {
+	return func(r *Registry) {
+		r.timeout = timeout
+	}
+}
+
+// WithCacheTTL 设置检查结果的默认缓存时长
+func WithCacheTTL(ttl time.Duration) func(*Registry) {
+	return func(r *Registry) {
+		r.cacheTTL = ttl
+	}
+}
+
+// Configure 按配置设置默认超时和缓存时长，只影响未单独设置的检查
+func (r *Registry) Configure(conf config.HealthConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if conf.Timeout > 0 {
+		r.timeout = conf.Timeout
+	}
+	if conf.CacheTTL > 0 {
+		r.cacheTTL = conf.CacheTTL
+	}
+}
+
+// SetLifecycle 设置应用的启动状态，启动完成前启动探针和就绪探针失败，开始停止后就绪探针立即失败
+func (r *Registry) SetLifecycle(started, ready func() bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.started = started
+	r.ready = ready
+}
+
+// Register 注册健康检查，重复注册同一名称会panic
+func (r *Registry) Register(c Check) {
+	if c.Checker == nil {
+		panic("健康检查不能为nil")
+	}
+	if len(c.Kinds) == 0 {
+		c.Kinds = []Kind{Readiness}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.entries {
+		if e.Name == c.Name {
+			panic(fmt.Sprintf("健康检查重复注册: %s", c.Name))
+		}
+	}
+	r.entries = append(r.entries, &entry{Check: c})
+}
+
+// Run 并发执行探针包含的检查，每个检查在各自的超时内完成，缓存未过期时直接使用上次的结果
+func (r *Registry) Run(ctx context.Context, kind Kind) Report {
+	r.mu.RLock()
+	var entries []*entry
+	for _, e := range r.entries {
+		if e.includes(kind) {
+			entries = append(entries, e)
+		}
+	}
+	timeout, cacheTTL := r.timeout, r.cacheTTL
+	gate := r.gate(kind)
+	r.mu.RUnlock()
+
+	results := make([]Result, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = e.run(ctx, timeout, cacheTTL)
+		}()
+	}
+	wg.Wait()
+
+	if gate != nil {
+		results = append(results, *gate)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	report := Report{Status: StatusOK, Checks: results}
+	for _, res := range results {
+		if res.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+// gate 返回应用启动状态对应的检查结果，调用方需持有读锁
+func (r *Registry) gate(kind Kind) *Result {
+	var ok bool
+	var msg string
+	switch {
+	case kind == Startup && r.started != nil:
+		ok, msg = r.started(), "应用尚未启动完成"
+	case kind == Readiness && r.ready != nil:
+		ok, msg = r.ready(), "应用尚未启动完成或正在停止"
+	default:
+		return nil
+	}
+
+	res := &Result{Name: "lifecycle", Status: StatusOK, Duration: "0s", CheckedAt: time.Now()}
+	if !ok {
+		res.Status, res.Error = StatusFail, msg
+	}
+	return res
+}
+
+// includes 判断检查是否参与探针
+func (e *entry) includes(kind Kind) bool {
+	for _, k := range e.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// run 执行检查或返回缓存的结果
+func (e *entry) run(ctx context.Context, timeout, cacheTTL time.Duration) Result {
+	if e.Timeout > 0 {
+		timeout = e.Timeout
+	}
+	if e.CacheTTL > 0 {
+		cacheTTL = e.CacheTTL
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.last.CheckedAt.IsZero() && time.Since(e.last.CheckedAt) < cacheTTL {
+		res := e.last
+		res.Cached = true
+		return res
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := e.check(ctx)
+	res := Result{
+		Name:      e.Name,
+		Status:    StatusOK,
+		Duration:  time.Since(start).Round(time.Microsecond).String(),
+		CheckedAt: start,
+	}
+	if err != nil {
+		res.Status, res.Error = StatusFail, err.Error()
+	}
+	e.last = res
+	return res
+}
+
+// check 执行检查，检查未响应ctx时在超时后返回超时错误
+func (e *entry) check(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				done <- errors.Errorf("健康检查panic: %v", v)
+			}
+		}()
+		done <- e.Checker.Check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "健康检查超时")
+	}
+}
+
+var _default = NewRegistry()
+
+// Default 返回全局健康检查注册表
+func Default() *Registry {
+	return _default
+}
+
+// Register 在全局注册表中注册健康检查，供各模块注册自己的依赖检查
+func Register(c Check) {
+	_default.Register(c)
+}
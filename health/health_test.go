@@ -0,0 +1,247 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// counter 返回记录执行次数的检查，err 为检查结果
+func counter(err error) (Checker, *atomic.Int32) {
+	var calls atomic.Int32
+	return CheckerFunc(func(ctx context.Context) error {
+		calls.Add(1)
+		return err
+	}), &calls
+}
+
+// names 返回报告中各检查的名称和状态
+func names(report Report) string {
+	parts := make([]string, 0, len(report.Checks))
+	for _, res := range report.Checks {
+		parts = append(parts, res.Name+"="+res.Status)
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	r := NewRegistry(WithCacheTTL(time.Nanosecond))
+	ok, _ := counter(nil)
+	r.Register(Check{Name: "redis", Checker: ok})
+	r.Register(Check{Name: "db", Checker: ok, Kinds: []Kind{Readiness, Startup}})
+	r.Register(Check{Name: "disk", Checker: CheckerFunc(func(context.Context) error { return errors.New("磁盘已满") }), Kinds: []Kind{Liveness}})
+
+	tests := []struct {
+		kind   Kind
+		status string
+		checks string
+	}{
+		// 未指定探针的检查只参与就绪探针，结果按名称排序
+		{Readiness, StatusOK, "db=ok,redis=ok"},
+		{Startup, StatusOK, "db=ok"},
+		{Liveness, StatusFail, "disk=fail"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			report := r.Run(ctx, tt.kind)
+			if report.Status != tt.status || names(report) != tt.checks {
+				t.Fatalf("Run = %s %s，期望 %s %s", report.Status, names(report), tt.status, tt.checks)
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("重复注册同一名称应panic")
+		}
+	}()
+	r.Register(Check{Name: "db", Checker: ok})
+}
+
+func TestRunCache(t *testing.T) {
+	ctx := context.Background()
+	r := NewRegistry(WithCacheTTL(time.Hour))
+	cached, cachedCalls := counter(errors.New("连接被拒绝"))
+	uncached, uncachedCalls := counter(nil)
+	r.Register(Check{Name: "cached", Checker: cached})
+	r.Register(Check{Name: "uncached", Checker: uncached, CacheTTL: time.Nanosecond})
+
+	first := r.Run(ctx, Readiness)
+	second := r.Run(ctx, Readiness)
+
+	// 缓存期内复用上次的结果，包括失败的结果
+	if n := cachedCalls.Load(); n != 1 {
+		t.Fatalf("缓存的检查执行了 %d 次", n)
+	}
+	if first.Checks[0].Cached || !second.Checks[0].Cached || second.Checks[0].Error != "连接被拒绝" {
+		t.Fatalf("缓存的检查结果 = %+v, %+v", first.Checks[0], second.Checks[0])
+	}
+	if !second.Checks[0].CheckedAt.Equal(first.Checks[0].CheckedAt) {
+		t.Fatal("缓存的结果应保留原检查时间")
+	}
+
+	// 单独设置的缓存时长优先于注册表的默认值
+	if n := uncachedCalls.Load(); n != 2 || second.Checks[1].Cached {
+		t.Fatalf("不缓存的检查执行了 %d 次", n)
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	defer close(block)
+
+	r := NewRegistry(WithTimeout(20*time.Millisecond), WithCacheTTL(time.Nanosecond))
+	// 不响应ctx的检查也在超时后返回
+	r.Register(Check{Name: "stuck", Checker: CheckerFunc(func(context.Context) error {
+		<-block
+		return nil
+	})})
+	r.Register(Check{Name: "slow", Timeout: time.Second, Checker: CheckerFunc(func(ctx context.Context) error {
+		select {
+		case <-time.After(50 * time.Millisecond):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})})
+	r.Register(Check{Name: "panic", Checker: CheckerFunc(func(context.Context) error { panic("boom") })})
+
+	start := time.Now()
+	report := r.Run(ctx, Readiness)
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("检查耗时 %s，超时没有生效", elapsed)
+	}
+
+	// 检查并发执行，单独设置的超时优先于注册表的默认值
+	if got := names(report); got != "panic=fail,slow=ok,stuck=fail" {
+		t.Fatalf("检查结果 = %s", got)
+	}
+	for _, res := range report.Checks {
+		switch res.Name {
+		case "stuck":
+			if !strings.Contains(res.Error, "健康检查超时") {
+				t.Fatalf("超时的检查错误 = %q", res.Error)
+			}
+		case "panic":
+			if !strings.Contains(res.Error, "健康检查panic: boom") {
+				t.Fatalf("panic的检查错误 = %q", res.Error)
+			}
+		}
+	}
+}
+
+func TestLifecycleGate(t *testing.T) {
+	ctx := context.Background()
+	r := NewRegistry()
+	ok, _ := counter(nil)
+	r.Register(Check{Name: "db", Checker: ok, Kinds: []Kind{Liveness, Readiness, Startup}})
+
+	// 未设置生命周期时不影响探针
+	if report := r.Run(ctx, Readiness); names(report) != "db=ok" {
+		t.Fatalf("未设置生命周期 = %s", names(report))
+	}
+
+	var started, ready atomic.Bool
+	r.SetLifecycle(started.Load, ready.Load)
+
+	tests := []struct {
+		name      string
+		started   bool
+		ready     bool
+		liveness  string
+		readiness string
+		startup   string
+	}{
+		{"启动中", false, false, StatusOK, StatusFail, StatusFail},
+		{"运行中", true, true, StatusOK, StatusOK, StatusOK},
+		// 开始停止后就绪探针失败，启动探针和存活探针不受影响
+		{"停止中", true, false, StatusOK, StatusFail, StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started.Store(tt.started)
+			ready.Store(tt.ready)
+			for kind, want := range map[Kind]string{Liveness: tt.liveness, Readiness: tt.readiness, Startup: tt.startup} {
+				if got := r.Run(ctx, kind).Status; got != want {
+					t.Fatalf("%s = %s，期望 %s", kind, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := NewRegistry()
+	var healthy atomic.Bool
+	r.Register(Check{Name: "db", CacheTTL: time.Nanosecond, Checker: CheckerFunc(func(context.Context) error {
+		if !healthy.Load() {
+			return errors.New("连接被拒绝")
+		}
+		return nil
+	})})
+
+	app := fiber.New()
+	RegisterRoutes(app, r, false)
+	RegisterRoutes(app.Group("/admin"), r, true)
+
+	tests := []struct {
+		name    string
+		path    string
+		healthy bool
+		status  int
+		error   string
+	}{
+		{"通过", "/readyz", true, fiber.StatusOK, ""},
+		{"失败", "/readyz", false, fiber.StatusServiceUnavailable, ""},
+		// 对外端口不返回检查详情，避免泄露内部信息
+		{"详情", "/admin/readyz", false, fiber.StatusServiceUnavailable, "连接被拒绝"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthy.Store(tt.healthy)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.path, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.status || resp.Header.Get(fiber.HeaderCacheControl) != "no-store" {
+				t.Fatalf("status = %d，Cache-Control = %q", resp.StatusCode, resp.Header.Get(fiber.HeaderCacheControl))
+			}
+
+			var report Report
+			if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+				t.Fatal(err)
+			}
+			if tt.error == "" && len(report.Checks) != 0 {
+				t.Fatalf("返回了检查详情 %+v", report.Checks)
+			}
+			if tt.error != "" && (len(report.Checks) != 1 || report.Checks[0].Error != tt.error) {
+				t.Fatalf("检查详情 = %+v", report.Checks)
+			}
+		})
+	}
+}
+
+func TestDiskSpace(t *testing.T) {
+	ctx := context.Background()
+	// 目录不存在时检查最近的上级目录
+	dir := filepath.Join(t.TempDir(), "uploads", "avatars")
+
+	if err := DiskSpace(dir, 0).Check(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := DiskSpace(dir, math.MaxUint64).Check(ctx); err == nil || !strings.Contains(err.Error(), "磁盘可用空间不足") {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package imaging
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// gifFrames 解析GIF的块结构，返回帧数和所有帧的像素数之和，不解压图像数据
+func gifFrames(data []byte) (frames, pixels int, err error) {
+	// 文件头6字节，逻辑屏幕描述符7字节
+	if len(data) < 13 {
+		return 0, 0, errors.Wrap(ErrCorrupt, "GIF文件头不完整")
+	}
+	width := int(binary.LittleEndian.Uint16(data[6:]))
+	height := int(binary.LittleEndian.Uint16(data[8:]))
+
+	i := 13 + colorTableSize(data[10])
+	for i < len(data) {
+		switch data[i] {
+		case 0x21: // 扩展块：引导符、标签和数据子块
+			if i, err = skipSubBlocks(data, i+2); err != nil {
+				return 0, 0, err
+			}
+		case 0x2C: // 图像描述符：位置、尺寸和标志，之后是局部颜色表、LZW最小码长和数据子块
+			if i+10 > len(data) {
+				return 0, 0, errors.Wrap(ErrCorrupt, "GIF图像描述符不完整")
+			}
+			left := int(binary.LittleEndian.Uint16(data[i+1:]))
+			top := int(binary.LittleEndian.Uint16(data[i+3:]))
+			w := int(binary.LittleEndian.Uint16(data[i+5:]))
+			h := int(binary.LittleEndian.Uint16(data[i+7:]))
+			if left+w > width || top+h > height {
+				return 0, 0, errors.Wrap(ErrCorrupt, "GIF帧超出逻辑屏幕")
+			}
+			frames++
+			pixels += w * h
+
+			if i, err = skipSubBlocks(data, i+10+colorTableSize(data[i+9])+1); err != nil {
+				return 0, 0, err
+			}
+		case 0x3B: // 结束符
+			return frames, pixels, nil
+		default:
+			return 0, 0, errors.Wrap(ErrCorrupt, "GIF包含未知的块")
+		}
+	}
+	return 0, 0, errors.Wrap(ErrCorrupt, "GIF缺少结束符")
+}
+
+// colorTableSize 根据逻辑屏幕描述符或图像描述符的标志字节计算颜色表的字节数
+func colorTableSize(flags byte) int {
+	if flags&0x80 == 0 {
+		return 0
+	}
+	return 3 << ((flags & 0x07) + 1)
+}
+
+// skipSubBlocks 跳过从 i 开始的数据子块序列，返回终止块之后的位置
+func skipSubBlocks(data []byte, i int) (int, error) {
+	for {
+		if i >= len(data) {
+			return 0, errors.Wrap(ErrCorrupt, "GIF数据子块不完整")
+		}
+		n := int(data[i])
+		i++
+		if n == 0 {
+			return i, nil
+		}
+		i += n
+	}
+}
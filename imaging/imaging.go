@@ -0,0 +1,164 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp" // 同时注册WebP解码器
+)
+
+// Format 图片格式，取值与 image.DecodeConfig 返回的格式名一致
+type Format string
+
+// 支持的图片格式
+const (
+	PNG  Format = "png"
+	JPEG Format = "jpeg"
+	GIF  Format = "gif"
+	WebP Format = "webp"
+)
+
+var (
+	// ErrUnsupportedFormat 不是支持的图片格式
+	ErrUnsupportedFormat = errors.New("不支持的图片格式")
+	// ErrTooManyPixels 图片像素数超过上限
+	ErrTooManyPixels = errors.New("图片尺寸过大")
+	// ErrTooManyFrames 动图帧数超过上限
+	ErrTooManyFrames = errors.New("动图帧数过多")
+	// ErrCorrupt 文件头有效但图片数据损坏
+	ErrCorrupt = errors.New("图片数据损坏")
+)
+
+// Info 图片基本信息
+type Info struct {
+	Format Format `json:"format"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// Detect 根据文件内容识别图片格式，只解析文件头和GIF的帧结构，不解码像素
+// maxPixels 大于0时拒绝宽高乘积超过该值的图片，GIF按所有帧的像素数之和计算，防止解压炸弹耗尽内存；
+// maxFrames 大于0时拒绝帧数超过该值的GIF
+func Detect(data []byte, maxPixels, maxFrames int) (Info, error) {
+	conf, name, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Info{}, ErrUnsupportedFormat
+	}
+
+	format := Format(name)
+	switch format {
+	case PNG, JPEG, GIF, WebP:
+	default:
+		return Info{}, ErrUnsupportedFormat
+	}
+
+	if conf.Width <= 0 || conf.Height <= 0 {
+		return Info{}, ErrUnsupportedFormat
+	}
+	if maxPixels > 0 && conf.Width*conf.Height > maxPixels {
+		return Info{}, ErrTooManyPixels
+	}
+
+	// GIF 的逻辑屏幕尺寸不能反映解码的开销，高度压缩的多帧动图解码后每一帧都占用内存
+	if format == GIF {
+		frames, pixels, err := gifFrames(data)
+		if err != nil {
+			return Info{}, err
+		}
+		if maxFrames > 0 && frames > maxFrames {
+			return Info{}, ErrTooManyFrames
+		}
+		if maxPixels > 0 && pixels > maxPixels {
+			return Info{}, ErrTooManyPixels
+		}
+	}
+
+	info := Info{Format: format, Width: conf.Width, Height: conf.Height}
+	if format == JPEG && jpegOrientation(data) >= 5 {
+		// 按EXIF方向旋转90度的图片宽高互换
+		info.Width, info.Height = info.Height, info.Width
+	}
+	return info, nil
+}
+
+// Sanitize 去除图片中的EXIF、XMP、文本注释等元数据
+// JPEG 按EXIF方向摆正后重新编码，PNG、GIF 重新编码，WebP 完整解码校验后在容器层面删除元数据块，不重新压缩
+// 调用前需通过 Detect 检查像素数和帧数
+func Sanitize(data []byte, format Format, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+
+	switch format {
+	case JPEG:
+		img, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Wrapf(ErrCorrupt, "解码JPEG失败: %v", err)
+		}
+		img = orient(img, jpegOrientation(data))
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, errors.Wrap(err, "编码JPEG失败")
+		}
+	case PNG:
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Wrapf(ErrCorrupt, "解码PNG失败: %v", err)
+		}
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, errors.Wrap(err, "编码PNG失败")
+		}
+	case GIF:
+		// 保留所有帧和循环次数，应用扩展和注释块不会被重新写入
+		g, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Wrapf(ErrCorrupt, "解码GIF失败: %v", err)
+		}
+		if err := gif.EncodeAll(&buf, g); err != nil {
+			return nil, errors.Wrap(err, "编码GIF失败")
+		}
+	case WebP:
+		// 没有WebP编码器，无法重新编码，解码一次确认图片数据有效，动图等解码器不支持的WebP同样拒绝
+		if _, err := webp.Decode(bytes.NewReader(data)); err != nil {
+			return nil, errors.Wrapf(ErrCorrupt, "解码WebP失败: %v", err)
+		}
+		return stripWebP(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decode 解码图片，GIF 只取第一帧
+func Decode(r io.Reader) (image.Image, Format, error) {
+	img, name, err := image.Decode(r)
+	if err != nil {
+		return nil, "", errors.Wrapf(ErrCorrupt, "解码图片失败: %v", err)
+	}
+	return img, Format(name), nil
+}
+
+// Thumbnail 居中裁剪为正方形后缩放到 size×size
+func Thumbnail(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+	src := image.Rect(x0, y0, x0+side, y0+side)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, src, draw.Src, nil)
+	return dst
+}
+
+// Encode 编码缩略图，JPEG 原图输出JPEG，其余格式输出PNG以保留透明通道
+func Encode(w io.Writer, img image.Image, source Format, quality int) error {
+	if source == JPEG {
+		return errors.Wrap(jpeg.Encode(w, img, &jpeg.Options{Quality: quality}), "编码JPEG失败")
+	}
+	return errors.Wrap(png.Encode(w, img), "编码PNG失败")
+}
@@ -0,0 +1,144 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/png"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// webp1x1 1×1像素的无损WebP图片
+const webp1x1 = "UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA=="
+
+// pngImage 生成指定尺寸的PNG图片
+func pngImage(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// animatedGIF 生成 frames 帧、每帧 size×size 的GIF动图，全部帧内容相同，压缩后体积很小
+func animatedGIF(t *testing.T, size, frames int) []byte {
+	t.Helper()
+	g := &gif.GIF{}
+	for i := 0; i < frames; i++ {
+		g.Image = append(g.Image, image.NewPaletted(image.Rect(0, 0, size, size), palette.Plan9))
+		g.Delay = append(g.Delay, 10)
+	}
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// webpImage 返回1×1像素的WebP图片，corrupt 为true时保留文件头、破坏图像数据
+func webpImage(t *testing.T, corrupt bool) []byte {
+	t.Helper()
+	data, err := base64.StdEncoding.DecodeString(webp1x1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if corrupt {
+		// VP8L 数据块的前5字节为签名和尺寸，之后为压缩数据
+		for i := 25; i < len(data); i++ {
+			data[i] = 0xFF
+		}
+	}
+	return data
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      []byte
+		maxPixels int
+		maxFrames int
+		want      Info
+		err       error
+	}{
+		{name: "PNG", data: pngImage(t, 40, 20), maxPixels: 1000, want: Info{Format: PNG, Width: 40, Height: 20}},
+		{name: "PNG像素数超限", data: pngImage(t, 40, 30), maxPixels: 1000, err: ErrTooManyPixels},
+		{name: "伪装成图片的HTML", data: []byte("<html><script>alert(1)</script></html>"), err: ErrUnsupportedFormat},
+		{name: "只有PNG签名", data: []byte("\x89PNG\r\n\x1a\n"), err: ErrUnsupportedFormat},
+		{name: "GIF动图", data: animatedGIF(t, 10, 5), maxPixels: 1000, maxFrames: 10, want: Info{Format: GIF, Width: 10, Height: 10}},
+		{name: "GIF帧数超限", data: animatedGIF(t, 10, 11), maxPixels: 10000, maxFrames: 10, err: ErrTooManyFrames},
+		// 逻辑屏幕只有 20×20，但所有帧的像素数之和超过上限
+		{name: "GIF总像素数超限", data: animatedGIF(t, 20, 10), maxPixels: 1000, maxFrames: 100, err: ErrTooManyPixels},
+		{name: "GIF结构截断", data: animatedGIF(t, 10, 5)[:60], maxPixels: 1000, err: ErrCorrupt},
+		{name: "WebP", data: webpImage(t, false), maxPixels: 1000, want: Info{Format: WebP, Width: 1, Height: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := Detect(tt.data, tt.maxPixels, tt.maxFrames)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v，期望 %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info != tt.want {
+				t.Fatalf("info = %+v，期望 %+v", info, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	t.Run("GIF保留所有帧", func(t *testing.T) {
+		clean, err := Sanitize(animatedGIF(t, 10, 5), GIF, 85)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g, err := gif.DecodeAll(bytes.NewReader(clean))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(g.Image) != 5 {
+			t.Fatalf("帧数 = %d", len(g.Image))
+		}
+	})
+
+	t.Run("WebP", func(t *testing.T) {
+		data := webpImage(t, false)
+		clean, err := Sanitize(data, WebP, 85)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(clean, data) {
+			t.Fatal("没有元数据的WebP不应被修改")
+		}
+	})
+
+	t.Run("WebP数据损坏", func(t *testing.T) {
+		data := webpImage(t, true)
+		if _, err := Detect(data, 1000, 0); err != nil {
+			t.Fatalf("文件头有效，Detect 应通过: %v", err)
+		}
+		if _, err := Sanitize(data, WebP, 85); !errors.Is(err, ErrCorrupt) {
+			t.Fatalf("err = %v，期望 %v", err, ErrCorrupt)
+		}
+	})
+
+	t.Run("PNG数据损坏", func(t *testing.T) {
+		data := pngImage(t, 10, 10)
+		if _, err := Sanitize(data[:len(data)/2], PNG, 85); !errors.Is(err, ErrCorrupt) {
+			t.Fatalf("err = %v，期望 %v", err, ErrCorrupt)
+		}
+	})
+}
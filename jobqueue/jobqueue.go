@@ -0,0 +1,214 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"doghole/config"
+	"doghole/domain/conn"
+	"doghole/ent"
+	"doghole/ent/job"
+	"doghole/ent/predicate"
+	"github.com/pkg/errors"
+)
+
+// DefaultQueue 默认队列名称
+const DefaultQueue = "default"
+
+// Handler 任务处理函数
+type Handler func(ctx context.Context, job *ent.Job) error
+
+// Handle 将带类型参数的处理函数包装为 Handler，任务参数以JSON反序列化为T
+func Handle[T any](fn func(ctx context.Context, payload T) error) Handler {
+	return func(ctx context.Context, j *ent.Job) error {
+		var payload T
+		if err := json.Unmarshal([]byte(j.Payload), &payload); err != nil {
+			return Permanent(errors.Wrapf(err, "解析任务参数失败: %s", j.Type))
+		}
+		return fn(ctx, payload)
+	}
+}
+
+// permanentError 不需要重试的错误
+type permanentError struct {
+	err error
+}
+
+// Error 实现 error 接口
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap 返回原始错误
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent 标记错误为不可重试，任务会直接进入死信
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+var (
+	_handlers   = make(map[string]Handler)
+	_handlersMu sync.RWMutex
+)
+
+// Register 注册任务处理函数，重复注册同一类型会panic
+func Register(jobType string, handler Handler) {
+	_handlersMu.Lock()
+	defer _handlersMu.Unlock()
+
+	if handler == nil {
+		panic("任务处理函数不能为nil")
+	}
+	if _, exists := _handlers[jobType]; exists {
+		panic(fmt.Sprintf("任务类型重复注册: %s", jobType))
+	}
+	_handlers[jobType] = handler
+}
+
+// lookup 查找任务处理函数
+func lookup(jobType string) (Handler, bool) {
+	_handlersMu.RLock()
+	defer _handlersMu.RUnlock()
+	h, ok := _handlers[jobType]
+	return h, ok
+}
+
+// enqueueOptions 入队选项
+type enqueueOptions struct {
+	queue       string
+	runAt       time.Time
+	maxAttempts int
+}
+
+// EnqueueOption 入队选项函数
+type EnqueueOption func(*enqueueOptions)
+
+// OnQueue 指定任务所属队列
+func OnQueue(queue string) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.queue = queue
+	}
+}
+
+// RunAt 指定任务最早执行时间
+func RunAt(t time.Time) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.runAt = t
+	}
+}
+
+// Delay 指定任务延迟执行
+func Delay(d time.Duration) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.runAt = time.Now().Add(d)
+	}
+}
+
+// MaxAttempts 指定任务最大执行次数
+func MaxAttempts(n int) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.maxAttempts = n
+	}
+}
+
+// Enqueue 使用写入连接将任务入队
+func Enqueue(ctx context.Context, jobType string, payload any, opts ...EnqueueOption) (*ent.Job, error) {
+	return EnqueueWith(ctx, conn.Writer().Job, jobType, payload, opts...)
+}
+
+// EnqueueTx 在事务中将任务入队，任务在事务提交后才对工作进程可见
+//
+//	err := conn.WithTx(ctx, func(tx *ent.Tx) error {
+//		u, err := tx.User.Create().Save(ctx)
+//		...
+//		_, err = jobqueue.EnqueueTx(ctx, tx, "mail.welcome", WelcomeMail{UserID: u.ID})
+//		return err
+//	})
+func EnqueueTx(ctx context.Context, tx *ent.Tx, jobType string, payload any, opts ...EnqueueOption) (*ent.Job, error) {
+	return EnqueueWith(ctx, tx.Job, jobType, payload, opts...)
+}
+
+// EnqueueWith 使用指定的任务客户端将任务入队
+func EnqueueWith(ctx context.Context, jobs *ent.JobClient, jobType string, payload any, opts ...EnqueueOption) (*ent.Job, error) {
+	o := enqueueOptions{
+		queue:       DefaultQueue,
+		maxAttempts: config.GetGlobalConfig().JobQueue.MaxAttempts,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "序列化任务参数失败")
+	}
+
+	create := jobs.Create().
+		SetQueue(o.queue).
+		SetType(jobType).
+		SetPayload(string(data))
+	if !o.runAt.IsZero() {
+		create.SetRunAt(o.runAt)
+	}
+	if o.maxAttempts > 0 {
+		create.SetMaxAttempts(o.maxAttempts)
+	}
+
+	j, err := create.Save(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "任务入队失败")
+	}
+	return j, nil
+}
+
+// Purge 清理完成时间早于保留时长的成功任务和死信任务，返回删除数量
+// 保留时长不大于0时不清理该状态的任务
+func Purge(ctx context.Context, client *ent.Client, succeeded, dead time.Duration) (int, error) {
+	var statuses []predicate.Job
+	now := time.Now()
+	if succeeded > 0 {
+		statuses = append(statuses, job.And(job.StatusEQ(job.StatusSucceeded), job.FinishedAtLT(now.Add(-succeeded))))
+	}
+	if dead > 0 {
+		statuses = append(statuses, job.And(job.StatusEQ(job.StatusDead), job.FinishedAtLT(now.Add(-dead))))
+	}
+	if len(statuses) == 0 {
+		return 0, nil
+	}
+
+	n, err := client.Job.Delete().
+		Where(job.Or(statuses...)).
+		Exec(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "清理已完成任务失败")
+	}
+	return n, nil
+}
+
+// Retry 将死信任务重新入队
+func Retry(ctx context.Context, client *ent.Client, id int) error {
+	n, err := client.Job.Update().
+		Where(job.ID(id), job.StatusEQ(job.StatusDead)).
+		SetStatus(job.StatusPending).
+		SetAttempts(0).
+		SetRunAt(time.Now()).
+		ClearLockedUntil().
+		ClearFinishedAt().
+		Save(ctx)
+	if err != nil {
+		return errors.Wrap(err, "重新入队失败")
+	}
+	if n == 0 {
+		return errors.New("任务不存在或不在死信中")
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"doghole/config"
+	"doghole/ent"
+	"doghole/ent/enttest"
+	"doghole/ent/job"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+// 任务类型全局注册且不能重复，测试用的处理函数在包初始化时注册一次
+func init() {
+	Register("test.ok", func(ctx context.Context, j *ent.Job) error { return nil })
+	Register("test.fail", func(ctx context.Context, j *ent.Job) error { return errors.New("暂时失败") })
+	Register("test.permanent", Handle(func(ctx context.Context, payload struct{ N int }) error {
+		return Permanent(errors.New("参数无效"))
+	}))
+	Register("test.panic", func(ctx context.Context, j *ent.Job) error { panic("boom") })
+	Register("test.payload", Handle(func(ctx context.Context, payload []string) error { return nil }))
+}
+
+// openClient 打开测试用的内存数据库
+// 共享缓存的内存数据库不支持并发写入事务，测试中的并发出队共用一个连接
+func openClient(t *testing.T) *ent.Client {
+	t.Helper()
+	drv, err := entsql.Open(dialect.SQLite, fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", strings.ReplaceAll(t.Name(), "/", "_")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	drv.DB().SetMaxOpenConns(1)
+	client := enttest.NewClient(t, enttest.WithOptions(ent.Driver(drv)))
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// newWorker 创建指定ID的工作进程，不启动拉取循环
+func newWorker(client *ent.Client, id string, conf config.JobQueueConfig) *Worker {
+	w := NewWorker(client, conf, dialect.SQLite, WithLogger(zap.NewNop()))
+	w.id = id
+	return w
+}
+
+// enqueue 将任务入队，失败时终止测试
+func enqueue(t *testing.T, client *ent.Client, jobType string, opts ...EnqueueOption) *ent.Job {
+	t.Helper()
+	j, err := EnqueueWith(context.Background(), client.Job, jobType, map[string]int{"n": 1}, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return j
+}
+
+func TestDequeue(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+	w1 := newWorker(client, "w1", config.JobQueueConfig{VisibilityTimeout: time.Minute})
+	w2 := newWorker(client, "w2", config.JobQueueConfig{VisibilityTimeout: time.Minute})
+
+	first := enqueue(t, client, "test.dequeue")
+	second := enqueue(t, client, "test.dequeue")
+	enqueue(t, client, "test.dequeue", Delay(time.Hour))
+	enqueue(t, client, "test.dequeue", OnQueue("mail"))
+
+	// 按执行时间先后取出，每个任务只会被一个工作进程取得
+	j, err := w1.dequeue(ctx, DefaultQueue)
+	if err != nil || j == nil || j.ID != first.ID {
+		t.Fatalf("w1 取得 %+v, %v", j, err)
+	}
+	j, err = w2.dequeue(ctx, DefaultQueue)
+	if err != nil || j == nil || j.ID != second.ID {
+		t.Fatalf("w2 取得 %+v, %v", j, err)
+	}
+
+	// 未到期的任务和其他队列的任务不会被取出
+	if j, err := w1.dequeue(ctx, DefaultQueue); err != nil || j != nil {
+		t.Fatalf("取得了未到期的任务 %+v, %v", j, err)
+	}
+
+	claimed := client.Job.GetX(ctx, first.ID)
+	if claimed.Status != job.StatusRunning || claimed.Attempts != 1 || claimed.LockedBy != "w1" || claimed.LockedUntil == nil {
+		t.Fatalf("取出后的任务 = %+v", claimed)
+	}
+}
+
+func TestDequeueConcurrent(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+	for i := 0; i < 20; i++ {
+		enqueue(t, client, "test.concurrent")
+	}
+
+	var (
+		mu      sync.Mutex
+		claimed = make(map[int]string)
+		wg      sync.WaitGroup
+	)
+	for i := 0; i < 4; i++ {
+		w := newWorker(client, fmt.Sprintf("w%d", i), config.JobQueueConfig{})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				j, err := w.dequeue(ctx, DefaultQueue)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if j == nil {
+					return
+				}
+				mu.Lock()
+				if prev, ok := claimed[j.ID]; ok {
+					t.Errorf("任务 %d 同时被 %s 和 %s 取得", j.ID, prev, w.id)
+				}
+				claimed[j.ID] = w.id
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(claimed) != 20 {
+		t.Fatalf("取得了 %d 个任务", len(claimed))
+	}
+}
+
+func TestExecute(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+	w := newWorker(client, "w1", config.JobQueueConfig{BackoffBase: time.Minute, BackoffMax: time.Hour})
+
+	tests := []struct {
+		name        string
+		jobType     string
+		maxAttempts int
+		status      job.Status
+		lastError   string
+		retry       bool
+	}{
+		{"成功", "test.ok", 3, job.StatusSucceeded, "", false},
+		{"失败后重试", "test.fail", 3, job.StatusPending, "暂时失败", true},
+		{"执行次数耗尽", "test.fail", 1, job.StatusDead, "暂时失败", false},
+		{"不可重试的错误", "test.permanent", 3, job.StatusDead, "参数无效", false},
+		{"panic", "test.panic", 3, job.StatusPending, "panic: boom", true},
+		{"参数格式错误", "test.payload", 3, job.StatusDead, "解析任务参数失败", false},
+		{"未注册的类型", "test.unknown", 3, job.StatusDead, "未注册的任务类型", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := strings.ReplaceAll(tt.name, " ", "_")
+			created := enqueue(t, client, tt.jobType, OnQueue(queue), MaxAttempts(tt.maxAttempts))
+			j, err := w.dequeue(ctx, queue)
+			if err != nil || j == nil {
+				t.Fatalf("出队失败: %v", err)
+			}
+
+			before := time.Now()
+			w.execute(j)
+
+			got := client.Job.GetX(ctx, created.ID)
+			if got.Status != tt.status || !strings.Contains(got.LastError, tt.lastError) {
+				t.Fatalf("status = %s，last_error = %q", got.Status, got.LastError)
+			}
+			if got.LockedUntil != nil || got.LockedBy != "" {
+				t.Fatal("执行结束后没有释放锁")
+			}
+			if tt.retry {
+				// 第一次失败后按 backoff_base 延迟重试
+				if delay := got.RunAt.Sub(before); delay < 59*time.Second || delay > 61*time.Second {
+					t.Fatalf("重试延迟 = %s", delay)
+				}
+			} else if got.FinishedAt == nil {
+				t.Fatal("结束的任务没有记录完成时间")
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	w := newWorker(nil, "w1", config.JobQueueConfig{BackoffBase: 10 * time.Second, BackoffMax: time.Minute})
+	for attempts, want := range map[int]time.Duration{
+		1:   10 * time.Second,
+		2:   20 * time.Second,
+		3:   40 * time.Second,
+		4:   time.Minute,
+		100: time.Minute,
+	} {
+		if got := w.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %s，期望 %s", attempts, got, want)
+		}
+	}
+}
+
+func TestLeaseExpiry(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+	w1 := newWorker(client, "w1", config.JobQueueConfig{VisibilityTimeout: time.Minute})
+	w2 := newWorker(client, "w2", config.JobQueueConfig{VisibilityTimeout: time.Minute})
+
+	created := enqueue(t, client, "test.ok", MaxAttempts(3))
+	stale, err := w1.dequeue(ctx, DefaultQueue)
+	if err != nil || stale == nil {
+		t.Fatalf("出队失败: %v", err)
+	}
+
+	// 可见性超时内其他工作进程不能取得该任务
+	if j, _ := w2.dequeue(ctx, DefaultQueue); j != nil {
+		t.Fatal("执行中的任务被其他工作进程取得")
+	}
+
+	// w1 崩溃，可见性超时后任务被 w2 重新取得
+	client.Job.UpdateOneID(created.ID).SetLockedUntil(time.Now().Add(-time.Second)).ExecX(ctx)
+	j, err := w2.dequeue(ctx, DefaultQueue)
+	if err != nil || j == nil || j.ID != created.ID || j.Attempts != 2 {
+		t.Fatalf("重新取得 %+v, %v", j, err)
+	}
+
+	// w1 迟到的结果被丢弃，不影响 w2 的执行
+	w1.execute(stale)
+	if got := client.Job.GetX(ctx, created.ID); got.Status != job.StatusRunning || got.LockedBy != "w2" {
+		t.Fatalf("迟到的结果覆盖了任务状态: %s %s", got.Status, got.LockedBy)
+	}
+	w2.execute(j)
+	if got := client.Job.GetX(ctx, created.ID); got.Status != job.StatusSucceeded {
+		t.Fatalf("status = %s", got.Status)
+	}
+}
+
+func TestReapExhausted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := openClient(t)
+	w := newWorker(client, "w1", config.JobQueueConfig{VisibilityTimeout: 10 * time.Millisecond})
+
+	created := enqueue(t, client, "test.reap", MaxAttempts(1))
+	if j, err := w.dequeue(ctx, DefaultQueue); err != nil || j == nil {
+		t.Fatalf("出队失败: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	// 执行次数已耗尽的超时任务不会被重新取出，由 reapLoop 标记为死信
+	if j, _ := w.dequeue(ctx, DefaultQueue); j != nil {
+		t.Fatal("执行次数已耗尽的任务被重新取出")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.reapLoop(ctx)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for client.Job.GetX(ctx, created.ID).Status != job.StatusDead {
+		if time.Now().After(deadline) {
+			t.Fatal("超时任务没有进入死信")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+}
+
+func TestRetryAndPurge(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+
+	dead := enqueue(t, client, "test.purge")
+	client.Job.UpdateOneID(dead.ID).
+		SetStatus(job.StatusDead).
+		SetAttempts(5).
+		SetFinishedAt(time.Now().Add(-2 * time.Hour)).
+		ExecX(ctx)
+	succeeded := enqueue(t, client, "test.purge")
+	client.Job.UpdateOneID(succeeded.ID).
+		SetStatus(job.StatusSucceeded).
+		SetFinishedAt(time.Now().Add(-2 * time.Hour)).
+		ExecX(ctx)
+	recent := enqueue(t, client, "test.purge")
+	client.Job.UpdateOneID(recent.ID).
+		SetStatus(job.StatusSucceeded).
+		SetFinishedAt(time.Now()).
+		ExecX(ctx)
+
+	if err := Retry(ctx, client, succeeded.ID); err == nil {
+		t.Fatal("只有死信任务可以重新入队")
+	}
+	if err := Retry(ctx, client, dead.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := client.Job.GetX(ctx, dead.ID); got.Status != job.StatusPending || got.Attempts != 0 || got.FinishedAt != nil {
+		t.Fatalf("重新入队后的任务 = %+v", got)
+	}
+
+	n, err := Purge(ctx, client, time.Hour, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("清理了 %d 个任务", n)
+	}
+	if client.Job.Query().CountX(ctx) != 2 {
+		t.Fatal("清理了未超过保留时长的任务")
+	}
+}
@@ -0,0 +1,313 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"doghole/config"
+	"doghole/ent"
+	"doghole/ent/job"
+	"doghole/ent/predicate"
+	applogger "doghole/logger"
+	"doghole/tracing"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Worker 任务工作进程，按队列并发数拉取并执行任务
+type Worker struct {
+	client  *ent.Client
+	config  config.JobQueueConfig
+	dialect string
+	id      string
+	logger  *zap.Logger
+}
+
+// NewWorker 创建任务工作进程
+// dialect 用于决定是否使用 SELECT ... FOR UPDATE SKIP LOCKED 出队
+func NewWorker(client *ent.Client, conf config.JobQueueConfig, dialect string, options ...func(*Worker)) *Worker {
+	if len(conf.Queues) == 0 {
+		conf.Queues = map[string]int{DefaultQueue: 1}
+	}
+	if conf.PollInterval <= 0 {
+		conf.PollInterval = time.Second
+	}
+	if conf.VisibilityTimeout <= 0 {
+		conf.VisibilityTimeout = 5 * time.Minute
+	}
+	if conf.BackoffBase <= 0 {
+		conf.BackoffBase = 10 * time.Second
+	}
+	if conf.BackoffMax <= 0 {
+		conf.BackoffMax = time.Hour
+	}
+
+	hostname, _ := os.Hostname()
+	w := &Worker{
+		client:  client,
+		config:  conf,
+		dialect: dialect,
+		id:      fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+		logger:  zap.L(),
+	}
+
+	for _, option := range options {
+		option(w)
+	}
+
+	return w
+}
+
+// WithLogger 设置日志记录器
+func WithLogger(logger *zap.Logger) func(*Worker) {
+	return func(w *Worker) {
+		w.logger = logger
+	}
+}
+
+// Run 运行工作进程，ctx取消后停止拉取新任务并等待执行中的任务结束
+func (w *Worker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.reapLoop(ctx)
+	}()
+
+	for queue, concurrency := range w.config.Queues {
+		for i := 0; i < max(concurrency, 1); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				w.loop(ctx, queue)
+			}()
+		}
+		w.logger.Info("任务队列已启动", zap.String("queue", queue), zap.Int("concurrency", concurrency))
+	}
+
+	wg.Wait()
+}
+
+// loop 单个并发槽的拉取循环
+func (w *Worker) loop(ctx context.Context, queue string) {
+	for ctx.Err() == nil {
+		j, err := w.dequeue(ctx, queue)
+		if err != nil && ctx.Err() == nil {
+			w.logger.Error("任务出队失败", zap.String("queue", queue), zap.Error(err))
+		}
+		if j == nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(w.config.PollInterval):
+			}
+			continue
+		}
+
+		w.execute(j)
+	}
+}
+
+// reapLoop 定期将执行次数已耗尽的超时任务标记为死信
+func (w *Worker) reapLoop(ctx context.Context) {
+	ticker := time.NewTicker(min(w.config.VisibilityTimeout, time.Minute))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := w.client.Job.Update().
+			Where(
+				job.StatusEQ(job.StatusRunning),
+				job.LockedUntilLT(time.Now()),
+				attemptsExhausted(true),
+			).
+			SetStatus(job.StatusDead).
+			SetFinishedAt(time.Now()).
+			ClearLockedUntil().
+			SetLockedBy("").
+			SetLastError("执行超过可见性超时且执行次数已耗尽").
+			Save(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.logger.Error("标记超时任务为死信失败", zap.Error(err))
+			}
+			continue
+		}
+		if n > 0 {
+			w.logger.Error("超时任务执行次数已耗尽，已进入死信", zap.Int("count", n))
+		}
+	}
+}
+
+// attemptsExhausted 按已执行次数是否达到最大执行次数过滤任务
+func attemptsExhausted(exhausted bool) predicate.Job {
+	return func(s *sql.Selector) {
+		if exhausted {
+			s.Where(sql.ColumnsGTE(s.C(job.FieldAttempts), s.C(job.FieldMaxAttempts)))
+		} else {
+			s.Where(sql.ColumnsLT(s.C(job.FieldAttempts), s.C(job.FieldMaxAttempts)))
+		}
+	}
+}
+
+// dequeue 取出一个到期任务并标记为执行中
+// 可见性超时已过的执行中任务视为工作进程崩溃，会被重新取出；执行次数已耗尽的由 reapLoop 标记为死信，不再取出
+func (w *Worker) dequeue(ctx context.Context, queue string) (*ent.Job, error) {
+	tx, err := w.client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	query := tx.Job.Query().
+		Where(
+			job.Queue(queue),
+			job.RunAtLTE(now),
+			job.Or(
+				job.StatusEQ(job.StatusPending),
+				job.And(job.StatusEQ(job.StatusRunning), job.LockedUntilLT(now), attemptsExhausted(false)),
+			),
+		).
+		Order(ent.Asc(job.FieldRunAt), ent.Asc(job.FieldID))
+	if w.supportsSkipLocked() {
+		query = query.ForUpdate(sql.WithLockAction(sql.SkipLocked))
+	}
+
+	j, err := query.First(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// 不支持 SKIP LOCKED 时，通过带原状态条件的更新保证只有一个工作进程取得任务
+	update := tx.Job.Update().
+		Where(job.ID(j.ID), job.StatusEQ(j.Status), job.Attempts(j.Attempts)).
+		SetStatus(job.StatusRunning).
+		SetAttempts(j.Attempts + 1).
+		SetLockedUntil(now.Add(w.config.VisibilityTimeout)).
+		SetLockedBy(w.id)
+	n, err := update.Save(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if n == 0 {
+		_ = tx.Rollback()
+		return nil, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	j.Status = job.StatusRunning
+	j.Attempts++
+	return j, nil
+}
+
+// supportsSkipLocked 判断数据库是否支持 SKIP LOCKED
+func (w *Worker) supportsSkipLocked() bool {
+	return w.dialect == dialect.Postgres || w.dialect == dialect.MySQL
+}
+
+// execute 执行任务并记录结果
+// 执行超时为可见性超时，超过后任务可能已被其他工作进程取走
+func (w *Worker) execute(j *ent.Job) {
+	// 每次执行作为一条追踪的根span，任务日志带有追踪ID
+	ctx, span := tracing.Tracer().Start(context.Background(), "job "+j.Type,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.Int("job.id", j.ID),
+			attribute.String("job.queue", j.Queue),
+			attribute.Int("job.attempt", j.Attempts),
+		),
+	)
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, w.config.VisibilityTimeout)
+	defer cancel()
+
+	logger := applogger.WithContext(ctx, w.logger).With(
+		zap.Int("job", j.ID),
+		zap.String("queue", j.Queue),
+		zap.String("type", j.Type),
+		zap.Int("attempt", j.Attempts),
+	)
+
+	start := time.Now()
+	err := w.run(ctx, j)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	// 结果写回使用独立的context，避免因任务超时导致状态无法保存
+	saveCtx, saveCancel := context.WithTimeout(trace.ContextWithSpan(context.Background(), span), 10*time.Second)
+	defer saveCancel()
+
+	update := w.client.Job.Update().
+		Where(job.ID(j.ID), job.LockedBy(w.id), job.Attempts(j.Attempts)).
+		ClearLockedUntil().
+		SetLockedBy("")
+
+	var permanent *permanentError
+	switch {
+	case err == nil:
+		update.SetStatus(job.StatusSucceeded).SetFinishedAt(time.Now()).SetLastError("")
+		logger.Debug("任务执行成功", zap.Duration("latency", time.Since(start)))
+	case errors.As(err, &permanent) || j.Attempts >= j.MaxAttempts:
+		update.SetStatus(job.StatusDead).SetFinishedAt(time.Now()).SetLastError(err.Error())
+		logger.Error("任务进入死信", zap.Error(err))
+	default:
+		update.SetStatus(job.StatusPending).
+			SetRunAt(time.Now().Add(w.backoff(j.Attempts))).
+			SetLastError(err.Error())
+		logger.Warn("任务执行失败，等待重试", zap.Error(err))
+	}
+
+	if n, err := update.Save(saveCtx); err != nil {
+		logger.Error("保存任务结果失败", zap.Error(err))
+	} else if n == 0 {
+		logger.Warn("任务已超过可见性超时并被重新投递，本次结果被丢弃")
+	}
+}
+
+// run 调用处理函数并将panic转换为错误
+func (w *Worker) run(ctx context.Context, j *ent.Job) (err error) {
+	handler, ok := lookup(j.Type)
+	if !ok {
+		return Permanent(fmt.Errorf("未注册的任务类型: %s", j.Type))
+	}
+
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("任务处理函数panic: %v", v)
+		}
+	}()
+
+	return handler(ctx, j)
+}
+
+// backoff 计算第attempts次失败后的重试间隔
+func (w *Worker) backoff(attempts int) time.Duration {
+	delay := w.config.BackoffBase
+	for i := 1; i < attempts && delay < w.config.BackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, w.config.BackoffMax)
+}
@@ -0,0 +1,242 @@
+package lifecycle
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// ErrStopTimeout 组件未在超时时间内停止
+var ErrStopTimeout = errors.New("组件停止超时")
+
+// Component 应用组件，按注册顺序启动，按相反顺序停止，后注册的组件可以依赖先注册的组件
+type Component struct {
+	Name string
+
+	// Start 启动组件，返回错误时停止已启动的组件，应用启动失败；收到停止信号或启动超时时 ctx 被取消
+	Start func(ctx context.Context) error
+	// Run 启动完成后在后台运行，ctx 取消或 Stop 被调用后应尽快返回
+	// 应用停止前返回时（无论是否出错）应用都会开始停止
+	Run func(ctx context.Context) error
+	// PreStop 应用开始停止时、停止任何组件之前调用，用于结束会阻塞先停止的组件的工作，
+	// 如HTTP服务器停止时会等待处理中的请求，推送长连接需要在此之前断开
+	PreStop func(ctx context.Context) error
+	// Stop 停止组件，在 Run 的ctx取消后调用，之后仍会等待 Run 返回
+	Stop func(ctx context.Context) error
+
+	StartTimeout time.Duration // 启动超时，0 表示使用应用的默认值
+	StopTimeout  time.Duration // 停止超时，包括等待 Run 返回的时间，0 表示使用应用的默认值
+}
+
+// App 管理组件的启动和停止
+type App struct {
+	components   []Component
+	startTimeout time.Duration
+	stopTimeout  time.Duration
+	logger       *zap.Logger
+	started      atomic.Bool
+	ready        atomic.Bool
+}
+
+// New 创建应用
+func New(options ...func(*App)) *App {
+	a := &App{
+		startTimeout: 30 * time.Second,
+		stopTimeout:  10 * time.Second,
+		logger:       zap.NewNop(),
+	}
+	for _, option := range options {
+		option(a)
+	}
+	return a
+}
+
+// WithStartTimeout 设置组件默认的启动超时
+func WithStartTimeout(timeout time.Duration) func(*App) {
+	return func(a *App) {
+		a.startTimeout = timeout
+	}
+}
+
+// WithStopTimeout 设置组件默认的停止超时
+func WithStopTimeout(timeout time.Duration) func(*App) {
+	return func(a *App) {
+		a.stopTimeout = timeout
+	}
+}
+
+// WithLogger 设置记录组件启动和停止的日志记录器
+func WithLogger(logger *zap.Logger) func(*App) {
+	return func(a *App) {
+		a.logger = logger
+	}
+}
+
+// Add 注册组件，组件按注册顺序启动
+func (a *App) Add(components ...Component) {
+	a.components = append(a.components, components...)
+}
+
+// Started 返回应用是否曾经启动完成，开始停止后仍返回true，用于启动探针
+func (a *App) Started() bool {
+	return a.started.Load()
+}
+
+// Ready 返回应用是否已启动完成且尚未开始停止，用于就绪探针
+func (a *App) Ready() bool {
+	return a.ready.Load()
+}
+
+// running 已启动的组件
+type running struct {
+	Component
+	cancel context.CancelFunc
+	done   chan struct{} // Run 返回后关闭，没有 Run 时为nil
+}
+
+// Run 按顺序启动所有组件，直到ctx取消或任一组件的 Run 返回，
+// 然后按相反顺序调用已启动组件的 PreStop，再按相反顺序停止已启动的组件
+// 返回启动失败、运行失败和停止失败的错误，正常停止时返回nil
+func (a *App) Run(ctx context.Context) error {
+	// exited 接收提前返回的 Run 的结果，容量足够时组件不会阻塞
+	exited := make(chan error, len(a.components))
+	started := make([]*running, 0, len(a.components))
+
+	var err error
+	for _, c := range a.components {
+		if ctx.Err() != nil {
+			break
+		}
+		r, startErr := a.start(ctx, c, exited)
+		if startErr != nil {
+			if ctx.Err() != nil {
+				// 启动期间收到停止信号，中断的启动不视为失败
+				a.logger.Info("启动期间收到停止信号，正在停止应用", zap.String("component", c.Name), zap.Error(startErr))
+				break
+			}
+			err = startErr
+			break
+		}
+		started = append(started, r)
+	}
+
+	if err == nil && ctx.Err() == nil {
+		a.started.Store(true)
+		a.ready.Store(true)
+		a.logger.Info("应用已启动", zap.Int("components", len(started)))
+		select {
+		case <-ctx.Done():
+			a.logger.Info("收到停止信号，正在停止应用")
+		case err = <-exited:
+			a.logger.Error("组件退出，正在停止应用", zap.Error(err))
+		}
+		a.ready.Store(false)
+	}
+
+	for i := len(started) - 1; i >= 0; i-- {
+		err = multierr.Append(err, a.preStop(started[i]))
+	}
+	for i := len(started) - 1; i >= 0; i-- {
+		err = multierr.Append(err, a.stop(started[i]))
+	}
+	return err
+}
+
+// start 在超时时间内启动组件，并在后台运行其 Run
+// 启动超时从 Run 的ctx派生，收到停止信号时耗时较长的启动（如数据库迁移）也能被中断
+func (a *App) start(ctx context.Context, c Component, exited chan<- error) (*running, error) {
+	r := &running{Component: c}
+
+	if c.Start != nil {
+		timeout := c.StartTimeout
+		if timeout <= 0 {
+			timeout = a.startTimeout
+		}
+		startCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := c.Start(startCtx)
+		cancel()
+		if err != nil {
+			return nil, errors.Wrapf(err, "启动组件 %s 失败", c.Name)
+		}
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	if c.Run != nil {
+		r.done = make(chan struct{})
+		go func() {
+			defer close(r.done)
+			err := c.Run(runCtx)
+			if runCtx.Err() != nil {
+				return
+			}
+			if err == nil {
+				err = errors.Errorf("组件 %s 意外退出", c.Name)
+			} else {
+				err = errors.Wrapf(err, "组件 %s 运行失败", c.Name)
+			}
+			exited <- err
+		}()
+	}
+
+	a.logger.Debug("组件已启动", zap.String("component", c.Name))
+	return r, nil
+}
+
+// preStop 在超时时间内执行组件的 PreStop
+func (a *App) preStop(r *running) error {
+	if r.PreStop == nil {
+		return nil
+	}
+
+	timeout := r.StopTimeout
+	if timeout <= 0 {
+		timeout = a.stopTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := r.PreStop(ctx); err != nil {
+		err = errors.Wrapf(err, "组件 %s 停止前处理失败", r.Name)
+		a.logger.Error("组件停止前处理失败", zap.String("component", r.Name), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// stop 在超时时间内停止组件并等待其 Run 返回
+func (a *App) stop(r *running) error {
+	timeout := r.StopTimeout
+	if timeout <= 0 {
+		timeout = a.stopTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	r.cancel()
+	var err error
+	if r.Stop != nil {
+		if stopErr := r.Stop(ctx); stopErr != nil {
+			err = errors.Wrapf(stopErr, "停止组件 %s 失败", r.Name)
+		}
+	}
+
+	if r.done != nil {
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			err = multierr.Append(err, errors.Wrapf(ErrStopTimeout, "%s (%s)", r.Name, timeout))
+		}
+	}
+
+	if err != nil {
+		a.logger.Error("组件停止失败", zap.String("component", r.Name), zap.Error(err))
+	} else {
+		a.logger.Debug("组件已停止", zap.String("component", r.Name))
+	}
+	return err
+}
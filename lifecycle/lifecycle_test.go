@@ -0,0 +1,208 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder 按发生顺序记录组件的启动和停止
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return strings.Join(r.events, ",")
+}
+
+// component 创建记录各阶段调用的组件，Run 在ctx取消后返回，Stop 时记录 Run 的ctx是否已取消
+func (r *recorder) component(name string) Component {
+	runCtx := make(chan context.Context, 1)
+	return Component{
+		Name: name,
+		Start: func(ctx context.Context) error {
+			r.add("start:" + name)
+			return nil
+		},
+		Run: func(ctx context.Context) error {
+			runCtx <- ctx
+			<-ctx.Done()
+			return nil
+		},
+		PreStop: func(ctx context.Context) error {
+			r.add("prestop:" + name)
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			if (<-runCtx).Err() == nil {
+				r.add("stop-before-cancel:" + name)
+			}
+			r.add("stop:" + name)
+			return nil
+		},
+	}
+}
+
+func TestRunOrder(t *testing.T) {
+	rec := &recorder{}
+	app := New()
+	app.Add(rec.component("db"), rec.component("cache"), rec.component("http"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	app.Add(Component{
+		Name: "probe",
+		Start: func(context.Context) error {
+			if app.Started() || app.Ready() {
+				t.Error("启动完成前 Started 或 Ready 为true")
+			}
+			// 所有组件启动后停止应用
+			go func() {
+				for !app.Ready() {
+					time.Sleep(time.Millisecond)
+				}
+				cancel()
+			}()
+			return nil
+		},
+	})
+
+	if err := app.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !app.Started() || app.Ready() {
+		t.Fatalf("停止后 Started=%v Ready=%v", app.Started(), app.Ready())
+	}
+
+	// 按注册顺序启动，先按相反顺序调用所有 PreStop，再按相反顺序停止，Stop 在 Run 的ctx取消后调用
+	want := strings.Join([]string{
+		"start:db", "start:cache", "start:http",
+		"prestop:http", "prestop:cache", "prestop:db",
+		"stop:http", "stop:cache", "stop:db",
+	}, ",")
+	if got := rec.String(); got != want {
+		t.Fatalf("调用顺序:\n%s\n期望:\n%s", got, want)
+	}
+}
+
+func TestRunStartFailure(t *testing.T) {
+	rec := &recorder{}
+	app := New()
+	failing := rec.component("migrate")
+	failing.Start = func(context.Context) error {
+		rec.add("start:migrate")
+		return errors.New("迁移失败")
+	}
+	app.Add(rec.component("db"), rec.component("cache"), failing, rec.component("http"))
+
+	err := app.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "启动组件 migrate 失败") {
+		t.Fatalf("err = %v", err)
+	}
+	if app.Started() {
+		t.Fatal("启动失败后 Started 为true")
+	}
+
+	// 只回滚已启动的组件，启动失败的组件和之后的组件不会被停止
+	want := "start:db,start:cache,start:migrate,prestop:cache,prestop:db,stop:cache,stop:db"
+	if got := rec.String(); got != want {
+		t.Fatalf("调用顺序:\n%s\n期望:\n%s", got, want)
+	}
+}
+
+func TestRunStartInterrupted(t *testing.T) {
+	rec := &recorder{}
+	app := New()
+	slow := Component{
+		Name:         "migrate",
+		StartTimeout: time.Minute,
+		Start: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+	app.Add(rec.component("db"), slow, rec.component("http"))
+
+	// 收到停止信号时中断耗时较长的启动，停止已启动的组件，不视为失败
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	begin := time.Now()
+	if err := app.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(begin); elapsed > 5*time.Second {
+		t.Fatalf("启动没有被中断，耗时 %s", elapsed)
+	}
+	if got := rec.String(); got != "start:db,prestop:db,stop:db" {
+		t.Fatalf("调用顺序 = %s", got)
+	}
+}
+
+func TestRunStartTimeout(t *testing.T) {
+	app := New(WithStartTimeout(20 * time.Millisecond))
+	app.Add(Component{
+		Name: "migrate",
+		Start: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	err := app.Run(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestRunComponentExit(t *testing.T) {
+	rec := &recorder{}
+	app := New()
+	app.Add(rec.component("db"), Component{
+		Name: "worker",
+		Run:  func(context.Context) error { return nil },
+	})
+
+	err := app.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "组件 worker 意外退出") {
+		t.Fatalf("err = %v", err)
+	}
+	if got := rec.String(); !strings.Contains(got, "stop:db") {
+		t.Fatalf("组件退出后其他组件没有停止: %s", got)
+	}
+}
+
+func TestRunStopTimeout(t *testing.T) {
+	rec := &recorder{}
+	app := New(WithStopTimeout(20 * time.Millisecond))
+	stuck := make(chan struct{})
+	defer close(stuck)
+	app.Add(rec.component("db"), Component{
+		Name: "stuck",
+		Run: func(context.Context) error {
+			<-stuck
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := app.Run(ctx)
+	if !errors.Is(err, ErrStopTimeout) || !strings.Contains(err.Error(), "stuck") {
+		t.Fatalf("err = %v", err)
+	}
+	// 停止超时的组件不影响之后的组件停止
+	if got := rec.String(); !strings.Contains(got, "stop:db") {
+		t.Fatalf("调用顺序 = %s", got)
+	}
+}
@@ -0,0 +1,169 @@
+package listener
+
+import (
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// 地址前缀
+const (
+	PrefixUnix    = "unix:"    // Unix域套接字，如 unix:/run/doghole.sock
+	PrefixTCP4    = "tcp4:"    // 只监听IPv4，如 tcp4:0.0.0.0:8080
+	PrefixTCP6    = "tcp6:"    // 只监听IPv6，如 tcp6:[::]:8080
+	PrefixSystemd = "systemd:" // systemd 传入的指定名称的套接字，如 systemd:http
+	Systemd       = "systemd"  // systemd 传入的全部套接字
+)
+
+// UnixOptions Unix域套接字的文件权限
+type UnixOptions struct {
+	Mode  os.FileMode // 文件权限，0 表示使用 umask 决定的默认权限
+	Group string      // 所属用户组名称或GID，为空表示不修改
+}
+
+// Listen 按地址创建监听，一个地址可能对应多个监听（如 systemd 传入了多个套接字）
+// 支持 host:port、[::1]:port、tcp4:、tcp6:、unix:/path.sock、systemd 和 systemd:名称 形式的地址
+func Listen(addr string, unix UnixOptions) ([]net.Listener, error) {
+	switch {
+	case addr == Systemd:
+		lns, err := SystemdListeners("")
+		if err == nil && len(lns) == 0 {
+			err = errors.New("未从systemd继承到套接字，请检查 LISTEN_FDS 和 LISTEN_PID 环境变量")
+		}
+		return lns, err
+	case strings.HasPrefix(addr, PrefixSystemd):
+		name := strings.TrimPrefix(addr, PrefixSystemd)
+		lns, err := SystemdListeners(name)
+		if err == nil && len(lns) == 0 {
+			err = errors.Errorf("未从systemd继承到名为 %s 的套接字", name)
+		}
+		return lns, err
+	case strings.HasPrefix(addr, PrefixUnix):
+		ln, err := ListenUnix(strings.TrimPrefix(addr, PrefixUnix), unix)
+		if err != nil {
+			return nil, err
+		}
+		return []net.Listener{ln}, nil
+	}
+
+	network := "tcp"
+	if strings.HasPrefix(addr, PrefixTCP4) {
+		network, addr = "tcp4", strings.TrimPrefix(addr, PrefixTCP4)
+	} else if strings.HasPrefix(addr, PrefixTCP6) {
+		network, addr = "tcp6", strings.TrimPrefix(addr, PrefixTCP6)
+	}
+
+	ln, err := net.Listen(network, addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "监听地址失败: %s", addr)
+	}
+	return []net.Listener{ln}, nil
+}
+
+// ListenAll 监听所有地址，任一地址失败时关闭已创建的监听
+func ListenAll(addrs []string, unix UnixOptions) ([]net.Listener, error) {
+	var all []net.Listener
+	for _, addr := range addrs {
+		lns, err := Listen(addr, unix)
+		if err != nil {
+			for _, ln := range all {
+				ln.Close()
+			}
+			return nil, err
+		}
+		all = append(all, lns...)
+	}
+	return all, nil
+}
+
+// ListenUnix 创建Unix域套接字监听
+// 路径上遗留的套接字文件（上次进程异常退出未删除）无法连接时会被删除，仍有进程在监听时返回错误
+func ListenUnix(path string, opts UnixOptions) (net.Listener, error) {
+	if path == "" {
+		return nil, errors.New("Unix域套接字路径不能为空")
+	}
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "监听Unix域套接字失败: %s", path)
+	}
+
+	if opts.Group != "" {
+		gid, err := lookupGroup(opts.Group)
+		if err == nil {
+			err = os.Chown(path, -1, gid)
+		}
+		if err != nil {
+			ln.Close()
+			return nil, errors.Wrapf(err, "设置Unix域套接字用户组失败: %s", path)
+		}
+	}
+	if opts.Mode != 0 {
+		if err := os.Chmod(path, opts.Mode); err != nil {
+			ln.Close()
+			return nil, errors.Wrapf(err, "设置Unix域套接字权限失败: %s", path)
+		}
+	}
+	return ln, nil
+}
+
+// ParseMode 解析八进制的文件权限，如 "0660"，为空时返回0
+func ParseMode(mode string) (os.FileMode, error) {
+	if mode == "" {
+		return 0, nil
+	}
+	m, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || m > 0o777 {
+		return 0, errors.Errorf("无效的文件权限: %s，应为八进制，如 0660", mode)
+	}
+	return os.FileMode(m), nil
+}
+
+// removeStaleSocket 删除无进程监听的遗留套接字文件，路径上是其他类型的文件时返回错误
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "检查Unix域套接字失败: %s", path)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return errors.Errorf("路径已存在且不是套接字: %s", path)
+	}
+
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err == nil {
+		conn.Close()
+		return errors.Errorf("Unix域套接字正在被其他进程使用: %s", path)
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) && !errors.Is(err, syscall.ENOENT) {
+		return errors.Wrapf(err, "检查Unix域套接字失败: %s", path)
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "删除遗留的Unix域套接字失败: %s", path)
+	}
+	return nil
+}
+
+// lookupGroup 按名称或GID查找用户组
+func lookupGroup(group string) (int, error) {
+	if gid, err := strconv.Atoi(group); err == nil {
+		return gid, nil
+	}
+	g, err := user.LookupGroup(group)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(g.Gid)
+}
@@ -0,0 +1,108 @@
+package listener
+
+import (
+	"net"
+	"strings"
+	"sync"
+)
+
+// multi 将多个监听合并为一个，Accept 返回任一监听上的连接
+type multi struct {
+	listeners []net.Listener
+	conns     chan net.Conn
+	errs      chan error
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Multi 合并多个监听，用于只接受单个监听的服务同时在多个地址上提供服务
+// 只有一个监听时直接返回该监听
+func Multi(listeners ...net.Listener) net.Listener {
+	if len(listeners) == 1 {
+		return listeners[0]
+	}
+
+	m := &multi{
+		listeners: listeners,
+		conns:     make(chan net.Conn),
+		errs:      make(chan error, len(listeners)),
+		done:      make(chan struct{}),
+	}
+	for _, ln := range listeners {
+		go m.accept(ln)
+	}
+	return m
+}
+
+// accept 持续接受连接并转交给 Accept，监听出现永久错误时结束
+func (m *multi) accept(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				continue
+			}
+			select {
+			case m.errs <- err:
+			case <-m.done:
+			}
+			return
+		}
+
+		select {
+		case m.conns <- conn:
+		case <-m.done:
+			conn.Close()
+			return
+		}
+	}
+}
+
+// Accept 返回任一监听上的下一个连接，任一监听出现永久错误时关闭全部监听并返回该错误
+func (m *multi) Accept() (net.Conn, error) {
+	select {
+	case conn := <-m.conns:
+		return conn, nil
+	case err := <-m.errs:
+		m.Close()
+		return nil, err
+	case <-m.done:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close 关闭全部监听
+func (m *multi) Close() error {
+	var first error
+	m.closeOnce.Do(func() {
+		close(m.done)
+		for _, ln := range m.listeners {
+			if err := ln.Close(); err != nil && first == nil {
+				first = err
+			}
+		}
+	})
+	return first
+}
+
+// Addr 返回包含全部监听地址的 net.Addr
+func (m *multi) Addr() net.Addr {
+	return multiAddr(m.listeners)
+}
+
+// multiAddr 包含全部监听地址的 net.Addr，String 返回逗号分隔的地址列表
+type multiAddr []net.Listener
+
+// Network 返回第一个监听的网络类型
+func (a multiAddr) Network() string {
+	return a[0].Addr().Network()
+}
+
+// String 返回逗号分隔的全部地址
+func (a multiAddr) String() string {
+	addrs := make([]string, len(a))
+	for i, ln := range a {
+		addrs[i] = ln.Addr().String()
+	}
+	return strings.Join(addrs, ", ")
+}
@@ -0,0 +1,92 @@
+package listener
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// listenFdsStart systemd 传入的第一个文件描述符
+const listenFdsStart = 3
+
+var (
+	systemdOnce      sync.Once
+	systemdMu        sync.Mutex
+	systemdListeners []namedListener
+	systemdErr       error
+)
+
+// namedListener systemd 传入的套接字及其在 FileDescriptorName 中配置的名称
+type namedListener struct {
+	name string
+	ln   net.Listener
+}
+
+// SystemdListeners 返回systemd套接字激活传入的监听，name 为空时返回全部，否则只返回名称匹配的
+// 每个套接字只会被返回一次；未通过systemd启动时返回空
+func SystemdListeners(name string) ([]net.Listener, error) {
+	systemdOnce.Do(func() {
+		systemdListeners, systemdErr = inheritSystemd()
+	})
+	if systemdErr != nil {
+		return nil, systemdErr
+	}
+
+	systemdMu.Lock()
+	defer systemdMu.Unlock()
+
+	var taken []net.Listener
+	remaining := systemdListeners[:0]
+	for _, l := range systemdListeners {
+		if name == "" || l.name == name {
+			taken = append(taken, l.ln)
+		} else {
+			remaining = append(remaining, l)
+		}
+	}
+	systemdListeners = remaining
+	return taken, nil
+}
+
+// inheritSystemd 按 sd_listen_fds 协议读取 LISTEN_PID、LISTEN_FDS 和 LISTEN_FDNAMES，
+// 读取后清除这些环境变量，避免子进程误用
+func inheritSystemd() ([]namedListener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count <= 0 {
+		return nil, nil
+	}
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	listeners := make([]namedListener, 0, count)
+	for i := range count {
+		name := "unknown"
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+
+		f := os.NewFile(uintptr(listenFdsStart+i), name)
+		ln, err := net.FileListener(f)
+		// FileListener 复制了文件描述符，原文件可以关闭
+		f.Close()
+		if err != nil {
+			for _, l := range listeners {
+				l.ln.Close()
+			}
+			return nil, errors.Wrapf(err, "使用systemd传入的套接字失败: fd=%d name=%s", listenFdsStart+i, name)
+		}
+		listeners = append(listeners, namedListener{name: name, ln: ln})
+	}
+	return listeners, nil
+}
@@ -1,11 +1,14 @@
 package logger
 
 import (
+	"context"
 	"io"
 	"os"
 	"strings"
 
 	"github.com/natefinch/lumberjack"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -24,6 +27,12 @@ const (
 	FormatConsole = "console"
 )
 
+// _level 全局Logger的日志级别，可在运行时修改
+var _level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+
+// _output 全局Logger的输出
+var _output zapcore.WriteSyncer = zapcore.AddSync(os.Stdout)
+
 // LoggerOptions 日志选项
 type LoggerOptions struct {
 	Level           string            // 日志级别
@@ -222,10 +231,12 @@ func Init(opts ...Option) error {
 	}
 
 	// 创建Core
+	_output = output
+	_level.SetLevel(getLogLevel(options.Level))
 	core := zapcore.NewCore(
 		encoder,
 		output,
-		zap.NewAtomicLevelAt(getLogLevel(options.Level)),
+		_level,
 	)
 
 	// 添加全局字段
@@ -262,6 +273,27 @@ func Init(opts ...Option) error {
 	return nil
 }
 
+// Level 返回全局Logger当前的日志级别
+func Level() string {
+	return _level.Level().String()
+}
+
+// SetLevel 在运行时修改全局Logger的日志级别，无需重新初始化
+func SetLevel(level string) error {
+	switch strings.ToLower(level) {
+	case LevelDebug, LevelInfo, LevelWarn, LevelError, LevelFatal:
+	default:
+		return errors.Errorf("无效的日志级别: %s，可选值: debug, info, warn, error, fatal", level)
+	}
+	_level.SetLevel(getLogLevel(level))
+	return nil
+}
+
+// Output 返回全局Logger的输出，用于将其他进程已编码的日志行写入同一个日志文件
+func Output() io.Writer {
+	return _output
+}
+
 // Sync 同步日志缓冲区到输出
 func Sync() {
 	_ = zap.L().Sync()
@@ -272,6 +304,28 @@ func Logger() *zap.Logger {
 	return zap.L()
 }
 
+// Ctx 返回带有上下文中追踪ID和spanID的全局Logger，请求和任务中的日志应使用它，以便与链路追踪关联
+func Ctx(ctx context.Context) *zap.Logger {
+	return WithLogger(zap.L(), TraceFields(ctx)...)
+}
+
+// WithContext 为指定的Logger添加上下文中的追踪ID和spanID，模块使用通过选项注入的Logger时代替 Ctx
+func WithContext(ctx context.Context, l *zap.Logger) *zap.Logger {
+	return WithLogger(l, TraceFields(ctx)...)
+}
+
+// TraceFields 返回上下文中span的 trace_id 和 span_id 字段，上下文中没有有效的span时返回nil
+func TraceFields(ctx context.Context) []zap.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []zap.Field{
+		zap.String("trace_id", sc.TraceID().String()),
+		zap.String("span_id", sc.SpanID().String()),
+	}
+}
+
 // WithLogger 在上下文中使用带有额外字段的Logger
 func WithLogger(logger *zap.Logger, fields ...zap.Field) *zap.Logger {
 	if len(fields) == 0 {
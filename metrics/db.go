@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+var (
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "SQL语句执行耗时（秒），按连接、操作和表统计",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"db", "operation", "table"})
+
+	dbQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "db",
+		Name:      "query_errors_total",
+		Help:      "执行失败的SQL语句数，按连接、操作和表统计",
+	}, []string{"db", "operation", "table"})
+)
+
+// RegisterDB 注册 database/sql 连接池的统计指标（打开、使用中、空闲的连接数和等待次数等），name 用于区分读写连接
+func RegisterDB(name string, db *sql.DB) error {
+	return Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Driver 包装ent驱动，记录每条SQL语句的耗时，name 用于区分读写连接，签名与 conn.DriverWrapper 相同
+func Driver(name string, drv dialect.Driver) dialect.Driver {
+	return &driver{Driver: drv, name: name}
+}
+
+// driver 记录语句耗时的ent驱动
+type driver struct {
+	dialect.Driver
+	name string
+}
+
+// Exec 执行语句并记录耗时
+func (d *driver) Exec(ctx context.Context, query string, args, v any) error {
+	start := time.Now()
+	err := d.Driver.Exec(ctx, query, args, v)
+	record(d.name, query, start, err)
+	return err
+}
+
+// Query 执行查询并记录耗时
+func (d *driver) Query(ctx context.Context, query string, args, v any) error {
+	start := time.Now()
+	err := d.Driver.Query(ctx, query, args, v)
+	record(d.name, query, start, err)
+	return err
+}
+
+// Tx 开启事务，事务中的语句同样记录耗时
+func (d *driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	t, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tx{Tx: t, name: d.name}, nil
+}
+
+// BeginTx 按选项开启事务，底层驱动不支持时返回错误
+func (d *driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, errors.New("底层驱动不支持 BeginTx")
+	}
+	t, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &tx{Tx: t, name: d.name}, nil
+}
+
+// tx 记录语句耗时的事务
+type tx struct {
+	dialect.Tx
+	name string
+}
+
+// Exec 在事务中执行语句并记录耗时
+func (t *tx) Exec(ctx context.Context, query string, args, v any) error {
+	start := time.Now()
+	err := t.Tx.Exec(ctx, query, args, v)
+	record(t.name, query, start, err)
+	return err
+}
+
+// Query 在事务中执行查询并记录耗时
+func (t *tx) Query(ctx context.Context, query string, args, v any) error {
+	start := time.Now()
+	err := t.Tx.Query(ctx, query, args, v)
+	record(t.name, query, start, err)
+	return err
+}
+
+// record 记录语句耗时，执行失败时同时增加错误计数
+func record(name, query string, start time.Time, err error) {
+	op, table := Statement(query)
+	dbQueryDuration.WithLabelValues(name, op, table).Observe(time.Since(start).Seconds())
+	if err != nil {
+		dbQueryErrors.WithLabelValues(name, op, table).Inc()
+	}
+}
+
+// statementTable 按操作匹配语句中的表名，表名可能带有 `、" 或 [ 引号
+var statementTable = map[string]*regexp.Regexp{
+	"select": regexp.MustCompile("(?is)\\bFROM\\s+[`\"\\[]?(\\w+)"),
+	"insert": regexp.MustCompile("(?is)^\\s*INSERT\\s+(?:IGNORE\\s+)?INTO\\s+[`\"\\[]?(\\w+)"),
+	"update": regexp.MustCompile("(?is)^\\s*UPDATE\\s+[`\"\\[]?(\\w+)"),
+	"delete": regexp.MustCompile("(?is)^\\s*DELETE\\s+FROM\\s+[`\"\\[]?(\\w+)"),
+}
+
+// Statement 从SQL语句中解析操作（select、insert、update、delete 或 other）和第一个表名，无法解析表名时为空
+func Statement(query string) (op, table string) {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "other", ""
+	}
+	op = strings.ToLower(fields[0])
+	re, ok := statementTable[op]
+	if !ok {
+		return "other", ""
+	}
+	if m := re.FindStringSubmatch(query); m != nil {
+		table = m[1]
+	}
+	return op, table
+}
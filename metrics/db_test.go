@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"doghole/ent"
+	"doghole/ent/enttest"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestStatement(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		op    string
+		table string
+	}{
+		{"查询", "SELECT `users`.`id` FROM `users` WHERE `users`.`id` = ?", "select", "users"},
+		{"小写和换行", "select id\nfrom\n  jobs where id = $1", "select", "jobs"},
+		{"子查询取第一个表", `SELECT "id" FROM "outboxes" WHERE NOT EXISTS (SELECT "earlier"."id" FROM "outboxes" AS "earlier")`, "select", "outboxes"},
+		{"方括号", "SELECT [id] FROM [files]", "select", "files"},
+		{"写入", "INSERT INTO `jobs` (`queue`) VALUES (?)", "insert", "jobs"},
+		{"忽略重复写入", "INSERT IGNORE INTO `certs` (`key`) VALUES (?)", "insert", "certs"},
+		{"更新", `UPDATE "jobs" SET "status" = $1`, "update", "jobs"},
+		{"删除", "  DELETE FROM `notifications` WHERE `id` = ?", "delete", "notifications"},
+		{"没有表名", "SELECT 1", "select", ""},
+		{"其他语句", "CREATE TABLE `users` (`id` integer)", "other", ""},
+		{"事务语句", "BEGIN", "other", ""},
+		{"空语句", "  ", "other", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, table := Statement(tt.query)
+			if op != tt.op || table != tt.table {
+				t.Fatalf("Statement = %q %q，期望 %q %q", op, table, tt.op, tt.table)
+			}
+		})
+	}
+}
+
+func TestDriver(t *testing.T) {
+	ctx := context.Background()
+	drv, err := entsql.Open(dialect.SQLite, "file:TestMetricsDriver?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrapped := Driver("metrics_test", drv)
+	client := enttest.NewClient(t, enttest.WithOptions(ent.Driver(wrapped)))
+	t.Cleanup(func() { client.Close() })
+
+	series := []struct {
+		name  string
+		delta float64
+	}{
+		{`doghole_db_query_duration_seconds_count{db="metrics_test",operation="insert",table="jobs"}`, 1},
+		// 执行失败的语句同样记录耗时
+		{`doghole_db_query_duration_seconds_count{db="metrics_test",operation="update",table="jobs"}`, 2},
+		{`doghole_db_query_errors_total{db="metrics_test",operation="update",table="jobs"}`, 1},
+		// 校验失败的写入没有执行语句，不计入错误数
+		{`doghole_db_query_errors_total{db="metrics_test",operation="insert",table="jobs"}`, 0},
+	}
+	before := make([]float64, len(series))
+	for i, s := range series {
+		before[i] = sample(t, s.name)
+	}
+
+	client.Job.Create().SetType("test").SetPayload("{}").ExecX(ctx)
+	client.Job.Query().AllX(ctx)
+
+	// 事务中的语句同样记录
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Job.Update().SetLastError("").ExecX(ctx)
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 执行失败的语句计入错误数
+	if err := client.Job.Create().SetType("").Exec(ctx); err == nil {
+		t.Fatal("类型为空的任务应写入失败")
+	}
+	if err := wrapped.Exec(ctx, "UPDATE `jobs` SET `missing` = 1", []any{}, nil); err == nil {
+		t.Fatal("更新不存在的列应失败")
+	}
+
+	for i, s := range series {
+		if delta := sample(t, s.name) - before[i]; delta != s.delta {
+			t.Fatalf("%s 增加了 %v，期望 %v", s.name, delta, s.delta)
+		}
+	}
+	if !strings.Contains(scrape(t), `doghole_db_query_duration_seconds_count{db="metrics_test",operation="select",table="jobs"}`) {
+		t.Fatal("缺少查询语句的耗时")
+	}
+}
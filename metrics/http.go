@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// unmatchedRoute 没有匹配任何路由的请求使用的路由标签，避免原始路径导致标签基数膨胀
+const unmatchedRoute = "unmatched"
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP请求数，按方法、路由模板和状态码统计",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP请求处理耗时（秒），按方法、路由模板和状态码统计",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	httpInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "正在处理的HTTP请求数",
+	})
+)
+
+// _routes 每个应用已注册的路由，键为 "方法 路由模板"
+var _routes sync.Map // map[*fiber.App]map[string]bool
+
+// Route 返回请求匹配的路由模板（如 /api/v1/files/:id），没有匹配任何路由时返回空字符串
+// 需在 c.Next() 之后调用，路由在服务启动前全部注册完成，每个应用在首次调用时收集一次
+func Route(c fiber.Ctx) string {
+	routes, ok := _routes.Load(c.App())
+	if !ok {
+		registered := make(map[string]bool)
+		for _, r := range c.App().GetRoutes(true) {
+			registered[r.Method+" "+r.Path] = true
+		}
+		routes, _ = _routes.LoadOrStore(c.App(), registered)
+	}
+
+	route := c.Route().Path
+	if !routes.(map[string]bool)[c.Method()+" "+route] {
+		return ""
+	}
+	return route
+}
+
+// HTTPMiddleware 记录HTTP请求数、耗时和处理中的请求数，需在其他中间件之前挂载
+// 路由标签使用注册时的路由模板（如 /api/v1/files/:id），而不是请求的原始路径
+func HTTPMiddleware() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		httpInFlight.Inc()
+		start := time.Now()
+		err := c.Next()
+		elapsed := time.Since(start).Seconds()
+		httpInFlight.Dec()
+
+		// c.Method() 引用请求缓冲区，请求结束后会被复用，作为标签值保存前需复制
+		method := strings.Clone(c.Method())
+		route := Route(c)
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(ResponseStatus(c, err))
+
+		httpRequests.WithLabelValues(method, route, status).Inc()
+		httpDuration.WithLabelValues(method, route, status).Observe(elapsed)
+		return err
+	}
+}
+
+// ResponseStatus 返回响应状态码，处理函数返回错误时按错误处理器将写入的状态码统计
+func ResponseStatus(c fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+	var fe *fiber.Error
+	if errors.As(err, &fe) {
+		return fe.Code
+	}
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// scrape 通过指标处理函数读取 Prometheus 文本格式的全部指标
+func scrape(t *testing.T) string {
+	t.Helper()
+	app := fiber.New()
+	app.Get("/metrics", Handler())
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/metrics", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+// sample 返回指标序列的当前值，序列不存在时为0
+// 指标是全局的，测试比较执行前后的差值
+func sample(t *testing.T, series string) float64 {
+	t.Helper()
+	for _, line := range strings.Split(scrape(t), "\n") {
+		if value, ok := strings.CutPrefix(line, series+" "); ok {
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return v
+		}
+	}
+	return 0
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(HTTPMiddleware())
+	api := app.Group("/http-test")
+	api.Get("/files/:id", func(c fiber.Ctx) error { return c.SendString(c.Params("id")) })
+	api.Post("/files", func(c fiber.Ctx) error { return fiber.NewError(fiber.StatusConflict, "文件已存在") })
+	api.Delete("/files/:id", func(c fiber.Ctx) error { return io.ErrUnexpectedEOF })
+	api.Use("/static", func(c fiber.Ctx) error { return c.SendString("static") })
+
+	tests := []struct {
+		name   string
+		series string
+		delta  float64
+	}{
+		// 路由标签使用路由模板，不同ID的请求计入同一序列
+		{"路由模板", `doghole_http_requests_total{method="GET",route="/http-test/files/:id",status="200"}`, 2},
+		{"返回fiber错误", `doghole_http_requests_total{method="POST",route="/http-test/files",status="409"}`, 1},
+		{"返回其他错误", `doghole_http_requests_total{method="DELETE",route="/http-test/files/:id",status="500"}`, 1},
+		// 中间件和未匹配的请求不使用原始路径，避免标签基数膨胀
+		{"中间件", `doghole_http_requests_total{method="GET",route="unmatched",status="200"}`, 1},
+		{"未匹配", `doghole_http_requests_total{method="GET",route="unmatched",status="404"}`, 1},
+		{"耗时", `doghole_http_request_duration_seconds_count{method="GET",route="/http-test/files/:id",status="200"}`, 2},
+		{"处理中的请求", `doghole_http_requests_in_flight`, 0},
+	}
+	before := make([]float64, len(tests))
+	for i, tt := range tests {
+		before[i] = sample(t, tt.series)
+	}
+
+	for _, req := range []struct{ method, path string }{
+		{fiber.MethodGet, "/http-test/files/1"},
+		{fiber.MethodGet, "/http-test/files/2"},
+		{fiber.MethodPost, "/http-test/files"},
+		{fiber.MethodDelete, "/http-test/files/3"},
+		{fiber.MethodGet, "/http-test/static/a.css"},
+		{fiber.MethodGet, "/http-test/unknown/4"},
+	} {
+		if _, err := app.Test(httptest.NewRequest(req.method, req.path, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if delta := sample(t, tt.series) - before[i]; delta != tt.delta {
+				t.Fatalf("%s 增加了 %v，期望 %v", tt.series, delta, tt.delta)
+			}
+		})
+	}
+	out := scrape(t)
+	if strings.Contains(out, "/http-test/files/1") || strings.Contains(out, "/http-test/unknown") {
+		t.Fatal("路由标签包含了原始路径")
+	}
+}
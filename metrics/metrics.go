@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace 所有指标名称的前缀
+const Namespace = "doghole"
+
+// _registry 独立的指标注册表，不使用 prometheus 的全局注册表，避免依赖库注册的指标混入
+var _registry = prometheus.NewRegistry()
+
+func init() {
+	_registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration, httpInFlight,
+		dbQueryDuration, dbQueryErrors,
+	)
+}
+
+// Registry 返回指标注册表
+func Registry() *prometheus.Registry {
+	return _registry
+}
+
+// Register 注册模块自己的指标，名称重复时返回错误
+func Register(collectors ...prometheus.Collector) error {
+	for _, c := range collectors {
+		if err := _registry.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MustRegister 注册模块自己的指标，名称重复时panic，适合在包初始化时调用
+func MustRegister(collectors ...prometheus.Collector) {
+	_registry.MustRegister(collectors...)
+}
+
+// Handler 返回以 Prometheus 文本格式输出所有指标的处理函数
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(_registry, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.ContinueOnError,
+	}))
+}
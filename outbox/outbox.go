@@ -0,0 +1,109 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"doghole/ent"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Message 待发布的领域事件
+type Message struct {
+	ID            int               `json:"id"`             // 发件箱记录ID，同一聚合内单调递增，可用于去重
+	AggregateType string            `json:"aggregate_type"` // 聚合类型
+	AggregateID   string            `json:"aggregate_id"`   // 聚合ID
+	EventType     string            `json:"event_type"`     // 事件类型
+	Payload       json.RawMessage   `json:"payload"`        // JSON格式的事件数据
+	Headers       map[string]string `json:"headers"`        // 事件元数据
+	CreatedAt     time.Time         `json:"created_at"`     // 事件写入时间
+}
+
+// Publisher 事件发布器
+// 中继保证至少一次发布，同一事件可能因重试或实例崩溃被重复发布，消费方应按 Message.ID 去重
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// PublisherFunc 将函数适配为 Publisher
+type PublisherFunc func(ctx context.Context, msg Message) error
+
+// Publish 实现 Publisher 接口
+func (f PublisherFunc) Publish(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
+
+// LogPublisher 仅记录日志的发布器，用于未配置消息系统时的调试
+func LogPublisher(logger *zap.Logger) Publisher {
+	return PublisherFunc(func(ctx context.Context, msg Message) error {
+		logger.Info("发件箱事件",
+			zap.Int("id", msg.ID),
+			zap.String("aggregate_type", msg.AggregateType),
+			zap.String("aggregate_id", msg.AggregateID),
+			zap.String("event_type", msg.EventType),
+			zap.ByteString("payload", msg.Payload),
+		)
+		return nil
+	})
+}
+
+// addOptions 写入选项
+type addOptions struct {
+	headers map[string]string
+}
+
+// AddOption 写入选项函数
+type AddOption func(*addOptions)
+
+// WithHeader 为事件添加元数据
+func WithHeader(key, value string) AddOption {
+	return func(o *addOptions) {
+		if o.headers == nil {
+			o.headers = make(map[string]string)
+		}
+		o.headers[key] = value
+	}
+}
+
+// Add 在事务中写入领域事件，事件仅在事务提交后才会被中继发布
+//
+//	err := conn.WithTx(ctx, func(tx *ent.Tx) error {
+//		u, err := tx.User.Create().Save(ctx)
+//		...
+//		_, err = outbox.Add(ctx, tx, "User", strconv.Itoa(u.ID), "user.registered", u)
+//		return err
+//	})
+func Add(ctx context.Context, tx *ent.Tx, aggregateType, aggregateID, eventType string, payload any, opts ...AddOption) (*ent.Outbox, error) {
+	return AddWith(ctx, tx.Outbox, aggregateType, aggregateID, eventType, payload, opts...)
+}
+
+// AddWith 使用指定的发件箱客户端写入领域事件
+// 不在事务中调用时无法保证事件与业务数据的一致性，一般应使用 Add
+func AddWith(ctx context.Context, outboxes *ent.OutboxClient, aggregateType, aggregateID, eventType string, payload any, opts ...AddOption) (*ent.Outbox, error) {
+	var o addOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "序列化事件数据失败")
+	}
+
+	create := outboxes.Create().
+		SetAggregateType(aggregateType).
+		SetAggregateID(aggregateID).
+		SetEventType(eventType).
+		SetPayload(string(data))
+	if len(o.headers) > 0 {
+		create.SetHeaders(o.headers)
+	}
+
+	row, err := create.Save(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "写入发件箱失败")
+	}
+	return row, nil
+}
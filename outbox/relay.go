@@ -0,0 +1,257 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"doghole/config"
+	"doghole/ent"
+	"doghole/ent/outbox"
+	"doghole/ent/predicate"
+	"entgo.io/ent/dialect/sql"
+	"go.uber.org/zap"
+)
+
+// cleanupInterval 清理已发布事件的间隔
+const cleanupInterval = time.Minute
+
+// Relay 发件箱中继，轮询未发布的事件并交给 Publisher 发布
+// 同一聚合的事件按写入顺序逐个发布，前一个事件发布成功前不会发布后续事件
+type Relay struct {
+	client    *ent.Client
+	config    config.OutboxConfig
+	publisher Publisher
+	logger    *zap.Logger
+}
+
+// NewRelay 创建发件箱中继
+func NewRelay(client *ent.Client, conf config.OutboxConfig, publisher Publisher, options ...func(*Relay)) *Relay {
+	if conf.PollInterval <= 0 {
+		conf.PollInterval = time.Second
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = 100
+	}
+	if conf.PublishTimeout <= 0 {
+		conf.PublishTimeout = 30 * time.Second
+	}
+	if conf.BackoffBase <= 0 {
+		conf.BackoffBase = time.Second
+	}
+	if conf.BackoffMax <= 0 {
+		conf.BackoffMax = 5 * time.Minute
+	}
+
+	r := &Relay{
+		client:    client,
+		config:    conf,
+		publisher: publisher,
+		logger:    zap.L(),
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
+}
+
+// WithLogger 设置日志记录器
+func WithLogger(logger *zap.Logger) func(*Relay) {
+	return func(r *Relay) {
+		r.logger = logger
+	}
+}
+
+// Run 运行中继，直到ctx被取消
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	var lastCleanup time.Time
+	for {
+		// 一批处理满且有进展时立即处理下一批，避免积压
+		for r.relayBatch(ctx) && ctx.Err() == nil {
+		}
+
+		if r.config.Retention > 0 && time.Since(lastCleanup) >= cleanupInterval {
+			r.cleanup(ctx)
+			lastCleanup = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayBatch 发布一批未发布的事件，返回是否可能还有可立即发布的事件
+// 只查询已到重试时间且前面没有处于退避中的同聚合事件的行，退避中的聚合不会占满批次导致其他聚合停滞
+func (r *Relay) relayBatch(ctx context.Context) bool {
+	now := time.Now()
+	rows, err := r.client.Outbox.Query().
+		Where(
+			outbox.DeliveredAtIsNil(),
+			outbox.NextAttemptAtLTE(now),
+			noEarlierPending(now),
+		).
+		Order(ent.Asc(outbox.FieldID)).
+		Limit(r.config.BatchSize).
+		All(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.Error("查询发件箱失败", zap.Error(err))
+		}
+		return false
+	}
+
+	// 聚合中存在正在被其他实例发布或本轮发布失败的事件时，跳过该聚合的后续事件
+	blocked := make(map[string]struct{})
+	published := 0
+
+	for _, row := range rows {
+		if ctx.Err() != nil {
+			break
+		}
+
+		key := row.AggregateType + "\x00" + row.AggregateID
+		if _, ok := blocked[key]; ok {
+			continue
+		}
+		if !r.claim(ctx, row) {
+			blocked[key] = struct{}{}
+			continue
+		}
+		if !r.publish(ctx, row) {
+			blocked[key] = struct{}{}
+			continue
+		}
+		published++
+	}
+
+	return len(rows) == r.config.BatchSize && published > 0
+}
+
+// noEarlierPending 排除同一聚合中存在更早的、尚未到重试时间的未发布事件的行，保证聚合内按顺序发布
+func noEarlierPending(now time.Time) predicate.Outbox {
+	return func(s *sql.Selector) {
+		earlier := sql.Table(outbox.Table).As("earlier")
+		s.Where(sql.NotExists(
+			sql.Select(earlier.C(outbox.FieldID)).
+				From(earlier).
+				Where(sql.And(
+					sql.ColumnsEQ(earlier.C(outbox.FieldAggregateType), s.C(outbox.FieldAggregateType)),
+					sql.ColumnsEQ(earlier.C(outbox.FieldAggregateID), s.C(outbox.FieldAggregateID)),
+					sql.ColumnsLT(earlier.C(outbox.FieldID), s.C(outbox.FieldID)),
+					sql.IsNull(earlier.C(outbox.FieldDeliveredAt)),
+					sql.GT(earlier.C(outbox.FieldNextAttemptAt), now),
+				)),
+		))
+	}
+}
+
+// claim 通过条件更新占用事件，避免多个实例同时发布
+// 占用期间将下一次尝试时间推迟一个发布超时，实例崩溃后事件会被重新发布
+func (r *Relay) claim(ctx context.Context, row *ent.Outbox) bool {
+	n, err := r.client.Outbox.Update().
+		Where(
+			outbox.ID(row.ID),
+			outbox.DeliveredAtIsNil(),
+			outbox.NextAttemptAt(row.NextAttemptAt),
+		).
+		SetNextAttemptAt(time.Now().Add(r.config.PublishTimeout)).
+		Save(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.Error("占用发件箱事件失败", zap.Int("id", row.ID), zap.Error(err))
+		}
+		return false
+	}
+	return n == 1
+}
+
+// publish 发布单个事件并记录结果，返回是否发布成功
+func (r *Relay) publish(ctx context.Context, row *ent.Outbox) bool {
+	publishCtx, cancel := context.WithTimeout(ctx, r.config.PublishTimeout)
+	defer cancel()
+
+	err := r.publisher.Publish(publishCtx, Message{
+		ID:            row.ID,
+		AggregateType: row.AggregateType,
+		AggregateID:   row.AggregateID,
+		EventType:     row.EventType,
+		Payload:       json.RawMessage(row.Payload),
+		Headers:       row.Headers,
+		CreatedAt:     row.CreatedAt,
+	})
+
+	// 结果写回使用独立的context，避免因退出导致已发布的事件被重复发布
+	saveCtx, saveCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer saveCancel()
+
+	attempts := row.Attempts + 1
+	logger := r.logger.With(
+		zap.Int("id", row.ID),
+		zap.String("event_type", row.EventType),
+		zap.String("aggregate_id", row.AggregateID),
+		zap.Int("attempt", attempts),
+	)
+
+	if err != nil {
+		logger.Warn("发布发件箱事件失败，等待重试", zap.Error(err))
+		if err := r.client.Outbox.UpdateOneID(row.ID).
+			SetAttempts(attempts).
+			SetLastError(err.Error()).
+			SetNextAttemptAt(time.Now().Add(r.backoff(attempts))).
+			Exec(saveCtx); err != nil {
+			logger.Error("更新发件箱事件失败", zap.Error(err))
+		}
+		return false
+	}
+
+	if r.config.Retention <= 0 {
+		err = r.client.Outbox.DeleteOneID(row.ID).Exec(saveCtx)
+	} else {
+		err = r.client.Outbox.UpdateOneID(row.ID).
+			SetAttempts(attempts).
+			SetLastError("").
+			SetDeliveredAt(time.Now()).
+			Exec(saveCtx)
+	}
+	if err != nil {
+		// 状态未能保存时事件会在租约到期后被重新发布，后续事件需等待以保证顺序
+		logger.Error("记录发件箱事件发布结果失败", zap.Error(err))
+		return false
+	}
+
+	logger.Debug("发件箱事件已发布")
+	return true
+}
+
+// cleanup 删除超过保留时长的已发布事件
+func (r *Relay) cleanup(ctx context.Context) {
+	n, err := r.client.Outbox.Delete().
+		Where(outbox.DeliveredAtLT(time.Now().Add(-r.config.Retention))).
+		Exec(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.Error("清理已发布的发件箱事件失败", zap.Error(err))
+		}
+		return
+	}
+	if n > 0 {
+		r.logger.Debug("已清理发布的发件箱事件", zap.Int("count", n))
+	}
+}
+
+// backoff 计算第attempts次失败后的重试间隔
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.config.BackoffBase
+	for i := 1; i < attempts && delay < r.config.BackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, r.config.BackoffMax)
+}
@@ -0,0 +1,245 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"doghole/config"
+	"doghole/ent"
+	"doghole/ent/enttest"
+	"doghole/ent/outbox"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+// openClient 打开测试用的内存数据库
+func openClient(t *testing.T) *ent.Client {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", strings.ReplaceAll(t.Name(), "/", "_")))
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// recorder 记录发布顺序的发布器，fail 中的事件类型发布失败
+type recorder struct {
+	mu        sync.Mutex
+	published []string
+	fail      map[string]bool
+}
+
+func (r *recorder) Publish(ctx context.Context, msg Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail[msg.EventType] {
+		return errors.New("消息系统不可用")
+	}
+	r.published = append(r.published, msg.AggregateID+":"+msg.EventType)
+	return nil
+}
+
+// take 返回并清空已发布的事件
+func (r *recorder) take() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := strings.Join(r.published, ",")
+	r.published = nil
+	return s
+}
+
+// add 写入事件，失败时终止测试
+func add(t *testing.T, client *ent.Client, aggregateID, eventType string) *ent.Outbox {
+	t.Helper()
+	row, err := AddWith(context.Background(), client.Outbox, "User", aggregateID, eventType, map[string]string{"id": aggregateID}, WithHeader("request_id", "req-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return row
+}
+
+// due 将事件的下一次尝试时间提前，模拟退避或租约到期
+func due(t *testing.T, client *ent.Client, row *ent.Outbox) {
+	t.Helper()
+	client.Outbox.UpdateOneID(row.ID).SetNextAttemptAt(time.Now().Add(-time.Second)).ExecX(context.Background())
+}
+
+func TestRelayOrder(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+	pub := &recorder{fail: map[string]bool{"u1.2": true}}
+	r := NewRelay(client, config.OutboxConfig{Retention: time.Hour, BackoffBase: time.Minute}, pub, WithLogger(zap.NewNop()))
+
+	add(t, client, "1", "u1.1")
+	failed := add(t, client, "1", "u1.2")
+	add(t, client, "2", "u2.1")
+	add(t, client, "1", "u1.3")
+	add(t, client, "2", "u2.2")
+
+	steps := []struct {
+		name      string
+		prepare   func()
+		published string
+	}{
+		// 聚合1的第2个事件发布失败，后续事件等待，不影响聚合2
+		{"首次发布", nil, "1:u1.1,2:u2.1,2:u2.2"},
+		{"退避期间", nil, ""},
+		{"仍然失败", func() { due(t, client, failed) }, ""},
+		{"恢复后按顺序发布", func() {
+			pub.fail = nil
+			due(t, client, failed)
+		}, "1:u1.2,1:u1.3"},
+		{"全部已发布", nil, ""},
+	}
+	for _, s := range steps {
+		if s.prepare != nil {
+			s.prepare()
+		}
+		r.relayBatch(ctx)
+		if got := pub.take(); got != s.published {
+			t.Fatalf("%s: 发布了 %q，期望 %q", s.name, got, s.published)
+		}
+	}
+
+	row := client.Outbox.GetX(ctx, failed.ID)
+	if row.DeliveredAt == nil || row.Attempts != 3 || row.LastError != "" {
+		t.Fatalf("发布成功后的事件 = %+v", row)
+	}
+}
+
+func TestRelayBackoff(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+	pub := &recorder{fail: map[string]bool{"fail": true}}
+	r := NewRelay(client, config.OutboxConfig{BackoffBase: time.Minute, BackoffMax: 5 * time.Minute}, pub, WithLogger(zap.NewNop()))
+
+	row := add(t, client, "1", "fail")
+	for attempt, want := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute} {
+		due(t, client, row)
+		before := time.Now()
+		r.relayBatch(ctx)
+
+		got := client.Outbox.GetX(ctx, row.ID)
+		if got.Attempts != attempt+1 || got.LastError != "消息系统不可用" {
+			t.Fatalf("第%d次失败后 attempts = %d，last_error = %q", attempt+1, got.Attempts, got.LastError)
+		}
+		if delay := got.NextAttemptAt.Sub(before); delay < want-time.Second || delay > want+time.Second {
+			t.Fatalf("第%d次失败后重试间隔 = %s，期望 %s", attempt+1, delay, want)
+		}
+	}
+}
+
+func TestRelayBatch(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+	pub := &recorder{fail: map[string]bool{"u1.1": true}}
+	r := NewRelay(client, config.OutboxConfig{BatchSize: 2, BackoffBase: time.Minute}, pub, WithLogger(zap.NewNop()))
+
+	add(t, client, "1", "u1.1")
+	for i := 2; i <= 5; i++ {
+		add(t, client, "1", fmt.Sprintf("u1.%d", i))
+	}
+	add(t, client, "2", "u2.1")
+	add(t, client, "3", "u3.1")
+	add(t, client, "3", "u3.2")
+
+	// 第一批只取到聚合1的事件，发布失败后没有进展
+	if r.relayBatch(ctx) {
+		t.Fatal("没有发布任何事件时不应立即处理下一批")
+	}
+	// 退避中的聚合1的后续事件不占用批次
+	if !r.relayBatch(ctx) {
+		t.Fatal("批次已满且有进展时应立即处理下一批")
+	}
+	if got := pub.take(); got != "2:u2.1,3:u3.1" {
+		t.Fatalf("发布了 %q", got)
+	}
+	if r.relayBatch(ctx) {
+		t.Fatal("批次未满时不应立即处理下一批")
+	}
+	if got := pub.take(); got != "3:u3.2" {
+		t.Fatalf("发布了 %q", got)
+	}
+
+	// 未设置保留时长时发布成功的事件直接删除
+	if n := client.Outbox.Query().CountX(ctx); n != 5 {
+		t.Fatalf("剩余事件数量 = %d", n)
+	}
+}
+
+func TestRelayClaim(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+	pub := &recorder{}
+	r := NewRelay(client, config.OutboxConfig{PublishTimeout: time.Minute}, pub, WithLogger(zap.NewNop()))
+
+	row := add(t, client, "1", "u1.1")
+	add(t, client, "1", "u1.2")
+
+	// 其他实例已占用聚合1的第一个事件，本实例跳过整个聚合
+	other := NewRelay(client, config.OutboxConfig{PublishTimeout: time.Minute}, pub, WithLogger(zap.NewNop()))
+	if !other.claim(ctx, row) {
+		t.Fatal("占用事件失败")
+	}
+	if r.claim(ctx, row) {
+		t.Fatal("同一事件被重复占用")
+	}
+	r.relayBatch(ctx)
+	if got := pub.take(); got != "" {
+		t.Fatalf("发布了被占用的聚合的事件 %q", got)
+	}
+
+	// 占用的实例崩溃，租约到期后重新发布
+	due(t, client, row)
+	r.relayBatch(ctx)
+	if got := pub.take(); got != "1:u1.1,1:u1.2" {
+		t.Fatalf("发布了 %q", got)
+	}
+}
+
+func TestRelayMessage(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+
+	var got []Message
+	r := NewRelay(client, config.OutboxConfig{}, PublisherFunc(func(ctx context.Context, msg Message) error {
+		got = append(got, msg)
+		return nil
+	}), WithLogger(zap.NewNop()))
+
+	row := add(t, client, "7", "user.registered")
+	r.relayBatch(ctx)
+
+	if len(got) != 1 {
+		t.Fatalf("发布了 %d 个事件", len(got))
+	}
+	msg := got[0]
+	if msg.ID != row.ID || msg.AggregateType != "User" || msg.AggregateID != "7" || msg.EventType != "user.registered" {
+		t.Fatalf("事件 = %+v", msg)
+	}
+	if string(msg.Payload) != `{"id":"7"}` || msg.Headers["request_id"] != "req-1" {
+		t.Fatalf("事件数据 = %s %v", msg.Payload, msg.Headers)
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+	r := NewRelay(client, config.OutboxConfig{Retention: time.Hour}, &recorder{}, WithLogger(zap.NewNop()))
+
+	old := add(t, client, "1", "old")
+	client.Outbox.UpdateOneID(old.ID).SetDeliveredAt(time.Now().Add(-2 * time.Hour)).ExecX(ctx)
+	recent := add(t, client, "1", "recent")
+	client.Outbox.UpdateOneID(recent.ID).SetDeliveredAt(time.Now()).ExecX(ctx)
+	pending := add(t, client, "1", "pending")
+
+	// 只删除超过保留时长的已发布事件
+	r.cleanup(ctx)
+	ids := client.Outbox.Query().Order(ent.Asc(outbox.FieldID)).IDsX(ctx)
+	if len(ids) != 2 || ids[0] != recent.ID || ids[1] != pending.ID {
+		t.Fatalf("剩余事件 = %v", ids)
+	}
+}
@@ -0,0 +1,323 @@
+package prefork
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// 主进程传给子进程的环境变量
+const (
+	envChild     = "DOGHOLE_PREFORK_CHILD"     // 子进程编号，从0开始
+	envListeners = "DOGHOLE_PREFORK_LISTENERS" // 传入的监听数量
+)
+
+// listenFdsStart 传入的第一个监听的文件描述符，0-2 为标准输入输出
+const listenFdsStart = 3
+
+// IsChild 判断当前进程是否为预分叉的子进程
+func IsChild() bool {
+	return os.Getenv(envChild) != ""
+}
+
+// ChildID 返回子进程编号，从0开始，不是子进程时返回-1
+func ChildID() int {
+	id, err := strconv.Atoi(os.Getenv(envChild))
+	if err != nil {
+		return -1
+	}
+	return id
+}
+
+// Listeners 返回主进程传给子进程的监听，每个子进程共享相同的套接字，由内核在子进程之间分配连接
+func Listeners() ([]net.Listener, error) {
+	if !IsChild() {
+		return nil, errors.New("不是预分叉的子进程")
+	}
+	count, err := strconv.Atoi(os.Getenv(envListeners))
+	if err != nil || count <= 0 {
+		return nil, errors.Errorf("主进程未传入监听: %s=%s", envListeners, os.Getenv(envListeners))
+	}
+
+	listeners := make([]net.Listener, 0, count)
+	for i := range count {
+		f := os.NewFile(uintptr(listenFdsStart+i), fmt.Sprintf("prefork-%d", i))
+		ln, err := net.FileListener(f)
+		// FileListener 复制了文件描述符，原文件可以关闭
+		f.Close()
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return nil, errors.Wrapf(err, "使用主进程传入的监听失败: fd=%d", listenFdsStart+i)
+		}
+		listeners = append(listeners, ln)
+	}
+	return listeners, nil
+}
+
+// WatchMaster 在子进程中运行，主进程退出（如被强制结束）后返回错误，避免子进程成为孤儿进程继续处理请求
+func WatchMaster(ctx context.Context) error {
+	ppid := os.Getppid()
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if os.Getppid() != ppid {
+				return errors.Errorf("预分叉主进程已退出: pid=%d", ppid)
+			}
+		}
+	}
+}
+
+// Master 预分叉主进程，负责启动子进程、转发子进程的日志和协调退出
+type Master struct {
+	listeners   []net.Listener
+	children    int
+	output      io.Writer
+	stopTimeout time.Duration
+	logger      *zap.Logger
+
+	outputMu sync.Mutex
+}
+
+// Option 主进程选项函数
+type Option func(*Master)
+
+// WithChildren 设置子进程数，0 表示使用CPU核数
+func WithChildren(n int) Option {
+	return func(m *Master) {
+		if n > 0 {
+			m.children = n
+		}
+	}
+}
+
+// WithOutput 设置子进程日志的输出，通常为主进程日志的输出（如带轮转的日志文件）
+func WithOutput(w io.Writer) Option {
+	return func(m *Master) {
+		m.output = w
+	}
+}
+
+// WithStopTimeout 设置退出时等待子进程优雅退出的时间，超时后强制结束
+func WithStopTimeout(timeout time.Duration) Option {
+	return func(m *Master) {
+		m.stopTimeout = timeout
+	}
+}
+
+// WithLogger 设置日志记录器
+func WithLogger(logger *zap.Logger) Option {
+	return func(m *Master) {
+		m.logger = logger
+	}
+}
+
+// NewMaster 创建预分叉主进程，listeners 由主进程创建并传给所有子进程
+func NewMaster(listeners []net.Listener, opts ...Option) *Master {
+	m := &Master{
+		listeners:   listeners,
+		children:    runtime.NumCPU(),
+		output:      os.Stdout,
+		stopTimeout: 30 * time.Second,
+		logger:      zap.L(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// child 运行中的子进程
+type child struct {
+	id  int
+	cmd *exec.Cmd
+}
+
+// exit 子进程的退出结果
+type exit struct {
+	id  int
+	err error
+}
+
+// Run 以相同的命令行参数启动子进程并等待，阻塞直到 ctx 取消或任一子进程退出
+// ctx 取消时向所有子进程发送 SIGTERM，等待其优雅退出，超时后强制结束；
+// 任一子进程意外退出时以同样的方式停止其余子进程并返回错误，由进程管理器重启整个服务
+// 返回前关闭所有监听
+func (m *Master) Run(ctx context.Context) error {
+	defer func() {
+		for _, ln := range m.listeners {
+			ln.Close()
+		}
+	}()
+
+	files, err := listenerFiles(m.listeners)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+
+	executable, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "获取可执行文件路径失败")
+	}
+
+	exits := make(chan exit, m.children)
+	var children []child
+	for id := range m.children {
+		c, err := m.start(executable, id, files, exits)
+		if err != nil {
+			m.terminate(children, exits)
+			return err
+		}
+		children = append(children, c)
+	}
+	m.logger.Info("预分叉子进程已启动", zap.Int("children", len(children)), zap.Int("listeners", len(files)))
+
+	select {
+	case <-ctx.Done():
+		m.terminate(children, exits)
+		return nil
+	case e := <-exits:
+		m.logger.Error("预分叉子进程意外退出，停止其余子进程", zap.Int("child", e.id), zap.Error(e.err))
+		m.terminate(without(children, e.id), exits)
+		if e.err == nil {
+			return errors.Errorf("预分叉子进程 %d 意外退出", e.id)
+		}
+		return errors.Wrapf(e.err, "预分叉子进程 %d 意外退出", e.id)
+	}
+}
+
+// start 启动一个子进程，子进程的标准输出按行转发到日志输出，退出结果写入 exits
+func (m *Master) start(executable string, id int, files []*os.File, exits chan<- exit) (child, error) {
+	cmd := exec.Command(executable, os.Args[1:]...)
+	cmd.Env = append(os.Environ(),
+		envChild+"="+strconv.Itoa(id),
+		envListeners+"="+strconv.Itoa(len(files)),
+	)
+	cmd.ExtraFiles = files
+	cmd.Stderr = os.Stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return child{}, errors.Wrap(err, "创建子进程输出管道失败")
+	}
+	if err := cmd.Start(); err != nil {
+		return child{}, errors.Wrapf(err, "启动预分叉子进程 %d 失败", id)
+	}
+
+	go func() {
+		// 必须读完输出后再等待进程退出
+		m.relay(stdout)
+		exits <- exit{id: id, err: cmd.Wait()}
+	}()
+	return child{id: id, cmd: cmd}, nil
+}
+
+// relay 按行转发子进程的输出，每个子进程使用独立的管道，整行写入，多个子进程的日志不会交错
+func (m *Master) relay(r io.Reader) {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			m.outputMu.Lock()
+			_, _ = m.output.Write(line)
+			m.outputMu.Unlock()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// terminate 向子进程发送 SIGTERM 并等待其退出，超过 stopTimeout 后强制结束
+func (m *Master) terminate(children []child, exits <-chan exit) {
+	if len(children) == 0 {
+		return
+	}
+	for _, c := range children {
+		if err := c.cmd.Process.Signal(syscall.SIGTERM); err != nil {
+			m.logger.Warn("通知预分叉子进程退出失败", zap.Int("child", c.id), zap.Error(err))
+		}
+	}
+
+	timer := time.NewTimer(m.stopTimeout)
+	defer timer.Stop()
+
+	running := make(map[int]child, len(children))
+	for _, c := range children {
+		running[c.id] = c
+	}
+	for len(running) > 0 {
+		select {
+		case e := <-exits:
+			if e.err != nil {
+				m.logger.Warn("预分叉子进程退出异常", zap.Int("child", e.id), zap.Error(e.err))
+			}
+			delete(running, e.id)
+		case <-timer.C:
+			for _, c := range running {
+				m.logger.Error("预分叉子进程未在超时时间内退出，强制结束", zap.Int("child", c.id))
+				_ = c.cmd.Process.Kill()
+			}
+			for len(running) > 0 {
+				delete(running, (<-exits).id)
+			}
+		}
+	}
+}
+
+// listenerFiles 复制监听的文件描述符，用于传给子进程
+func listenerFiles(listeners []net.Listener) ([]*os.File, error) {
+	files := make([]*os.File, 0, len(listeners))
+	for _, ln := range listeners {
+		fl, ok := ln.(interface{ File() (*os.File, error) })
+		if !ok {
+			err := errors.Errorf("监听不支持传给子进程: %s", ln.Addr())
+			for _, f := range files {
+				f.Close()
+			}
+			return nil, err
+		}
+		f, err := fl.File()
+		if err != nil {
+			for _, f := range files {
+				f.Close()
+			}
+			return nil, errors.Wrapf(err, "获取监听的文件描述符失败: %s", ln.Addr())
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// without 返回去掉指定编号后的子进程列表
+func without(children []child, id int) []child {
+	rest := make([]child, 0, len(children))
+	for _, c := range children {
+		if c.id != id {
+			rest = append(rest, c)
+		}
+	}
+	return rest
+}
@@ -0,0 +1,52 @@
+package querycache
+
+import (
+	"context"
+	"time"
+
+	"doghole/ent"
+	"go.uber.org/zap"
+)
+
+// Hook 返回缓存失效钩子，挂载到写连接上
+// 变更成功后递增实体类型的缓存版本号；在事务中的变更在事务提交后失效
+func (c *Cache) Hook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			value, err := next.Mutate(ctx, m)
+			if err != nil {
+				return value, err
+			}
+
+			entity := m.Type()
+			if txm, ok := m.(interface{ Tx() (*ent.Tx, error) }); ok {
+				if tx, err := txm.Tx(); err == nil {
+					tx.OnCommit(func(next ent.Committer) ent.Committer {
+						return ent.CommitFunc(func(ctx context.Context, tx *ent.Tx) error {
+							if err := next.Commit(ctx, tx); err != nil {
+								return err
+							}
+							c.invalidate(ctx, entity)
+							return nil
+						})
+					})
+					return value, nil
+				}
+			}
+
+			c.invalidate(ctx, entity)
+			return value, nil
+		})
+	}
+}
+
+// invalidate 使实体类型的缓存失效
+// 失效失败时缓存最长在TTL后过期，不影响变更本身
+func (c *Cache) invalidate(ctx context.Context, entity string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	if err := c.backend.Bump(ctx, entity); err != nil {
+		c.logger.Error("查询缓存失效失败", zap.String("type", entity), zap.Error(err))
+	}
+}
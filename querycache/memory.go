@@ -0,0 +1,115 @@
+package querycache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryEntry LRU缓存项
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryBackend 进程内LRU缓存，超过容量时淘汰最久未使用的缓存项
+// 版本号只在本进程内有效，多实例部署时其他实例的写入不会使本实例的缓存失效，只能等待TTL过期
+type MemoryBackend struct {
+	mu          sync.Mutex
+	maxEntries  int
+	ll          *list.List
+	items       map[string]*list.Element
+	generations map[string]uint64
+}
+
+// NewMemoryBackend 创建进程内LRU缓存，maxEntries 为最大缓存项数
+func NewMemoryBackend(maxEntries int) *MemoryBackend {
+	if maxEntries <= 0 {
+		maxEntries = 10000
+	}
+	return &MemoryBackend{
+		maxEntries:  maxEntries,
+		ll:          list.New(),
+		items:       make(map[string]*list.Element),
+		generations: make(map[string]uint64),
+	}
+}
+
+// Get 实现 Backend 接口
+func (b *MemoryBackend) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	el, ok := b.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := el.Value.(*memoryEntry)
+	if time.Now().After(entry.expiresAt) {
+		b.removeElement(el)
+		return nil, false, nil
+	}
+
+	b.ll.MoveToFront(el)
+	return entry.value, true, nil
+}
+
+// Set 实现 Backend 接口
+func (b *MemoryBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if el, ok := b.items[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		b.ll.MoveToFront(el)
+		return nil
+	}
+
+	b.items[key] = b.ll.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	for b.ll.Len() > b.maxEntries {
+		b.removeElement(b.ll.Back())
+	}
+	return nil
+}
+
+// Generations 实现 Backend 接口
+func (b *MemoryBackend) Generations(ctx context.Context, entities ...string) ([]uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	gens := make([]uint64, len(entities))
+	for i, entity := range entities {
+		gens[i] = b.generations[entity]
+	}
+	return gens, nil
+}
+
+// Bump 实现 Backend 接口，旧版本的缓存项不再被访问，由LRU淘汰
+func (b *MemoryBackend) Bump(ctx context.Context, entities ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, entity := range entities {
+		b.generations[entity]++
+	}
+	return nil
+}
+
+// Len 返回当前缓存项数
+func (b *MemoryBackend) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.ll.Len()
+}
+
+// removeElement 删除缓存项，调用方需持有锁
+func (b *MemoryBackend) removeElement(el *list.Element) {
+	b.ll.Remove(el)
+	delete(b.items, el.Value.(*memoryEntry).key)
+}
@@ -0,0 +1,234 @@
+package querycache
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"doghole/ent"
+	entgo "entgo.io/ent"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// Backend 缓存存储后端
+type Backend interface {
+	// Get 读取缓存，不存在或已过期时返回 false
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set 写入缓存
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Generations 读取实体类型的缓存版本号，版本号是缓存键的一部分
+	Generations(ctx context.Context, entities ...string) ([]uint64, error)
+	// Bump 递增实体类型的缓存版本号，使该类型的所有缓存失效
+	Bump(ctx context.Context, entities ...string) error
+}
+
+// cacheableOps 可缓存的查询操作，Select 和 GroupBy 通过扫描写入调用方的变量，不做缓存
+var cacheableOps = map[string]bool{
+	entgo.OpQueryAll:     true,
+	entgo.OpQueryFirst:   true,
+	entgo.OpQueryOnly:    true,
+	entgo.OpQueryIDs:     true,
+	entgo.OpQueryFirstID: true,
+	entgo.OpQueryOnlyID:  true,
+	entgo.OpQueryCount:   true,
+	entgo.OpQueryExist:   true,
+}
+
+// keyOptions 查询缓存选项
+type keyOptions struct {
+	key       string
+	dependsOn []string
+	ttl       time.Duration
+}
+
+// keyCtxKey context中保存 keyOptions 的键
+type keyCtxKey struct{}
+
+// KeyOption 查询缓存选项函数
+type KeyOption func(*keyOptions)
+
+// DependsOn 声明查询结果还依赖的其他实体类型，如通过 WithXxx 预加载的关联实体
+// 这些实体变更时缓存同样失效
+func DependsOn(entities ...string) KeyOption {
+	return func(o *keyOptions) {
+		o.dependsOn = append(o.dependsOn, entities...)
+	}
+}
+
+// TTL 覆盖默认的缓存时长
+func TTL(ttl time.Duration) KeyOption {
+	return func(o *keyOptions) {
+		o.ttl = ttl
+	}
+}
+
+// WithKey 为ctx中执行的查询开启缓存，key 需唯一标识查询条件
+// 拦截器无法读取查询的过滤条件，相同实体类型和操作下 key 相同的查询会共用缓存结果
+//
+//	u, err := conn.Reader().User.Get(querycache.WithKey(ctx, "id:"+id), id)
+//
+// 命中缓存时返回的实体与数据库连接分离，不能再通过实体执行 QueryXxx、Update 等操作
+func WithKey(ctx context.Context, key string, opts ...KeyOption) context.Context {
+	o := &keyOptions{key: key}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return context.WithValue(ctx, keyCtxKey{}, o)
+}
+
+// Cache 查询缓存，通过读连接上的拦截器读取，通过写连接上的钩子失效
+type Cache struct {
+	backend Backend
+	ttl     time.Duration
+	group   singleflight.Group
+	types   sync.Map // 实体类型和操作到结果类型的映射，命中缓存时用于解码
+	logger  *zap.Logger
+}
+
+// New 创建查询缓存
+func New(backend Backend, options ...func(*Cache)) *Cache {
+	c := &Cache{
+		backend: backend,
+		ttl:     time.Minute,
+		logger:  zap.L(),
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// WithTTL 设置默认缓存时长
+func WithTTL(ttl time.Duration) func(*Cache) {
+	return func(c *Cache) {
+		if ttl > 0 {
+			c.ttl = ttl
+		}
+	}
+}
+
+// WithLogger 设置日志记录器
+func WithLogger(logger *zap.Logger) func(*Cache) {
+	return func(c *Cache) {
+		c.logger = logger
+	}
+}
+
+// Interceptor 返回查询拦截器，挂载到读连接上
+// 只缓存通过 WithKey 开启缓存的查询；同一缓存键的并发未命中只会执行一次数据库查询
+func (c *Cache) Interceptor() ent.Interceptor {
+	return ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, q ent.Query) (ent.Value, error) {
+			opts, _ := ctx.Value(keyCtxKey{}).(*keyOptions)
+			qc := entgo.QueryFromContext(ctx)
+			if opts == nil || qc == nil || !cacheableOps[qc.Op] {
+				return next.Query(ctx, q)
+			}
+
+			entities := append([]string{qc.Type}, opts.dependsOn...)
+			gens, err := c.backend.Generations(ctx, entities...)
+			if err != nil {
+				c.logger.Warn("读取查询缓存版本失败", zap.String("type", qc.Type), zap.Error(err))
+				return next.Query(ctx, q)
+			}
+
+			key := cacheKey(qc, opts.key, gens)
+			typeKey := qc.Type + ":" + qc.Op
+
+			// 首次执行前不知道结果类型，只能先查询数据库
+			if typ, ok := c.types.Load(typeKey); ok {
+				if data, ok, err := c.backend.Get(ctx, key); err != nil {
+					c.logger.Warn("读取查询缓存失败", zap.String("key", key), zap.Error(err))
+				} else if ok {
+					if v, err := decode(data, typ.(reflect.Type)); err == nil {
+						return v, nil
+					}
+				}
+			}
+
+			ttl := c.ttl
+			if opts.ttl > 0 {
+				ttl = opts.ttl
+			}
+
+			// 合并并发未命中，每个调用方各自解码，避免共享同一个实体
+			data, err, _ := c.group.Do(key, func() (any, error) {
+				v, err := next.Query(ctx, q)
+				if err != nil {
+					return nil, err
+				}
+				data, err := encode(v)
+				if err != nil {
+					return nil, err
+				}
+				c.types.Store(typeKey, reflect.TypeOf(v))
+				if err := c.backend.Set(ctx, key, data, ttl); err != nil {
+					c.logger.Warn("写入查询缓存失败", zap.String("key", key), zap.Error(err))
+				}
+				return data, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			typ, _ := c.types.Load(typeKey)
+			return decode(data.([]byte), typ.(reflect.Type))
+		})
+	})
+}
+
+// cacheKey 生成缓存键，包含实体类型、操作、分页参数和各依赖实体的版本号
+func cacheKey(qc *entgo.QueryContext, key string, gens []uint64) string {
+	var b strings.Builder
+	b.WriteString(qc.Type)
+	b.WriteByte(':')
+	b.WriteString(qc.Op)
+	for _, g := range gens {
+		b.WriteString(":v")
+		b.WriteString(strconv.FormatUint(g, 10))
+	}
+	if qc.Limit != nil {
+		fmt.Fprintf(&b, ":l%d", *qc.Limit)
+	}
+	if qc.Offset != nil {
+		fmt.Fprintf(&b, ":o%d", *qc.Offset)
+	}
+	if qc.Unique != nil {
+		fmt.Fprintf(&b, ":u%t", *qc.Unique)
+	}
+	if len(qc.Fields) > 0 {
+		b.WriteString(":f")
+		b.WriteString(strings.Join(qc.Fields, ","))
+	}
+	b.WriteByte(':')
+	b.WriteString(key)
+	return b.String()
+}
+
+// encode 使用gob序列化查询结果，敏感字段同样会被保存
+func encode(v ent.Value) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).EncodeValue(reflect.ValueOf(v)); err != nil {
+		return nil, errors.Wrap(err, "序列化查询结果失败")
+	}
+	return buf.Bytes(), nil
+}
+
+// decode 将缓存数据反序列化为指定类型
+func decode(data []byte, typ reflect.Type) (ent.Value, error) {
+	ptr := reflect.New(typ)
+	if err := gob.NewDecoder(bytes.NewReader(data)).DecodeValue(ptr); err != nil {
+		return nil, errors.Wrap(err, "反序列化查询结果失败")
+	}
+	return ptr.Elem().Interface(), nil
+}
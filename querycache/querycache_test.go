@@ -0,0 +1,202 @@
+package querycache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"doghole/ent"
+	"doghole/ent/enttest"
+	"doghole/ent/featureflag"
+	"github.com/alicebob/miniredis/v2"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/redis/go-redis/v9"
+)
+
+// newRedisBackend 使用进程内的 miniredis 创建Redis缓存
+func newRedisBackend(t *testing.T) (*RedisBackend, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisBackend(client, "test:"), mr
+}
+
+// openClients 打开同一个内存数据库的两个连接：cached 挂载查询缓存，raw 绕过缓存直接写入
+func openClients(t *testing.T, cache *Cache) (cached, raw *ent.Client) {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", strings.ReplaceAll(t.Name(), "/", "_"))
+	cached = enttest.Open(t, "sqlite3", dsn)
+	raw = enttest.Open(t, "sqlite3", dsn)
+	t.Cleanup(func() {
+		cached.Close()
+		raw.Close()
+	})
+
+	cached.Intercept(cache.Interceptor())
+	cached.Use(cache.Hook())
+	return cached, raw
+}
+
+func TestRedisBackendGetSet(t *testing.T) {
+	ctx := context.Background()
+	b, mr := newRedisBackend(t)
+
+	if _, ok, err := b.Get(ctx, "k"); err != nil || ok {
+		t.Fatalf("Get 不存在的键: ok=%v err=%v", ok, err)
+	}
+
+	if err := b.Set(ctx, "k", []byte("v"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if !mr.Exists("test:q:k") {
+		t.Fatal("缓存键未带前缀写入Redis")
+	}
+	data, ok, err := b.Get(ctx, "k")
+	if err != nil || !ok || string(data) != "v" {
+		t.Fatalf("Get = %q, %v, %v", data, ok, err)
+	}
+
+	mr.FastForward(2 * time.Minute)
+	if _, ok, err := b.Get(ctx, "k"); err != nil || ok {
+		t.Fatalf("缓存项应在TTL后过期: ok=%v err=%v", ok, err)
+	}
+}
+
+func TestRedisBackendGenerations(t *testing.T) {
+	ctx := context.Background()
+	b, mr := newRedisBackend(t)
+
+	gens, err := b.Generations(ctx, "User", "Job")
+	if err != nil || len(gens) != 2 || gens[0] != 0 || gens[1] != 0 {
+		t.Fatalf("初始版本号 = %v, %v", gens, err)
+	}
+
+	if err := b.Bump(ctx, "User"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Bump(ctx, "User", "Job"); err != nil {
+		t.Fatal(err)
+	}
+	gens, err = b.Generations(ctx, "User", "Job")
+	if err != nil || gens[0] != 2 || gens[1] != 1 {
+		t.Fatalf("递增后版本号 = %v, %v", gens, err)
+	}
+
+	mr.Set("test:gen:User", "x")
+	if _, err := b.Generations(ctx, "User"); err == nil {
+		t.Fatal("无效的版本号应返回错误")
+	}
+}
+
+func TestCache(t *testing.T) {
+	backends := map[string]func(t *testing.T) Backend{
+		"memory": func(t *testing.T) Backend { return NewMemoryBackend(100) },
+		"redis": func(t *testing.T) Backend {
+			b, _ := newRedisBackend(t)
+			return b
+		},
+	}
+
+	for name, newBackend := range backends {
+		t.Run(name, func(t *testing.T) {
+			t.Run("HitAndInvalidate", func(t *testing.T) { testHitAndInvalidate(t, newBackend(t)) })
+			t.Run("InvalidateAfterCommit", func(t *testing.T) { testInvalidateAfterCommit(t, newBackend(t)) })
+			t.Run("Uncached", func(t *testing.T) { testUncached(t, newBackend(t)) })
+		})
+	}
+}
+
+// testHitAndInvalidate 命中缓存时不访问数据库，通过挂载钩子的连接写入后缓存失效
+func testHitAndInvalidate(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	cached, raw := openClients(t, New(backend))
+
+	flag := cached.FeatureFlag.Create().SetKey("a").SaveX(ctx)
+	get := func() *ent.FeatureFlag {
+		t.Helper()
+		f, err := cached.FeatureFlag.Query().
+			Where(featureflag.Key("a")).
+			Only(WithKey(ctx, "key:a"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	if f := get(); f.Enabled {
+		t.Fatal("初始状态应为关闭")
+	}
+
+	// 绕过缓存直接修改数据库，缓存未失效时仍返回旧结果
+	raw.FeatureFlag.UpdateOneID(flag.ID).SetEnabled(true).ExecX(ctx)
+	if f := get(); f.Enabled {
+		t.Fatal("应命中缓存返回旧结果")
+	}
+
+	// 通过挂载钩子的连接写入，缓存失效后返回新结果
+	cached.FeatureFlag.UpdateOneID(flag.ID).SetDescription("b").ExecX(ctx)
+	f := get()
+	if !f.Enabled || f.Description != "b" {
+		t.Fatalf("缓存失效后应返回新结果: enabled=%v description=%q", f.Enabled, f.Description)
+	}
+
+	n, err := cached.FeatureFlag.Query().Count(WithKey(ctx, "all"))
+	if err != nil || n != 1 {
+		t.Fatalf("Count = %d, %v", n, err)
+	}
+}
+
+// testInvalidateAfterCommit 事务中的写入在提交后才使缓存失效
+func testInvalidateAfterCommit(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	cached, _ := openClients(t, New(backend))
+
+	count := func() int {
+		t.Helper()
+		n, err := cached.FeatureFlag.Query().Count(WithKey(ctx, "all"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	if n := count(); n != 0 {
+		t.Fatalf("Count = %d", n)
+	}
+
+	tx, err := cached.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.FeatureFlag.Create().SetKey("a").SaveX(ctx)
+	gens, _ := backend.Generations(ctx, "FeatureFlag")
+	if gens[0] != 0 {
+		t.Fatal("事务提交前不应使缓存失效")
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := count(); n != 1 {
+		t.Fatalf("事务提交后 Count = %d", n)
+	}
+}
+
+// testUncached 未通过 WithKey 开启缓存的查询不经过缓存
+func testUncached(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	cached, raw := openClients(t, New(backend))
+
+	cached.FeatureFlag.Create().SetKey("a").SaveX(ctx)
+	if n := cached.FeatureFlag.Query().CountX(ctx); n != 1 {
+		t.Fatalf("Count = %d", n)
+	}
+
+	raw.FeatureFlag.Create().SetKey("b").SaveX(ctx)
+	if n := cached.FeatureFlag.Query().CountX(ctx); n != 2 {
+		t.Fatalf("未开启缓存的查询应读取数据库: Count = %d", n)
+	}
+}
@@ -0,0 +1,76 @@
+package querycache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisBackend 基于Redis的缓存，版本号保存在Redis中，多实例部署时写入会使所有实例的缓存失效
+type RedisBackend struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisBackend 创建基于Redis的缓存，prefix 为所有键的前缀
+func NewRedisBackend(client redis.UniversalClient, prefix string) *RedisBackend {
+	return &RedisBackend{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Get 实现 Backend 接口
+func (b *RedisBackend) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	data, err := b.client.Get(ctx, b.prefix+"q:"+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// Set 实现 Backend 接口
+func (b *RedisBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return b.client.Set(ctx, b.prefix+"q:"+key, value, ttl).Err()
+}
+
+// Generations 实现 Backend 接口
+func (b *RedisBackend) Generations(ctx context.Context, entities ...string) ([]uint64, error) {
+	keys := make([]string, len(entities))
+	for i, entity := range entities {
+		keys[i] = b.prefix + "gen:" + entity
+	}
+
+	values, err := b.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	gens := make([]uint64, len(values))
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if gens[i], err = strconv.ParseUint(s, 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "无效的缓存版本号: %s", keys[i])
+		}
+	}
+	return gens, nil
+}
+
+// Bump 实现 Backend 接口，旧版本的缓存项由Redis按TTL过期
+func (b *RedisBackend) Bump(ctx context.Context, entities ...string) error {
+	pipe := b.client.Pipeline()
+	for _, entity := range entities {
+		pipe.Incr(ctx, b.prefix+"gen:"+entity)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// tokenBucket 令牌桶算法
+// 桶容量为 burst（默认等于 limit），令牌以 limit/window 的速率匀速补充
+type tokenBucket struct{}
+
+// Allow 实现 Algorithm 接口
+func (tokenBucket) Allow(state *State, policy Policy, now time.Time) Result {
+	capacity := float64(policy.Limit)
+	if policy.Burst > 0 {
+		capacity = float64(policy.Burst)
+	}
+	rate := float64(policy.Limit) / policy.Window.Seconds()
+
+	if state.UpdatedAt.IsZero() {
+		state.Tokens = capacity
+	} else if elapsed := now.Sub(state.UpdatedAt).Seconds(); elapsed > 0 {
+		state.Tokens = math.Min(capacity, state.Tokens+elapsed*rate)
+	}
+
+	result := Result{Limit: int(capacity)}
+	if state.Tokens >= 1 {
+		state.Tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - state.Tokens) / rate)
+	}
+
+	result.Remaining = int(math.Floor(state.Tokens))
+	result.Reset = secondsToDuration((capacity - state.Tokens) / rate)
+
+	return result
+}
+
+// slidingWindow 滑动窗口计数算法
+// 以上一窗口计数按剩余时间比例加权，近似计算最近一个窗口内的请求数
+type slidingWindow struct{}
+
+// Allow 实现 Algorithm 接口
+func (slidingWindow) Allow(state *State, policy Policy, now time.Time) Result {
+	window := policy.Window
+	start := now.Truncate(window)
+
+	if !state.WindowStart.Equal(start) {
+		if start.Sub(state.WindowStart) == window {
+			state.PrevCount = state.Count
+		} else {
+			state.PrevCount = 0
+		}
+		state.Count = 0
+		state.WindowStart = start
+	}
+
+	elapsed := now.Sub(start)
+	weight := 1 - float64(elapsed)/float64(window)
+	estimate := float64(state.PrevCount)*weight + float64(state.Count)
+	limit := float64(policy.Limit)
+
+	result := Result{Limit: policy.Limit, Reset: window - elapsed}
+	if estimate+1 <= limit {
+		state.Count++
+		estimate++
+		result.Allowed = true
+	} else {
+		result.RetryAfter = result.Reset
+		if state.PrevCount > 0 {
+			// 求解上一窗口权重衰减到足以容纳一个请求所需的时间
+			need := 1 - (limit-float64(state.Count)-1)/float64(state.PrevCount)
+			if wait := time.Duration(need*float64(window)) - elapsed; wait > 0 && wait < result.RetryAfter {
+				result.RetryAfter = wait
+			}
+		}
+	}
+
+	result.Remaining = max(policy.Limit-int(math.Ceil(estimate)), 0)
+
+	return result
+}
+
+// secondsToDuration 将秒数转换为 time.Duration
+func secondsToDuration(seconds float64) time.Duration {
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
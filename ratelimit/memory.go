@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval 内存存储每隔多少次更新清理一次过期状态
+const sweepInterval = 1024
+
+// memoryEntry 内存存储中的状态条目
+type memoryEntry struct {
+	state     State
+	expiresAt time.Time
+}
+
+// MemoryStore 进程内限流状态存储，适用于单实例部署
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+	updates int
+}
+
+// NewMemoryStore 创建进程内限流状态存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]*memoryEntry),
+	}
+}
+
+// Update 实现 Store 接口
+func (s *MemoryStore) Update(_ context.Context, key string, ttl time.Duration, fn func(state *State)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	s.updates++
+	if s.updates%sweepInterval == 0 {
+		s.sweep(now)
+	}
+
+	entry, ok := s.entries[key]
+	if !ok || now.After(entry.expiresAt) {
+		entry = &memoryEntry{}
+		s.entries[key] = entry
+	}
+
+	fn(&entry.state)
+	entry.expiresAt = now.Add(ttl)
+
+	return nil
+}
+
+// sweep 清理过期状态，调用方需持有锁
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, entry := range s.entries {
+		if now.After(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"doghole/auth"
+	"doghole/domain/conn"
+	"github.com/gofiber/fiber/v3"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// 限流维度
+const (
+	KeyByIP     = "ip"
+	KeyByUser   = "user"
+	KeyByAPIKey = "api_key"
+	KeyByTenant = "tenant"
+)
+
+// 限流相关请求头与响应头
+const (
+	HeaderAPIKey             = "X-API-Key"
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// 存储类型
+const (
+	StoreMemory = "memory"
+	StoreSQL    = "sql"
+)
+
+// NewStore 根据存储类型创建限流状态存储
+func NewStore(kind, dialect string) (Store, error) {
+	switch kind {
+	case "", StoreMemory:
+		return NewMemoryStore(), nil
+	case StoreSQL:
+		if conn.Writer() == nil {
+			return nil, errors.New("数据库连接未初始化，无法使用sql限流存储")
+		}
+		return NewSQLStore(conn.Writer(), dialect), nil
+	default:
+		return nil, fmt.Errorf("不支持的限流存储类型: %s", kind)
+	}
+}
+
+// New 创建限流中间件
+// 存储异常时放行请求并记录错误，避免限流组件故障导致服务不可用
+func New(limiter *Limiter) fiber.Handler {
+	policy := limiter.Policy()
+	policyHeader := fmt.Sprintf("%d;w=%d", policy.Limit, int(math.Ceil(policy.Window.Seconds())))
+
+	return func(c fiber.Ctx) error {
+		result, err := limiter.Allow(c.Context(), clientKey(c, policy.KeyBy))
+		if err != nil {
+			zap.L().Error("限流判断失败", zap.String("policy", policy.Name), zap.Error(err))
+			return c.Next()
+		}
+
+		c.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+		c.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+		c.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(result.Reset)))
+		c.Set(HeaderRateLimitPolicy, policyHeader)
+
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(max(ceilSeconds(result.RetryAfter), 1)))
+			return fiber.NewError(fiber.StatusTooManyRequests, "请求过于频繁，请稍后再试")
+		}
+
+		return c.Next()
+	}
+}
+
+// clientKey 按限流维度提取客户端标识，无法识别时退化为按IP限流
+func clientKey(c fiber.Ctx, keyBy string) string {
+	switch keyBy {
+	case KeyByUser:
+		if id := auth.UserID(c); id != "" {
+			return "user:" + id
+		}
+	case KeyByAPIKey:
+		if key := c.Get(HeaderAPIKey); key != "" {
+			// 不在存储中保存明文API密钥
+			sum := sha256.Sum256([]byte(key))
+			return "key:" + hex.EncodeToString(sum[:8])
+		}
+	case KeyByTenant:
+		if id := auth.TenantID(c); id != "" {
+			return "tenant:" + id
+		}
+		if id := c.Get(auth.HeaderTenantID); id != "" {
+			return "tenant:" + id
+		}
+	}
+	return "ip:" + c.IP()
+}
+
+// ceilSeconds 向上取整为秒
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,146 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"doghole/config"
+	"github.com/pkg/errors"
+)
+
+// 限流算法
+const (
+	AlgorithmTokenBucket   = "token_bucket"
+	AlgorithmSlidingWindow = "sliding_window"
+)
+
+// State 限流状态，由存储负责持久化，由算法负责解释
+type State struct {
+	Tokens      float64   // 令牌桶剩余令牌数
+	Count       int64     // 滑动窗口当前窗口计数
+	PrevCount   int64     // 滑动窗口上一窗口计数
+	WindowStart time.Time // 滑动窗口当前窗口起始时间
+	UpdatedAt   time.Time // 最近一次更新时间，零值表示新状态
+}
+
+// Store 限流状态存储接口
+type Store interface {
+	// Update 原子地读取、修改并保存key对应的状态，ttl为状态的保留时长
+	Update(ctx context.Context, key string, ttl time.Duration, fn func(state *State)) error
+}
+
+// Algorithm 限流算法接口
+type Algorithm interface {
+	// Allow 根据状态判断是否放行本次请求并更新状态
+	Allow(state *State, policy Policy, now time.Time) Result
+}
+
+// Policy 限流策略
+type Policy struct {
+	Name      string
+	Algorithm string
+	Limit     int
+	Window    time.Duration
+	Burst     int
+	KeyBy     string
+}
+
+// Result 限流判断结果
+type Result struct {
+	Allowed    bool          // 是否放行
+	Limit      int           // 配额上限
+	Remaining  int           // 剩余配额
+	Reset      time.Duration // 配额完全恢复所需时间
+	RetryAfter time.Duration // 被拒绝时建议的重试等待时间
+}
+
+// PolicyFromConfig 从配置构造限流策略
+func PolicyFromConfig(c config.RateLimitPolicy) Policy {
+	return Policy{
+		Name:      c.Name,
+		Algorithm: c.Algorithm,
+		Limit:     c.Limit,
+		Window:    c.Window,
+		Burst:     c.Burst,
+		KeyBy:     c.KeyBy,
+	}
+}
+
+// Validate 校验策略配置
+func (p Policy) Validate() error {
+	if p.Name == "" {
+		return errors.New("限流策略名称不能为空")
+	}
+	if p.Limit <= 0 {
+		return fmt.Errorf("限流策略 %s 的 limit 必须大于0", p.Name)
+	}
+	if p.Window <= 0 {
+		return fmt.Errorf("限流策略 %s 的 window 必须大于0", p.Name)
+	}
+	if _, ok := algorithms[p.Algorithm]; !ok {
+		return fmt.Errorf("限流策略 %s 使用了不支持的算法: %s", p.Name, p.Algorithm)
+	}
+	switch p.KeyBy {
+	case "", KeyByIP, KeyByUser, KeyByAPIKey, KeyByTenant:
+	default:
+		return fmt.Errorf("限流策略 %s 使用了不支持的限流维度: %s", p.Name, p.KeyBy)
+	}
+	return nil
+}
+
+// algorithms 已注册的限流算法
+var algorithms = map[string]Algorithm{
+	AlgorithmTokenBucket:   tokenBucket{},
+	AlgorithmSlidingWindow: slidingWindow{},
+}
+
+// Limiter 限流器
+type Limiter struct {
+	policy    Policy
+	store     Store
+	algorithm Algorithm
+}
+
+// NewLimiter 创建限流器
+func NewLimiter(policy Policy, store Store) (*Limiter, error) {
+	if policy.Algorithm == "" {
+		policy.Algorithm = AlgorithmTokenBucket
+	}
+	if policy.KeyBy == "" {
+		policy.KeyBy = KeyByIP
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, errors.New("限流存储不能为空")
+	}
+
+	return &Limiter{
+		policy:    policy,
+		store:     store,
+		algorithm: algorithms[policy.Algorithm],
+	}, nil
+}
+
+// Policy 返回限流器使用的策略
+func (l *Limiter) Policy() Policy {
+	return l.policy
+}
+
+// Allow 对指定客户端标识执行一次限流判断
+func (l *Limiter) Allow(ctx context.Context, key string) (Result, error) {
+	var result Result
+	now := time.Now()
+
+	err := l.store.Update(ctx, l.policy.Name+":"+key, 2*l.policy.Window, func(state *State) {
+		result = l.algorithm.Allow(state, l.policy, now)
+		state.UpdatedAt = now
+	})
+	if err != nil {
+		return Result{}, errors.Wrap(err, "更新限流状态失败")
+	}
+
+	return result, nil
+}
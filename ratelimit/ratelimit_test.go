@@ -0,0 +1,214 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"doghole/auth"
+	"doghole/ent/enttest"
+	"entgo.io/ent/dialect"
+	"github.com/gofiber/fiber/v3"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// base 测试使用的固定时间，对齐到分钟便于计算滑动窗口
+var base = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestTokenBucket(t *testing.T) {
+	policy := Policy{Limit: 10, Window: 10 * time.Second, Burst: 3}
+	var state State
+	allow := func(now time.Time) Result {
+		result := tokenBucket{}.Allow(&state, policy, now)
+		state.UpdatedAt = now
+		return result
+	}
+
+	// 新状态装满 burst 个令牌
+	for i := 2; i >= 0; i-- {
+		r := allow(base)
+		if !r.Allowed || r.Remaining != i || r.Limit != 3 {
+			t.Fatalf("第 %d 个请求: %+v", 3-i, r)
+		}
+	}
+
+	r := allow(base)
+	if r.Allowed {
+		t.Fatal("令牌耗尽后应拒绝")
+	}
+	if r.RetryAfter != time.Second {
+		t.Errorf("RetryAfter = %s，期望按每秒1个令牌补充", r.RetryAfter)
+	}
+	if r.Reset != 3*time.Second {
+		t.Errorf("Reset = %s", r.Reset)
+	}
+
+	// 1秒后补充1个令牌
+	if r := allow(base.Add(time.Second)); !r.Allowed || r.Remaining != 0 {
+		t.Fatalf("补充令牌后应放行: %+v", r)
+	}
+
+	// 长时间空闲后不超过桶容量
+	if r := allow(base.Add(time.Hour)); !r.Allowed || r.Remaining != 2 {
+		t.Fatalf("令牌数不应超过容量: %+v", r)
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	policy := Policy{Limit: 4, Window: time.Minute}
+	var state State
+	allow := func(now time.Time) Result {
+		return slidingWindow{}.Allow(&state, policy, now)
+	}
+
+	for i := 0; i < 4; i++ {
+		if r := allow(base.Add(30 * time.Second)); !r.Allowed {
+			t.Fatalf("第 %d 个请求应放行: %+v", i+1, r)
+		}
+	}
+	r := allow(base.Add(30 * time.Second))
+	if r.Allowed || r.Remaining != 0 {
+		t.Fatalf("超过限额应拒绝: %+v", r)
+	}
+	if r.RetryAfter != 30*time.Second || r.Reset != 30*time.Second {
+		t.Errorf("RetryAfter = %s，Reset = %s", r.RetryAfter, r.Reset)
+	}
+
+	// 下一窗口开始时上一窗口的4个请求权重为1，仍拒绝
+	if r := allow(base.Add(time.Minute)); r.Allowed {
+		t.Fatalf("窗口切换时应计入上一窗口的请求: %+v", r)
+	}
+
+	// 下一窗口过去一半时上一窗口按一半计入，估计2个请求，还可放行2个
+	for i := 0; i < 2; i++ {
+		if r := allow(base.Add(90 * time.Second)); !r.Allowed {
+			t.Fatalf("第 %d 个请求应放行: %+v", i+1, r)
+		}
+	}
+	r = allow(base.Add(90 * time.Second))
+	if r.Allowed {
+		t.Fatalf("加权后超过限额应拒绝: %+v", r)
+	}
+	// 需要上一窗口权重降到 1/4，即窗口过去 3/4 时
+	if r.RetryAfter != 15*time.Second {
+		t.Errorf("RetryAfter = %s", r.RetryAfter)
+	}
+
+	// 间隔超过一个窗口后上一窗口不再计入
+	if r := allow(base.Add(5 * time.Minute)); !r.Allowed || r.Remaining != 3 {
+		t.Fatalf("间隔一个以上窗口后应重新计数: %+v", r)
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		err    string
+	}{
+		{"缺少名称", Policy{Limit: 1, Window: time.Second, Algorithm: AlgorithmTokenBucket}, "名称"},
+		{"limit为0", Policy{Name: "p", Window: time.Second, Algorithm: AlgorithmTokenBucket}, "limit"},
+		{"window为0", Policy{Name: "p", Limit: 1, Algorithm: AlgorithmTokenBucket}, "window"},
+		{"未知算法", Policy{Name: "p", Limit: 1, Window: time.Second, Algorithm: "leaky"}, "算法"},
+		{"未知维度", Policy{Name: "p", Limit: 1, Window: time.Second, Algorithm: AlgorithmTokenBucket, KeyBy: "cookie"}, "维度"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("err = %v，期望包含 %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	stores := map[string]func(t *testing.T) Store{
+		StoreMemory: func(t *testing.T) Store { return NewMemoryStore() },
+		StoreSQL: func(t *testing.T) Store {
+			client := enttest.Open(t, dialect.SQLite, fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", strings.ReplaceAll(t.Name(), "/", "_")))
+			t.Cleanup(func() { client.Close() })
+			return NewSQLStore(client, dialect.SQLite)
+		},
+	}
+
+	for name, newStore := range stores {
+		for _, algorithm := range []string{AlgorithmTokenBucket, AlgorithmSlidingWindow} {
+			t.Run(name+"/"+algorithm, func(t *testing.T) {
+				limiter, err := NewLimiter(Policy{Name: "api", Algorithm: algorithm, Limit: 2, Window: time.Hour}, newStore(t))
+				if err != nil {
+					t.Fatal(err)
+				}
+				ctx := context.Background()
+
+				for i := 0; i < 2; i++ {
+					if r, err := limiter.Allow(ctx, "ip:1.1.1.1"); err != nil || !r.Allowed {
+						t.Fatalf("第 %d 个请求应放行: %+v %v", i+1, r, err)
+					}
+				}
+				if r, err := limiter.Allow(ctx, "ip:1.1.1.1"); err != nil || r.Allowed {
+					t.Fatalf("超过限额应拒绝: %+v %v", r, err)
+				}
+
+				// 不同客户端的状态互不影响
+				if r, err := limiter.Allow(ctx, "ip:2.2.2.2"); err != nil || !r.Allowed {
+					t.Fatalf("其他客户端应放行: %+v %v", r, err)
+				}
+			})
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	limiter, err := NewLimiter(Policy{Name: "api", Limit: 1, Window: time.Minute, KeyBy: KeyByUser}, NewMemoryStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error {
+		if id := c.Get("X-Test-User"); id != "" {
+			c.Locals(auth.LocalsUserID, id)
+		}
+		return c.Next()
+	})
+	app.Use(New(limiter))
+	app.Get("/", func(c fiber.Ctx) error { return c.SendString("ok") })
+
+	do := func(user string) int {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		if user != "" {
+			req.Header.Set("X-Test-User", user)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user == "alice" && resp.StatusCode == fiber.StatusOK {
+			if got := resp.Header.Get(HeaderRateLimitPolicy); got != "1;w=60" {
+				t.Errorf("RateLimit-Policy = %q", got)
+			}
+		}
+		if resp.StatusCode == fiber.StatusTooManyRequests && resp.Header.Get(fiber.HeaderRetryAfter) == "" {
+			t.Error("拒绝时应返回 Retry-After")
+		}
+		return resp.StatusCode
+	}
+
+	if got := do("alice"); got != fiber.StatusOK {
+		t.Fatalf("status = %d", got)
+	}
+	if got := do("alice"); got != fiber.StatusTooManyRequests {
+		t.Fatalf("超过限额 status = %d", got)
+	}
+	// 按用户限流，其他用户和匿名请求（按IP）不受影响
+	if got := do("bob"); got != fiber.StatusOK {
+		t.Fatalf("其他用户 status = %d", got)
+	}
+	if got := do(""); got != fiber.StatusOK {
+		t.Fatalf("匿名请求 status = %d", got)
+	}
+}
@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"doghole/ent"
+	"doghole/ent/ratelimitbucket"
+	"entgo.io/ent/dialect"
+	"github.com/pkg/errors"
+)
+
+// SQLStore 基于数据库的限流状态存储，适用于多实例部署
+type SQLStore struct {
+	client  *ent.Client
+	dialect string
+}
+
+// NewSQLStore 创建基于数据库的限流状态存储
+// dialect 用于决定是否可以使用 SELECT ... FOR UPDATE 行锁
+func NewSQLStore(client *ent.Client, dialect string) *SQLStore {
+	return &SQLStore{
+		client:  client,
+		dialect: dialect,
+	}
+}
+
+// Update 实现 Store 接口
+func (s *SQLStore) Update(ctx context.Context, key string, ttl time.Duration, fn func(state *State)) error {
+	// 首次写入时可能与其他实例并发创建同一个键，冲突后重试一次
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if err = s.update(ctx, key, ttl, fn); !ent.IsConstraintError(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// update 在事务中执行一次读-改-写
+func (s *SQLStore) update(ctx context.Context, key string, ttl time.Duration, fn func(state *State)) error {
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "开启限流事务失败")
+	}
+
+	query := tx.RateLimitBucket.Query().Where(ratelimitbucket.Key(key))
+	if s.dialect != dialect.SQLite {
+		query = query.ForUpdate()
+	}
+
+	now := time.Now()
+	bucket, err := query.Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		_ = tx.Rollback()
+		return errors.Wrap(err, "查询限流状态失败")
+	}
+
+	var state State
+	if bucket != nil && bucket.ExpiresAt.After(now) {
+		state = State{
+			Tokens:      bucket.Tokens,
+			Count:       bucket.Count,
+			PrevCount:   bucket.PrevCount,
+			WindowStart: bucket.WindowStart,
+			UpdatedAt:   bucket.UpdatedAt,
+		}
+	}
+
+	fn(&state)
+
+	if bucket == nil {
+		err = tx.RateLimitBucket.Create().
+			SetKey(key).
+			SetTokens(state.Tokens).
+			SetCount(state.Count).
+			SetPrevCount(state.PrevCount).
+			SetWindowStart(state.WindowStart).
+			SetUpdatedAt(state.UpdatedAt).
+			SetExpiresAt(now.Add(ttl)).
+			Exec(ctx)
+	} else {
+		err = tx.RateLimitBucket.UpdateOne(bucket).
+			SetTokens(state.Tokens).
+			SetCount(state.Count).
+			SetPrevCount(state.PrevCount).
+			SetWindowStart(state.WindowStart).
+			SetUpdatedAt(state.UpdatedAt).
+			SetExpiresAt(now.Add(ttl)).
+			Exec(ctx)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Purge 清理已过期的限流状态，返回删除数量
+func (s *SQLStore) Purge(ctx context.Context) (int, error) {
+	n, err := s.client.RateLimitBucket.Delete().
+		Where(ratelimitbucket.ExpiresAtLT(time.Now())).
+		Exec(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "清理过期限流状态失败")
+	}
+	return n, nil
+}
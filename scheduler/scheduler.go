@@ -0,0 +1,312 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"doghole/config"
+	"doghole/ent"
+	"doghole/ent/scheduledrun"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// TaskFunc 定时任务函数
+type TaskFunc func(ctx context.Context) error
+
+var (
+	_tasks   = make(map[string]TaskFunc)
+	_tasksMu sync.RWMutex
+)
+
+// Register 按名称注册定时任务，调度规则由配置文件的 scheduler.tasks 决定
+func Register(name string, fn TaskFunc) {
+	_tasksMu.Lock()
+	defer _tasksMu.Unlock()
+
+	if fn == nil {
+		panic("定时任务函数不能为nil")
+	}
+	if _, exists := _tasks[name]; exists {
+		panic(fmt.Sprintf("定时任务重复注册: %s", name))
+	}
+	_tasks[name] = fn
+}
+
+// lookup 查找定时任务
+func lookup(name string) (TaskFunc, bool) {
+	_tasksMu.RLock()
+	defer _tasksMu.RUnlock()
+	fn, ok := _tasks[name]
+	return fn, ok
+}
+
+// cronParser 支持标准5段式表达式和 @daily 等描述符
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// entry 已生效的调度项
+type entry struct {
+	name      string
+	schedule  cron.Schedule
+	location  *time.Location
+	timeout   time.Duration
+	retention time.Duration
+	fn        TaskFunc
+}
+
+// Scheduler 定时任务调度器
+// 多实例部署时各实例计算出相同的触发时间，通过运行记录的唯一约束保证每个触发点只有一个实例执行
+type Scheduler struct {
+	client   *ent.Client
+	instance string
+	logger   *zap.Logger
+
+	mu     sync.Mutex
+	root   context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewScheduler 创建定时任务调度器
+func NewScheduler(client *ent.Client, options ...func(*Scheduler)) *Scheduler {
+	hostname, _ := os.Hostname()
+	s := &Scheduler{
+		client:   client,
+		instance: fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+		logger:   zap.L(),
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// WithLogger 设置日志记录器
+func WithLogger(logger *zap.Logger) func(*Scheduler) {
+	return func(s *Scheduler) {
+		s.logger = logger
+	}
+}
+
+// Run 按配置启动调度，直到ctx被取消；取消后等待运行中的任务结束
+func (s *Scheduler) Run(ctx context.Context, conf config.SchedulerConfig) error {
+	entries, err := buildEntries(conf)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.root = ctx
+	s.start(entries)
+	s.mu.Unlock()
+
+	<-ctx.Done()
+
+	s.mu.Lock()
+	s.cancel()
+	s.mu.Unlock()
+	s.wg.Wait()
+
+	return nil
+}
+
+// Reload 使用新配置重新调度，配置无效时保留原有调度
+// 正在运行的任务不会被中断
+func (s *Scheduler) Reload(conf config.SchedulerConfig) {
+	entries, err := buildEntries(conf)
+	if err != nil {
+		s.logger.Error("定时任务配置无效，保留原有调度", zap.Error(err))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.root == nil || s.root.Err() != nil {
+		return
+	}
+	s.cancel()
+	s.start(entries)
+	s.logger.Info("定时任务配置已重新加载", zap.Int("tasks", len(entries)))
+}
+
+// Validate 校验调度配置中的时区和cron表达式
+func Validate(conf config.SchedulerConfig) error {
+	_, err := buildEntries(conf)
+	return err
+}
+
+// start 启动调度循环，调用方需持有锁
+func (s *Scheduler) start(entries []entry) {
+	ctx, cancel := context.WithCancel(s.root)
+	s.cancel = cancel
+
+	for _, e := range entries {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.loop(ctx, e)
+		}()
+		s.logger.Info("定时任务已调度",
+			zap.String("task", e.name),
+			zap.Time("next", e.schedule.Next(time.Now().In(e.location))),
+		)
+	}
+}
+
+// loop 单个任务的调度循环
+// 上一次运行结束后才计算下一个触发点，同一实例内任务不会重叠执行
+func (s *Scheduler) loop(ctx context.Context, e entry) {
+	for {
+		next := e.schedule.Next(time.Now().In(e.location))
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.fire(e, next)
+	}
+}
+
+// fire 尝试领取触发点并执行任务
+func (s *Scheduler) fire(e entry, scheduledAt time.Time) {
+	// 任务执行使用调度器的根context，重新加载配置不会中断运行中的任务
+	ctx := s.root
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+
+	logger := s.logger.With(zap.String("task", e.name), zap.Time("scheduled_at", scheduledAt))
+
+	run, err := s.client.ScheduledRun.Create().
+		SetTask(e.name).
+		SetScheduledAt(scheduledAt.UTC().Truncate(time.Second)).
+		SetInstance(s.instance).
+		Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			logger.Debug("触发点已由其他实例执行")
+			return
+		}
+		logger.Error("领取定时任务失败", zap.Error(err))
+		return
+	}
+
+	start := time.Now()
+	taskErr := execute(ctx, e.fn)
+
+	// 结果写回使用独立的context，避免因任务超时导致状态无法保存
+	saveCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := s.client.ScheduledRun.UpdateOne(run).SetFinishedAt(time.Now())
+	if taskErr != nil {
+		update.SetStatus(scheduledrun.StatusFailed).SetError(taskErr.Error())
+		logger.Error("定时任务执行失败", zap.Duration("latency", time.Since(start)), zap.Error(taskErr))
+	} else {
+		update.SetStatus(scheduledrun.StatusSucceeded)
+		logger.Info("定时任务执行成功", zap.Duration("latency", time.Since(start)))
+	}
+	if err := update.Exec(saveCtx); err != nil {
+		logger.Error("保存定时任务运行记录失败", zap.Error(err))
+	}
+
+	s.pruneHistory(saveCtx, e.name, e.retention)
+}
+
+// pruneHistory 清理超过保留时长的运行记录
+func (s *Scheduler) pruneHistory(ctx context.Context, task string, retention time.Duration) {
+	if retention <= 0 {
+		return
+	}
+	if _, err := s.client.ScheduledRun.Delete().
+		Where(
+			scheduledrun.Task(task),
+			scheduledrun.StartedAtLT(time.Now().Add(-retention)),
+		).
+		Exec(ctx); err != nil {
+		s.logger.Error("清理定时任务运行记录失败", zap.String("task", task), zap.Error(err))
+	}
+}
+
+// execute 执行任务并将panic转换为错误
+func execute(ctx context.Context, fn TaskFunc) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("定时任务panic: %v", v)
+		}
+	}()
+	return fn(ctx)
+}
+
+// buildEntries 根据配置生成调度项
+func buildEntries(conf config.SchedulerConfig) ([]entry, error) {
+	defaultLoc, err := loadLocation(conf.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(conf.Tasks))
+	for name := range conf.Tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entries := make([]entry, 0, len(names))
+	for _, name := range names {
+		task := conf.Tasks[name]
+		if !task.Enabled {
+			continue
+		}
+
+		fn, ok := lookup(name)
+		if !ok {
+			zap.L().Warn("配置了未注册的定时任务", zap.String("task", name))
+			continue
+		}
+
+		schedule, err := cronParser.Parse(task.Cron)
+		if err != nil {
+			return nil, errors.Wrapf(err, "定时任务 %s 的cron表达式无效", name)
+		}
+
+		loc := defaultLoc
+		if task.TimeZone != "" {
+			if loc, err = loadLocation(task.TimeZone); err != nil {
+				return nil, errors.Wrapf(err, "定时任务 %s 的时区无效", name)
+			}
+		}
+
+		entries = append(entries, entry{
+			name:      name,
+			schedule:  schedule,
+			location:  loc,
+			timeout:   task.Timeout,
+			retention: conf.HistoryRetention,
+			fn:        fn,
+		})
+	}
+
+	return entries, nil
+}
+
+// loadLocation 加载时区，空值表示本地时区
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(name)
+}
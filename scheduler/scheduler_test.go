@@ -0,0 +1,267 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"doghole/config"
+	"doghole/ent"
+	"doghole/ent/enttest"
+	"doghole/ent/scheduledrun"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+// 定时任务全局注册且不能重复，测试用的任务在包初始化时注册一次
+func init() {
+	Register("test.ok", func(ctx context.Context) error { return nil })
+	Register("test.other", func(ctx context.Context) error { return nil })
+}
+
+// openClient 打开测试用的内存数据库
+func openClient(t *testing.T) *ent.Client {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", strings.ReplaceAll(t.Name(), "/", "_")))
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// newScheduler 创建指定实例名称的调度器，不启动调度循环
+func newScheduler(client *ent.Client, instance string) *Scheduler {
+	s := NewScheduler(client, WithLogger(zap.NewNop()))
+	s.instance = instance
+	s.root = context.Background()
+	return s
+}
+
+// every 按固定间隔触发的调度规则，cron表达式的最小粒度为1分钟
+// 触发时间按秒记录，间隔不能小于1秒
+type every time.Duration
+
+func (d every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(d))
+}
+
+func TestBuildEntries(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skip("缺少时区数据")
+	}
+
+	tests := []struct {
+		name    string
+		conf    config.SchedulerConfig
+		tasks   []string
+		loc     *time.Location
+		wantErr string
+	}{
+		{
+			name:  "按名称排序",
+			conf:  config.SchedulerConfig{Tasks: map[string]config.ScheduledTask{"test.other": {Cron: "@hourly", Enabled: true}, "test.ok": {Cron: "*/5 * * * *", Enabled: true}}},
+			tasks: []string{"test.ok", "test.other"},
+			loc:   time.Local,
+		},
+		{
+			name:  "跳过未启用的任务",
+			conf:  config.SchedulerConfig{Tasks: map[string]config.ScheduledTask{"test.ok": {Cron: "@hourly"}}},
+			tasks: []string{},
+		},
+		{
+			name:  "跳过未注册的任务",
+			conf:  config.SchedulerConfig{Tasks: map[string]config.ScheduledTask{"test.unknown": {Cron: "@hourly", Enabled: true}}},
+			tasks: []string{},
+		},
+		{
+			name:  "使用默认时区",
+			conf:  config.SchedulerConfig{TimeZone: "Asia/Shanghai", Tasks: map[string]config.ScheduledTask{"test.ok": {Cron: "@daily", Enabled: true}}},
+			tasks: []string{"test.ok"},
+			loc:   shanghai,
+		},
+		{
+			name:  "任务时区优先",
+			conf:  config.SchedulerConfig{TimeZone: "Asia/Shanghai", Tasks: map[string]config.ScheduledTask{"test.ok": {Cron: "@daily", TimeZone: "UTC", Enabled: true}}},
+			tasks: []string{"test.ok"},
+			loc:   time.UTC,
+		},
+		{
+			name:    "无效的cron表达式",
+			conf:    config.SchedulerConfig{Tasks: map[string]config.ScheduledTask{"test.ok": {Cron: "* * *", Enabled: true}}},
+			wantErr: "cron表达式无效",
+		},
+		{
+			name:    "无效的默认时区",
+			conf:    config.SchedulerConfig{TimeZone: "Mars/Olympus"},
+			wantErr: "Mars/Olympus",
+		},
+		{
+			name:    "无效的任务时区",
+			conf:    config.SchedulerConfig{Tasks: map[string]config.ScheduledTask{"test.ok": {Cron: "@daily", TimeZone: "Mars/Olympus", Enabled: true}}},
+			wantErr: "时区无效",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := buildEntries(tt.conf)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v，期望包含 %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			names := make([]string, 0, len(entries))
+			for _, e := range entries {
+				names = append(names, e.name)
+				if tt.loc != nil && e.location.String() != tt.loc.String() {
+					t.Fatalf("%s 的时区 = %s，期望 %s", e.name, e.location, tt.loc)
+				}
+			}
+			if strings.Join(names, ",") != strings.Join(tt.tasks, ",") {
+				t.Fatalf("调度项 = %v，期望 %v", names, tt.tasks)
+			}
+		})
+	}
+}
+
+func TestFire(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+	scheduledAt := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		fn     TaskFunc
+		status scheduledrun.Status
+		err    string
+	}{
+		{"成功", func(ctx context.Context) error { return nil }, scheduledrun.StatusSucceeded, ""},
+		{"失败", func(ctx context.Context) error { return errors.New("连接超时") }, scheduledrun.StatusFailed, "连接超时"},
+		{"panic", func(ctx context.Context) error { panic("boom") }, scheduledrun.StatusFailed, "定时任务panic: boom"},
+		{"超时", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, scheduledrun.StatusFailed, context.DeadlineExceeded.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newScheduler(client, "a")
+			s.fire(entry{name: tt.name, fn: tt.fn, timeout: 20 * time.Millisecond}, scheduledAt)
+
+			run := client.ScheduledRun.Query().Where(scheduledrun.Task(tt.name)).OnlyX(ctx)
+			if run.Status != tt.status || run.Error != tt.err || run.FinishedAt == nil || run.Instance != "a" {
+				t.Fatalf("运行记录 = %+v", run)
+			}
+		})
+	}
+}
+
+func TestFireClaim(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+
+	var calls atomic.Int32
+	e := entry{name: "claim", fn: func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}}
+
+	// 多个实例计算出相同的触发点，只有一个实例执行
+	scheduledAt := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+	for _, instance := range []string{"a", "b", "c"} {
+		newScheduler(client, instance).fire(e, scheduledAt)
+	}
+	// 触发时间按秒截断，亚秒级的时钟差异不会导致重复执行
+	newScheduler(client, "d").fire(e, scheduledAt.Add(300*time.Millisecond))
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("同一触发点执行了 %d 次", n)
+	}
+
+	newScheduler(client, "b").fire(e, scheduledAt.Add(time.Minute))
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("下一个触发点执行了 %d 次", n)
+	}
+	if n := client.ScheduledRun.Query().Where(scheduledrun.Task("claim")).CountX(ctx); n != 2 {
+		t.Fatalf("运行记录数量 = %d", n)
+	}
+}
+
+func TestPruneHistory(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+
+	for i, name := range []string{"prune", "prune", "keep"} {
+		client.ScheduledRun.Create().
+			SetTask(name).
+			SetScheduledAt(time.Date(2026, 1, 1, i, 0, 0, 0, time.UTC)).
+			SetInstance("a").
+			SetStartedAt(time.Now().Add(-48 * time.Hour)).
+			ExecX(ctx)
+	}
+
+	// 只清理当前任务超过保留时长的记录
+	newScheduler(client, "a").fire(entry{name: "prune", fn: func(ctx context.Context) error { return nil }, retention: 24 * time.Hour}, time.Now())
+	if n := client.ScheduledRun.Query().Where(scheduledrun.Task("prune")).CountX(ctx); n != 1 {
+		t.Fatalf("清理后 prune 的运行记录数量 = %d", n)
+	}
+	if n := client.ScheduledRun.Query().Where(scheduledrun.Task("keep")).CountX(ctx); n != 1 {
+		t.Fatalf("清理了其他任务的运行记录")
+	}
+}
+
+func TestLoop(t *testing.T) {
+	client := openClient(t)
+	s := newScheduler(client, "a")
+	root, cancel := context.WithCancel(context.Background())
+	s.root = root
+
+	var (
+		mu                sync.Mutex
+		running, overlaps int
+		calls             atomic.Int32
+	)
+	e := entry{name: "loop", schedule: every(time.Second), location: time.UTC, fn: func(ctx context.Context) error {
+		mu.Lock()
+		running++
+		if running > 1 {
+			overlaps++
+		}
+		mu.Unlock()
+		calls.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	}}
+
+	s.mu.Lock()
+	s.start([]entry{e})
+	s.mu.Unlock()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for calls.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("定时任务没有按计划触发")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	s.wg.Wait()
+
+	// 上一次运行结束后才计算下一个触发点
+	if overlaps != 0 {
+		t.Fatalf("任务重叠执行了 %d 次", overlaps)
+	}
+	if n := client.ScheduledRun.Query().Where(scheduledrun.Task("loop"), scheduledrun.StatusEQ(scheduledrun.StatusSucceeded)).CountX(context.Background()); n < 2 {
+		t.Fatalf("成功的运行记录数量 = %d", n)
+	}
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"doghole/config"
+	"github.com/andybalholm/brotli"
+	"github.com/gofiber/fiber/v3"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/zap"
+)
+
+// 支持的压缩编码
+const (
+	EncodingZstd   = "zstd"
+	EncodingBrotli = "br"
+	EncodingGzip   = "gzip"
+)
+
+// compressWriter 可复用的压缩写入器
+type compressWriter interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// _compressors 各编码的写入器池，压缩级别使用各算法兼顾速度和压缩率的默认值
+var _compressors = map[string]*sync.Pool{
+	EncodingZstd: {New: func() any {
+		w, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+		return w
+	}},
+	EncodingBrotli: {New: func() any {
+		return brotli.NewWriterLevel(nil, brotli.DefaultCompression)
+	}},
+	EncodingGzip: {New: func() any {
+		return gzip.NewWriter(nil)
+	}},
+}
+
+// Compression 按请求的 Accept-Encoding 压缩响应体，支持 zstd、br 和 gzip
+// 只压缩内容类型在允许列表中且不小于 min_size 的响应，已编码、流式、HEAD 请求和带 no-transform 的响应不压缩
+// 压缩后的响应使用弱 ETag，因为压缩结果与原始内容不再逐字节相同
+func Compression(conf config.CompressionConfig) fiber.Handler {
+	var encodings []string
+	for _, e := range conf.Encodings {
+		e = strings.ToLower(strings.TrimSpace(e))
+		if _, ok := _compressors[e]; !ok {
+			zap.L().Warn("忽略不支持的压缩编码", zap.String("encoding", e))
+			continue
+		}
+		encodings = append(encodings, e)
+	}
+
+	return func(c fiber.Ctx) error {
+		if err := c.Next(); err != nil || len(encodings) == 0 {
+			return err
+		}
+
+		resp := c.Response()
+		if !compressibleType(string(resp.Header.ContentType()), conf.ContentTypes) {
+			return nil
+		}
+		// 是否压缩取决于 Accept-Encoding，缓存需要按其区分
+		c.Vary(fiber.HeaderAcceptEncoding)
+
+		status := resp.StatusCode()
+		switch {
+		case c.Method() == fiber.MethodHead,
+			status < fiber.StatusOK, status == fiber.StatusNoContent,
+			status == fiber.StatusNotModified, status == fiber.StatusPartialContent,
+			len(resp.Header.Peek(fiber.HeaderContentEncoding)) > 0,
+			strings.Contains(string(resp.Header.Peek(fiber.HeaderCacheControl)), "no-transform"),
+			resp.IsBodyStream(),
+			len(resp.Body()) < conf.MinSize:
+			return nil
+		}
+
+		encoding := negotiateEncoding(c.Get(fiber.HeaderAcceptEncoding), encodings)
+		if encoding == "" {
+			return nil
+		}
+
+		body := resp.Body()
+		var buf bytes.Buffer
+		pool := _compressors[encoding]
+		w := pool.Get().(compressWriter)
+		w.Reset(&buf)
+		_, err := w.Write(body)
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+		pool.Put(w)
+		if err != nil {
+			zap.L().Warn("压缩响应失败", zap.String("encoding", encoding), zap.Error(err))
+			return nil
+		}
+		// 压缩后没有变小时保留原始响应
+		if buf.Len() >= len(body) {
+			return nil
+		}
+
+		resp.SetBodyRaw(buf.Bytes())
+		c.Set(fiber.HeaderContentEncoding, encoding)
+		if etag := string(resp.Header.Peek(fiber.HeaderETag)); etag != "" && !strings.HasPrefix(etag, "W/") {
+			c.Set(fiber.HeaderETag, "W/"+etag)
+		}
+		return nil
+	}
+}
+
+// compressibleType 判断内容类型是否在允许列表中，忽略 charset 等参数
+func compressibleType(contentType string, allowed []string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if mediaType == "" {
+		return false
+	}
+	for _, a := range allowed {
+		a = strings.ToLower(a)
+		if prefix, ok := strings.CutSuffix(a, "/*"); ok {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+		} else if mediaType == a {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiateEncoding 按 Accept-Encoding 的权重选择编码，权重相同时按服务端的优先级，客户端不接受任何编码时返回空
+func negotiateEncoding(accept string, encodings []string) string {
+	if accept == "" {
+		return ""
+	}
+
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(accept, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		weights[name] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, e := range encodings {
+		q, ok := weights[e]
+		if !ok {
+			q, ok = weights["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = e, q
+		}
+	}
+	return best
+}
@@ -0,0 +1,235 @@
+package server
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"doghole/config"
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/cors"
+	"github.com/pkg/errors"
+)
+
+// originRegexPrefix 正则来源的前缀
+const originRegexPrefix = "regex:"
+
+// CORS 按路由组匹配策略的跨域资源共享中间件，配置可在运行时重新加载
+type CORS struct {
+	policies atomic.Pointer[corsPolicies]
+}
+
+// corsPolicies 编译后的一组策略，按路由组前缀从长到短排列
+type corsPolicies struct {
+	enabled  bool
+	policies []corsPolicy
+}
+
+// corsPolicy 编译后的单个路由组策略
+type corsPolicy struct {
+	group   string
+	handler fiber.Handler
+}
+
+// NewCORS 校验并编译CORS配置，来源格式错误或组合不安全（如携带凭据时允许任意来源）时返回错误
+func NewCORS(conf config.CORSConfig) (*CORS, error) {
+	policies, err := compileCORS(conf)
+	if err != nil {
+		return nil, err
+	}
+	c := &CORS{}
+	c.policies.Store(policies)
+	return c, nil
+}
+
+// Reload 使用新配置替换策略，配置无效时返回错误并保留原有策略
+func (c *CORS) Reload(conf config.CORSConfig) error {
+	policies, err := compileCORS(conf)
+	if err != nil {
+		return err
+	}
+	c.policies.Store(policies)
+	return nil
+}
+
+// Handler 返回中间件，按请求路径的最长前缀匹配路由组策略，没有匹配的策略时不返回CORS响应头
+func (c *CORS) Handler() fiber.Handler {
+	return func(ctx fiber.Ctx) error {
+		policies := c.policies.Load()
+		if !policies.enabled {
+			return ctx.Next()
+		}
+
+		path := ctx.Path()
+		for _, p := range policies.policies {
+			if p.group == "" || path == p.group || strings.HasPrefix(path, p.group+"/") {
+				return p.handler(ctx)
+			}
+		}
+		return ctx.Next()
+	}
+}
+
+// compileCORS 校验所有策略并创建对应的处理函数
+func compileCORS(conf config.CORSConfig) (*corsPolicies, error) {
+	compiled := &corsPolicies{enabled: conf.Enabled}
+	if !conf.Enabled {
+		return compiled, nil
+	}
+
+	groups := make(map[string]bool, len(conf.Policies))
+	for _, p := range conf.Policies {
+		group := strings.TrimSuffix(p.Group, "/")
+		if groups[group] {
+			return nil, errors.Errorf("CORS配置错误: 路由组 %q 重复", p.Group)
+		}
+		groups[group] = true
+
+		handler, err := newCORSHandler(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "CORS配置错误: 路由组 %q", p.Group)
+		}
+		compiled.policies = append(compiled.policies, corsPolicy{group: group, handler: handler})
+	}
+
+	sort.SliceStable(compiled.policies, func(i, j int) bool {
+		return len(compiled.policies[i].group) > len(compiled.policies[j].group)
+	})
+	return compiled, nil
+}
+
+// newCORSHandler 校验策略并创建Fiber的CORS中间件，正则来源通过 AllowOriginsFunc 匹配
+func newCORSHandler(p config.CORSPolicy) (handler fiber.Handler, err error) {
+	if len(p.AllowOrigins) == 0 {
+		return nil, errors.New("allow_origins 不能为空")
+	}
+
+	var (
+		origins  []string
+		patterns []*regexp.Regexp
+	)
+	for _, origin := range p.AllowOrigins {
+		origin = strings.TrimSpace(origin)
+		switch {
+		case strings.HasPrefix(origin, originRegexPrefix):
+			re, err := compileOriginRegex(strings.TrimPrefix(origin, originRegexPrefix))
+			if err != nil {
+				return nil, err
+			}
+			patterns = append(patterns, re)
+		case origin == "*":
+			if p.AllowCredentials {
+				return nil, errors.New("allow_credentials 为 true 时 allow_origins 不能包含 *，否则任意网站都能携带用户凭据访问接口，浏览器也会拒绝此类响应")
+			}
+			origins = append(origins, origin)
+		case strings.EqualFold(origin, "null"):
+			// 沙箱 iframe、本地文件等来源的 Origin 都是 null，允许它等同于允许任意来源
+			return nil, errors.New("allow_origins 不能包含 null，任意网站都能通过沙箱 iframe 发出 Origin 为 null 的请求")
+		default:
+			normalized, err := normalizeCORSOrigin(origin)
+			if err != nil {
+				return nil, err
+			}
+			origins = append(origins, normalized)
+		}
+	}
+
+	if p.AllowCredentials {
+		// 携带凭据时浏览器将 * 视为普通的名称而不是通配符
+		for _, h := range p.AllowHeaders {
+			if h == "*" {
+				return nil, errors.New("allow_credentials 为 true 时 allow_headers 不能为 *，请列出允许的请求头")
+			}
+		}
+		for _, h := range p.ExposeHeaders {
+			if h == "*" {
+				return nil, errors.New("allow_credentials 为 true 时 expose_headers 不能为 *，请列出允许读取的响应头")
+			}
+		}
+	}
+
+	cfg := cors.Config{
+		AllowOrigins:     origins,
+		AllowMethods:     p.AllowMethods,
+		AllowHeaders:     p.AllowHeaders,
+		ExposeHeaders:    p.ExposeHeaders,
+		AllowCredentials: p.AllowCredentials,
+		MaxAge:           int(p.MaxAge.Seconds()),
+	}
+	if len(patterns) > 0 && !slices.Contains(origins, "*") {
+		// 同时设置 AllowOrigins 和 AllowOriginsFunc 时Fiber会输出警告，将精确来源和通配来源也转为正则统一匹配
+		for _, origin := range origins {
+			patterns = append(patterns, originPattern(origin))
+		}
+		cfg.AllowOrigins = nil
+		// 传入的 Origin 已转为小写
+		cfg.AllowOriginsFunc = func(origin string) bool {
+			for _, re := range patterns {
+				if re.MatchString(origin) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	// 上面已校验过来源格式，Fiber仍然拒绝时将panic转为错误
+	defer func() {
+		if v := recover(); v != nil {
+			err = errors.Errorf("%v", v)
+		}
+	}()
+	return cors.New(cfg), nil
+}
+
+// normalizeCORSOrigin 校验精确来源或子域名通配来源，返回小写形式
+// 来源只能包含协议、主机和端口，通配符只能作为最左侧的子域名，且其后至少有两级域名，如 https://*.example.com
+func normalizeCORSOrigin(origin string) (string, error) {
+	origin = strings.ToLower(origin)
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", errors.Errorf("无效的来源 %q，格式应为 https://example.com", origin)
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return "", errors.Errorf("无效的来源 %q，来源不能包含路径、查询参数或用户信息", origin)
+	}
+
+	host := u.Hostname()
+	if strings.Contains(host, "*") {
+		rest, ok := strings.CutPrefix(host, "*.")
+		if !ok || strings.Contains(rest, "*") {
+			return "", errors.Errorf("无效的来源 %q，通配符只能作为最左侧的子域名，如 https://*.example.com", origin)
+		}
+		if !strings.Contains(rest, ".") {
+			return "", errors.Errorf("来源 %q 的通配范围过大，通配符之后至少需要两级域名", origin)
+		}
+	}
+
+	return u.Scheme + "://" + u.Host, nil
+}
+
+// originPattern 将校验过的精确来源或通配来源转为正则，通配符匹配一级或多级子域名
+func originPattern(origin string) *regexp.Regexp {
+	scheme, host, _ := strings.Cut(origin, "://*.")
+	if host == "" {
+		return regexp.MustCompile("^" + regexp.QuoteMeta(origin) + "$")
+	}
+	return regexp.MustCompile("^" + regexp.QuoteMeta(scheme+"://") + `[a-z0-9-]+(\.[a-z0-9-]+)*\.` + regexp.QuoteMeta(host) + "$")
+}
+
+// compileOriginRegex 编译正则来源，正则必须匹配完整的来源
+// 只检查是否以 ^ 开头、以 $ 结尾并不够，^https://a\.example\.com$|evil\.com$ 这样的分支仍能匹配任意以 evil.com 结尾的来源，
+// 因此整个表达式包在非捕获组中再锚定，已写的 ^ 和 $ 不影响匹配结果
+func compileOriginRegex(expr string) (*regexp.Regexp, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, errors.New("正则来源不能为空")
+	}
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return nil, errors.Wrapf(err, "无效的正则来源 %q", expr)
+	}
+	return re, nil
+}
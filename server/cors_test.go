@@ -0,0 +1,150 @@
+package server
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"doghole/config"
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestNewCORSValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy config.CORSPolicy
+		err    string
+	}{
+		{"精确来源", config.CORSPolicy{AllowOrigins: []string{"https://app.example.com"}}, ""},
+		{"子域名通配", config.CORSPolicy{AllowOrigins: []string{"https://*.example.com"}, AllowCredentials: true}, ""},
+		{"正则来源", config.CORSPolicy{AllowOrigins: []string{`regex:https://[a-z]+\.example\.org`}}, ""},
+		{"来源为空", config.CORSPolicy{}, "allow_origins 不能为空"},
+		{"携带凭据时允许任意来源", config.CORSPolicy{AllowOrigins: []string{"*"}, AllowCredentials: true}, "不能包含 *"},
+		{"携带凭据时允许任意请求头", config.CORSPolicy{AllowOrigins: []string{"https://app.example.com"}, AllowHeaders: []string{"*"}, AllowCredentials: true}, "allow_headers"},
+		{"来源为null", config.CORSPolicy{AllowOrigins: []string{"null"}}, "不能包含 null"},
+		{"通配顶级域名", config.CORSPolicy{AllowOrigins: []string{"https://*.com"}}, "通配范围过大"},
+		{"通配符不在最左侧", config.CORSPolicy{AllowOrigins: []string{"https://app.*.example.com"}}, "通配符只能作为最左侧的子域名"},
+		{"来源包含路径", config.CORSPolicy{AllowOrigins: []string{"https://app.example.com/path"}}, "不能包含路径"},
+		{"无效的正则", config.CORSPolicy{AllowOrigins: []string{"regex:https://(a"}}, "无效的正则来源"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCORS(config.CORSConfig{Enabled: true, Policies: []config.CORSPolicy{tt.policy}})
+			if tt.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("err = %v，期望包含 %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCORSOrigins(t *testing.T) {
+	c, err := NewCORS(config.CORSConfig{
+		Enabled: true,
+		Policies: []config.CORSPolicy{
+			{
+				Group: "/api",
+				AllowOrigins: []string{
+					"https://app.example.com",
+					"https://*.example.org",
+					// 分支只锚定了一端，整个表达式必须匹配完整的来源
+					`regex:^https://a\.example\.net$|evil\.com$`,
+					`regex:^https://b\.example\.net|x$`,
+				},
+				AllowCredentials: true,
+			},
+			{AllowOrigins: []string{"*"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+	app.Use(c.Handler())
+	app.Get("/*", func(c fiber.Ctx) error { return c.SendString("ok") })
+
+	tests := []struct {
+		name    string
+		path    string
+		origin  string
+		allowed string
+	}{
+		{"精确来源", "/api/users", "https://app.example.com", "https://app.example.com"},
+		{"精确来源的后缀域名", "/api/users", "https://app.example.com.attacker.net", ""},
+		{"通配一级子域名", "/api/users", "https://a.example.org", "https://a.example.org"},
+		{"通配多级子域名", "/api/users", "https://a.b.example.org", "https://a.b.example.org"},
+		{"通配不匹配裸域名", "/api/users", "https://example.org", ""},
+		{"通配不匹配其他协议", "/api/users", "http://a.example.org", ""},
+		{"通配不匹配相似域名", "/api/users", "https://a.badexample.org", ""},
+		{"正则来源", "/api/users", "https://a.example.net", "https://a.example.net"},
+		{"正则分支绕过", "/api/users", "https://attacker.evil.com", ""},
+		{"正则分支绕过2", "/api/users", "https://b.example.net.attacker.com/x", ""},
+		{"正则分支绕过3", "/api/users", "https://attacker.com/x", ""},
+		{"携带凭据的策略拒绝null", "/api/users", "null", ""},
+		{"其余路由允许任意来源", "/public", "https://any.site", "*"},
+		{"路由组前缀按路径段匹配", "/apis", "https://any.site", "*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, tt.path, nil)
+			req.Header.Set(fiber.HeaderOrigin, tt.origin)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := resp.Header.Get(fiber.HeaderAccessControlAllowOrigin); got != tt.allowed {
+				t.Fatalf("Access-Control-Allow-Origin = %q，期望 %q", got, tt.allowed)
+			}
+			// 允许任意来源时不能同时允许携带凭据
+			if resp.Header.Get(fiber.HeaderAccessControlAllowOrigin) == "*" && resp.Header.Get(fiber.HeaderAccessControlAllowCredentials) != "" {
+				t.Fatal("允许任意来源的响应携带了 Access-Control-Allow-Credentials")
+			}
+		})
+	}
+}
+
+func TestCORSReload(t *testing.T) {
+	c, err := NewCORS(config.CORSConfig{Enabled: true, Policies: []config.CORSPolicy{{AllowOrigins: []string{"https://a.example.com"}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := fiber.New()
+	app.Use(c.Handler())
+	app.Get("/", func(c fiber.Ctx) error { return c.SendString("ok") })
+
+	allowOrigin := func(origin string) string {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		req.Header.Set(fiber.HeaderOrigin, origin)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Header.Get(fiber.HeaderAccessControlAllowOrigin)
+	}
+
+	// 无效配置保留原有策略
+	if err := c.Reload(config.CORSConfig{Enabled: true, Policies: []config.CORSPolicy{{AllowOrigins: []string{"null"}}}}); err == nil {
+		t.Fatal("无效配置应返回错误")
+	}
+	if got := allowOrigin("https://a.example.com"); got != "https://a.example.com" {
+		t.Fatalf("原有策略失效: %q", got)
+	}
+
+	if err := c.Reload(config.CORSConfig{Enabled: true, Policies: []config.CORSPolicy{{AllowOrigins: []string{"https://b.example.com"}}}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := allowOrigin("https://a.example.com"); got != "" {
+		t.Fatalf("重新加载后仍允许旧来源: %q", got)
+	}
+	if got := allowOrigin("https://b.example.com"); got != "https://b.example.com" {
+		t.Fatalf("重新加载后不允许新来源: %q", got)
+	}
+}
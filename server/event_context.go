@@ -0,0 +1,22 @@
+package server
+
+import (
+	"doghole/auth"
+	"doghole/eventbus"
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/requestid"
+)
+
+// EventContext 将请求ID和操作者保存到请求的context中，随事件总线传播给订阅者
+// 操作者取自 Locals 中的用户ID，需挂载在设置用户ID的认证中间件之后才能获取
+func EventContext() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		md := eventbus.Metadata{
+			RequestID: requestid.FromContext(c),
+			Actor:     auth.UserID(c),
+		}
+
+		c.SetContext(eventbus.WithMetadata(c.Context(), md))
+		return c.Next()
+	}
+}
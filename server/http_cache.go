@@ -0,0 +1,243 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"doghole/auth"
+	"doghole/config"
+	"github.com/gofiber/fiber/v3"
+)
+
+// HeaderCache 标识响应是否来自服务端缓存的响应头
+const HeaderCache = "X-Cache"
+
+// cachedHeaders 写入服务端缓存的响应头
+var cachedHeaders = []string{
+	fiber.HeaderContentType,
+	fiber.HeaderContentEncoding,
+	fiber.HeaderCacheControl,
+	fiber.HeaderETag,
+	fiber.HeaderLastModified,
+	fiber.HeaderVary,
+}
+
+// cachedResponse 服务端缓存的响应
+type cachedResponse struct {
+	Status  int
+	Headers [][2]string
+	Body    []byte
+}
+
+// HTTPCache 条件请求与响应缓存中间件
+// 为GET和HEAD请求的200响应计算强ETag，按 If-None-Match 和 If-Modified-Since 返回304，
+// 并按路由组规则设置 Cache-Control 和 Vary；启用服务端缓存的路由组会缓存匿名请求的响应
+func HTTPCache(conf config.HTTPCacheConfig) fiber.Handler {
+	rules := make([]config.HTTPCacheRule, 0, len(conf.Rules))
+	for _, r := range conf.Rules {
+		r.Group = strings.TrimSuffix(r.Group, "/")
+		rules = append(rules, r)
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return len(rules[i].Group) > len(rules[j].Group)
+	})
+
+	var store *responseCache
+	for _, r := range rules {
+		if r.ServerCache {
+			store = newResponseCache(conf.MaxEntries)
+			break
+		}
+	}
+
+	maxBodySize := conf.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = 1 << 20
+	}
+
+	return func(c fiber.Ctx) error {
+		method := c.Method()
+		if method != fiber.MethodGet && method != fiber.MethodHead {
+			return c.Next()
+		}
+
+		rule := matchCacheRule(rules, c.Path())
+		useServerCache := store != nil && rule.ServerCache && method == fiber.MethodGet && isAnonymous(c)
+
+		var key string
+		if useServerCache {
+			key = serverCacheKey(c, rule.Vary)
+			if cached, ok := store.get(key); ok {
+				c.Status(cached.Status)
+				for _, h := range cached.Headers {
+					c.Set(h[0], h[1])
+				}
+				c.Set(HeaderCache, "HIT")
+				// 复制响应体，避免后续中间件修改缓存中的数据
+				c.Response().SetBody(cached.Body)
+				respondNotModified(c)
+				return nil
+			}
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		resp := c.Response()
+		status := resp.StatusCode()
+		if (status != fiber.StatusOK && status != fiber.StatusNotModified) || resp.IsBodyStream() {
+			return nil
+		}
+
+		// 304响应同样需要携带缓存策略
+		if rule.CacheControl != "" && len(resp.Header.Peek(fiber.HeaderCacheControl)) == 0 {
+			c.Set(fiber.HeaderCacheControl, rule.CacheControl)
+		}
+		for _, v := range rule.Vary {
+			c.Vary(v)
+		}
+		if status == fiber.StatusNotModified {
+			return nil
+		}
+
+		body := resp.Body()
+		if len(body) > maxBodySize {
+			return nil
+		}
+		if len(resp.Header.Peek(fiber.HeaderETag)) == 0 {
+			sum := sha256.Sum256(body)
+			c.Set(fiber.HeaderETag, `"`+hex.EncodeToString(sum[:16])+`"`)
+		}
+
+		if useServerCache && isStorable(c) {
+			cached := &cachedResponse{Status: resp.StatusCode(), Body: append([]byte(nil), body...)}
+			for _, h := range cachedHeaders {
+				if v := resp.Header.Peek(h); len(v) > 0 {
+					cached.Headers = append(cached.Headers, [2]string{h, string(v)})
+				}
+			}
+
+			ttl := rule.TTL
+			if ttl <= 0 {
+				ttl = time.Minute
+			}
+			store.set(key, cached, ttl)
+			c.Set(HeaderCache, "MISS")
+		}
+
+		respondNotModified(c)
+		return nil
+	}
+}
+
+// SetETag 使用实体版本设置强ETag，未设置时中间件根据响应体计算
+func SetETag(c fiber.Ctx, version string) {
+	c.Set(fiber.HeaderETag, `"`+strings.Trim(version, `"`)+`"`)
+}
+
+// SetLastModified 设置 Last-Modified 响应头，用于 If-Modified-Since 判断
+func SetLastModified(c fiber.Ctx, t time.Time) {
+	c.Set(fiber.HeaderLastModified, t.UTC().Format(http.TimeFormat))
+}
+
+// NotModified 根据已设置的 ETag 和 Last-Modified 判断客户端缓存是否仍然有效
+// 处理函数可在设置校验值后调用，命中时直接返回304以跳过查询和序列化：
+//
+//	server.SetETag(c, strconv.Itoa(u.Version))
+//	if server.NotModified(c) {
+//		return c.SendStatus(fiber.StatusNotModified)
+//	}
+func NotModified(c fiber.Ctx) bool {
+	if inm := c.Get(fiber.HeaderIfNoneMatch); inm != "" {
+		etag := string(c.Response().Header.Peek(fiber.HeaderETag))
+		return etag != "" && etagMatch(inm, etag)
+	}
+
+	ims := c.Get(fiber.HeaderIfModifiedSince)
+	lastModified := string(c.Response().Header.Peek(fiber.HeaderLastModified))
+	if ims == "" || lastModified == "" {
+		return false
+	}
+
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
+
+// respondNotModified 客户端缓存有效时将响应改为304
+func respondNotModified(c fiber.Ctx) {
+	if !NotModified(c) {
+		return
+	}
+	c.Status(fiber.StatusNotModified)
+	c.Response().ResetBody()
+	c.Response().Header.Del(fiber.HeaderContentLength)
+}
+
+// etagMatch 按弱比较判断 If-None-Match 是否包含指定ETag
+func etagMatch(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// matchCacheRule 按最长前缀匹配路由组缓存规则
+func matchCacheRule(rules []config.HTTPCacheRule, path string) config.HTTPCacheRule {
+	for _, r := range rules {
+		if path == r.Group || strings.HasPrefix(path, r.Group+"/") || r.Group == "" {
+			return r
+		}
+	}
+	return config.HTTPCacheRule{}
+}
+
+// isAnonymous 判断请求是否未携带身份凭据且未通过认证
+func isAnonymous(c fiber.Ctx) bool {
+	return auth.UserID(c) == "" &&
+		c.Get(fiber.HeaderAuthorization) == "" &&
+		c.Get(fiber.HeaderCookie) == "" &&
+		c.Get("X-API-Key") == ""
+}
+
+// isStorable 判断响应是否允许写入服务端缓存
+func isStorable(c fiber.Ctx) bool {
+	resp := c.Response()
+	if len(resp.Header.Peek(fiber.HeaderSetCookie)) > 0 {
+		return false
+	}
+	cc := strings.ToLower(string(resp.Header.Peek(fiber.HeaderCacheControl)))
+	return !strings.Contains(cc, "no-store") && !strings.Contains(cc, "private")
+}
+
+// serverCacheKey 生成服务端缓存键，包含主机名、路径、查询参数和 Vary 请求头的值
+// 同一服务绑定多个域名时，不同域名的响应可能不同（如绝对链接），不能共用缓存
+func serverCacheKey(c fiber.Ctx, vary []string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(c.Host()))
+	b.WriteString(c.Path())
+	b.WriteByte('?')
+	b.Write(c.Request().URI().QueryString())
+	for _, h := range vary {
+		b.WriteByte('\n')
+		b.WriteString(strings.ToLower(h))
+		b.WriteByte(':')
+		b.WriteString(c.Get(h))
+	}
+	return b.String()
+}
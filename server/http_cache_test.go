@@ -0,0 +1,273 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"doghole/config"
+	"github.com/gofiber/fiber/v3"
+)
+
+// cacheGet 发送GET请求，headers 为成对的请求头名称和值
+func cacheGet(t *testing.T, app *fiber.App, path string, headers ...string) (*http.Response, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodGet, path, nil)
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(body)
+}
+
+func TestHTTPCacheConditional(t *testing.T) {
+	modified := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	app := fiber.New()
+	app.Use(HTTPCache(config.HTTPCacheConfig{
+		Enabled: true,
+		Rules: []config.HTTPCacheRule{
+			{Group: "/items", CacheControl: "public, max-age=60", Vary: []string{"Accept-Language"}},
+		},
+	}))
+	app.Get("/items", func(c fiber.Ctx) error { return c.SendString("items") })
+	app.Get("/items/versioned", func(c fiber.Ctx) error {
+		SetETag(c, "v7")
+		SetLastModified(c, modified)
+		if NotModified(c) {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+		return c.SendString("versioned")
+	})
+	app.Post("/items", func(c fiber.Ctx) error { return c.SendString("created") })
+
+	resp, body := cacheGet(t, app, "/items")
+	etag := resp.Header.Get(fiber.HeaderETag)
+	if resp.StatusCode != fiber.StatusOK || body != "items" || !strings.HasPrefix(etag, `"`) {
+		t.Fatalf("首次请求: %d %q ETag=%q", resp.StatusCode, body, etag)
+	}
+	if cc := resp.Header.Get(fiber.HeaderCacheControl); cc != "public, max-age=60" {
+		t.Fatalf("Cache-Control = %q", cc)
+	}
+	if vary := resp.Header.Get(fiber.HeaderVary); !strings.Contains(vary, "Accept-Language") {
+		t.Fatalf("Vary = %q", vary)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		headers []string
+		status  int
+	}{
+		{"ETag匹配", "/items", []string{fiber.HeaderIfNoneMatch, etag}, fiber.StatusNotModified},
+		{"弱比较匹配", "/items", []string{fiber.HeaderIfNoneMatch, `"other", W/` + etag}, fiber.StatusNotModified},
+		{"ETag不匹配", "/items", []string{fiber.HeaderIfNoneMatch, `"other"`}, fiber.StatusOK},
+		{"处理函数设置的版本", "/items/versioned", []string{fiber.HeaderIfNoneMatch, `"v7"`}, fiber.StatusNotModified},
+		{"版本已变化", "/items/versioned", []string{fiber.HeaderIfNoneMatch, `"v6"`}, fiber.StatusOK},
+		{"未修改", "/items/versioned", []string{fiber.HeaderIfModifiedSince, modified.Format(http.TimeFormat)}, fiber.StatusNotModified},
+		{"已修改", "/items/versioned", []string{fiber.HeaderIfModifiedSince, modified.Add(-time.Second).Format(http.TimeFormat)}, fiber.StatusOK},
+		// 同时携带时以 If-None-Match 为准
+		{"ETag优先", "/items/versioned", []string{fiber.HeaderIfNoneMatch, `"v6"`, fiber.HeaderIfModifiedSince, modified.Format(http.TimeFormat)}, fiber.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := cacheGet(t, app, tt.path, tt.headers...)
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d，期望 %d", resp.StatusCode, tt.status)
+			}
+			if tt.status == fiber.StatusNotModified {
+				if body != "" {
+					t.Fatalf("304响应携带了响应体 %q", body)
+				}
+				if resp.Header.Get(fiber.HeaderCacheControl) == "" || resp.Header.Get(fiber.HeaderETag) == "" {
+					t.Fatal("304响应缺少 Cache-Control 或 ETag")
+				}
+			}
+		})
+	}
+
+	// 非GET请求不计算ETag
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/items", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Header.Get(fiber.HeaderETag) != "" {
+		t.Fatal("POST响应不应设置ETag")
+	}
+}
+
+// serverCacheApp 创建启用服务端缓存的测试应用，返回处理函数的执行次数
+func serverCacheApp(maxEntries int) (*fiber.App, *atomic.Int32) {
+	var calls atomic.Int32
+	app := fiber.New()
+	app.Use(testAuth, HTTPCache(config.HTTPCacheConfig{
+		Enabled:    true,
+		MaxEntries: maxEntries,
+		Rules: []config.HTTPCacheRule{
+			{Group: "/public", ServerCache: true, TTL: time.Minute, Vary: []string{"Accept-Language"}},
+		},
+	}))
+	app.Get("/public/:name", func(c fiber.Ctx) error {
+		n := calls.Add(1)
+		switch c.Params("name") {
+		case "cookie":
+			c.Cookie(&fiber.Cookie{Name: "session", Value: "s"})
+		case "private":
+			c.Set(fiber.HeaderCacheControl, "private")
+		}
+		return c.SendString(fmt.Sprintf("%s-%d", c.Params("name"), n))
+	})
+	return app, &calls
+}
+
+func TestHTTPCacheServerCache(t *testing.T) {
+	app, calls := serverCacheApp(100)
+
+	resp, first := cacheGet(t, app, "/public/a")
+	if resp.Header.Get(HeaderCache) != "MISS" {
+		t.Fatalf("首次请求 X-Cache = %q", resp.Header.Get(HeaderCache))
+	}
+	resp, body := cacheGet(t, app, "/public/a")
+	if resp.Header.Get(HeaderCache) != "HIT" || body != first {
+		t.Fatalf("重复请求: X-Cache=%q %q", resp.Header.Get(HeaderCache), body)
+	}
+	// 缓存命中时同样响应条件请求
+	resp, _ = cacheGet(t, app, "/public/a", fiber.HeaderIfNoneMatch, resp.Header.Get(fiber.HeaderETag))
+	if resp.StatusCode != fiber.StatusNotModified || resp.Header.Get(HeaderCache) != "HIT" {
+		t.Fatalf("条件请求: %d X-Cache=%q", resp.StatusCode, resp.Header.Get(HeaderCache))
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("处理函数执行了 %d 次", n)
+	}
+
+	// 只缓存匿名请求，携带身份凭据或已认证的请求不读写缓存
+	tests := []struct {
+		name    string
+		path    string
+		headers []string
+	}{
+		{"已认证用户", "/public/a", []string{"X-Test-User", "alice"}},
+		{"Authorization", "/public/a", []string{fiber.HeaderAuthorization, "Bearer t"}},
+		{"Cookie", "/public/a", []string{fiber.HeaderCookie, "session=s"}},
+		{"API Key", "/public/a", []string{"X-API-Key", "k"}},
+		{"设置Cookie的响应", "/public/cookie", nil},
+		{"私有响应", "/public/private", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := calls.Load()
+			for i := 0; i < 2; i++ {
+				resp, body := cacheGet(t, app, tt.path, tt.headers...)
+				if resp.Header.Get(HeaderCache) == "HIT" || body == first {
+					t.Fatalf("第%d次请求使用了缓存的响应", i+1)
+				}
+			}
+			if n := calls.Load() - before; n != 2 {
+				t.Fatalf("处理函数执行了 %d 次", n)
+			}
+		})
+	}
+
+	// Vary 请求头的值不同时使用不同的缓存项
+	resp, _ = cacheGet(t, app, "/public/a", "Accept-Language", "en")
+	if resp.Header.Get(HeaderCache) != "MISS" {
+		t.Fatalf("不同语言 X-Cache = %q", resp.Header.Get(HeaderCache))
+	}
+}
+
+func TestHTTPCacheEviction(t *testing.T) {
+	app, calls := serverCacheApp(2)
+
+	steps := []struct {
+		path  string
+		cache string
+	}{
+		{"/public/a", "MISS"},
+		{"/public/b", "MISS"},
+		{"/public/a", "HIT"},
+		// 超过容量时淘汰最久未使用的 b
+		{"/public/c", "MISS"},
+		{"/public/a", "HIT"},
+		{"/public/b", "MISS"},
+		// 写入 b 时淘汰了 c
+		{"/public/a", "HIT"},
+		{"/public/c", "MISS"},
+	}
+	for i, s := range steps {
+		resp, _ := cacheGet(t, app, s.path)
+		if got := resp.Header.Get(HeaderCache); got != s.cache {
+			t.Fatalf("第%d步 %s X-Cache = %q，期望 %q", i+1, s.path, got, s.cache)
+		}
+	}
+	if n := calls.Load(); n != 5 {
+		t.Fatalf("处理函数执行了 %d 次", n)
+	}
+}
+
+func TestResponseCacheExpiry(t *testing.T) {
+	rc := newResponseCache(10)
+	rc.set("k", &cachedResponse{Status: fiber.StatusOK}, 20*time.Millisecond)
+	if _, ok := rc.get("k"); !ok {
+		t.Fatal("未过期的缓存项不存在")
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, ok := rc.get("k"); ok {
+		t.Fatal("过期的缓存项仍然可用")
+	}
+	if rc.ll.Len() != 0 || len(rc.items) != 0 {
+		t.Fatal("过期的缓存项没有被删除")
+	}
+}
+
+func TestHTTPCacheCompression(t *testing.T) {
+	app := fiber.New()
+	app.Use(
+		Compression(config.CompressionConfig{Encodings: []string{EncodingGzip}, ContentTypes: []string{"text/*"}}),
+		HTTPCache(config.HTTPCacheConfig{Enabled: true}),
+	)
+	payload := strings.Repeat("doghole ", 200)
+	app.Get("/text", func(c fiber.Ctx) error { return c.SendString(payload) })
+
+	resp, _ := cacheGet(t, app, "/text")
+	strong := resp.Header.Get(fiber.HeaderETag)
+	if resp.Header.Get(fiber.HeaderContentEncoding) != "" || !strings.HasPrefix(strong, `"`) {
+		t.Fatalf("未压缩的响应: Content-Encoding=%q ETag=%q", resp.Header.Get(fiber.HeaderContentEncoding), strong)
+	}
+
+	// 压缩后的响应与原始内容不再逐字节相同，使用弱ETag
+	resp, _ = cacheGet(t, app, "/text", fiber.HeaderAcceptEncoding, "gzip")
+	if resp.Header.Get(fiber.HeaderContentEncoding) != EncodingGzip {
+		t.Fatalf("Content-Encoding = %q", resp.Header.Get(fiber.HeaderContentEncoding))
+	}
+	weak := resp.Header.Get(fiber.HeaderETag)
+	if weak != "W/"+strong {
+		t.Fatalf("压缩响应的 ETag = %q，期望 %q", weak, "W/"+strong)
+	}
+	if vary := resp.Header.Get(fiber.HeaderVary); !strings.Contains(vary, fiber.HeaderAcceptEncoding) {
+		t.Fatalf("Vary = %q", vary)
+	}
+
+	// 客户端持有弱ETag或强ETag时都能得到304
+	for _, etag := range []string{weak, strong} {
+		resp, body := cacheGet(t, app, "/text", fiber.HeaderAcceptEncoding, "gzip", fiber.HeaderIfNoneMatch, etag)
+		if resp.StatusCode != fiber.StatusNotModified || body != "" {
+			t.Fatalf("If-None-Match %s: %d %q", etag, resp.StatusCode, body)
+		}
+		if resp.Header.Get(fiber.HeaderContentEncoding) != "" {
+			t.Fatal("304响应不应压缩")
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"doghole/auth"
+	"doghole/config"
+	"doghole/domain/conn"
+	"doghole/ent"
+	"doghole/ent/idempotencykey"
+	"doghole/logger"
+	"github.com/gofiber/fiber/v3"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// HeaderIdempotencyKey 幂等键请求头
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// HeaderIdempotentReplayed 标记响应为重放结果的响应头
+const HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+// idempotencySkipHeaders 重放时不保存的响应头
+var idempotencySkipHeaders = map[string]struct{}{
+	fiber.HeaderDate:             {},
+	fiber.HeaderContentLength:    {},
+	fiber.HeaderConnection:       {},
+	fiber.HeaderTransferEncoding: {},
+	fiber.HeaderServer:           {},
+	fiber.HeaderXRequestID:       {},
+	fiber.HeaderSetCookie:        {},
+}
+
+// idempotencySaveTimeout 保存或释放幂等键的超时，不受请求处理超时影响
+const idempotencySaveTimeout = 5 * time.Second
+
+// Idempotency 幂等键中间件
+// 对携带 Idempotency-Key 的 POST/PATCH 请求保存响应，重试时直接重放；
+// 同一键携带不同请求体返回 422，同一键的并发请求返回 409。
+// 幂等键按调用方（用户、租户或匿名请求的IP）隔离；处理期间定期续期租约，
+// 租约到期后（如进程崩溃）重试请求可以接管。
+func Idempotency(cfg config.IdempotencyConfig) fiber.Handler {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 24 * time.Hour
+	}
+	if cfg.LockTimeout <= 0 {
+		cfg.LockTimeout = time.Minute
+	}
+
+	return func(c fiber.Ctx) error {
+		if !cfg.Enabled {
+			return c.Next()
+		}
+
+		method := c.Method()
+		if method != fiber.MethodPost && method != fiber.MethodPatch {
+			return c.Next()
+		}
+
+		key := c.Get(HeaderIdempotencyKey)
+		if key == "" {
+			return c.Next()
+		}
+		if len(key) > 255 {
+			return fiber.NewError(fiber.StatusBadRequest, "Idempotency-Key 长度不能超过255")
+		}
+
+		ctx := c.Context()
+		scope := idempotencyScope(c)
+		fingerprint := requestFingerprint(c)
+
+		record, err := acquireIdempotencyKey(ctx, scope, key, method, c.Path(), fingerprint, cfg)
+		if err != nil {
+			return err
+		}
+
+		if record != nil {
+			// 未读取的流式请求体会残留在连接中，不执行处理函数时关闭连接
+			if c.Locals(localsBodyStream) != nil {
+				c.Response().SetConnectionClose()
+			}
+			if record.Fingerprint != fingerprint {
+				return fiber.NewError(fiber.StatusUnprocessableEntity, "Idempotency-Key 已被用于不同的请求")
+			}
+			if record.State == idempotencykey.StateProcessing {
+				return fiber.NewError(fiber.StatusConflict, "相同 Idempotency-Key 的请求正在处理中")
+			}
+			return replayIdempotentResponse(c, record)
+		}
+
+		// 处理请求期间续期租约，避免处理时间超过租约时被重试请求接管；
+		// 处理函数panic时同样停止续期，租约到期后重试请求可以接管
+		stop := renewIdempotencyKey(ctx, scope, key, cfg.LockTimeout)
+		defer stop()
+		err = c.Next()
+		stop()
+
+		// 路由组超时会取消请求的context，保存和释放使用独立的context，避免幂等键一直停留在处理中
+		saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), idempotencySaveTimeout)
+		defer cancel()
+
+		// 处理失败时释放幂等键以便客户端重试
+		if err != nil {
+			releaseIdempotencyKey(saveCtx, scope, key)
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		body := c.Response().Body()
+		if status >= fiber.StatusInternalServerError || (cfg.MaxBodySize > 0 && len(body) > cfg.MaxBodySize) {
+			releaseIdempotencyKey(saveCtx, scope, key)
+			return nil
+		}
+
+		headers := make(map[string][]string)
+		for k, v := range c.GetRespHeaders() {
+			if _, skip := idempotencySkipHeaders[http.CanonicalHeaderKey(k)]; skip {
+				continue
+			}
+			headers[k] = v
+		}
+
+		err = conn.Writer().IdempotencyKey.Update().
+			Where(idempotencykey.Scope(scope), idempotencykey.Key(key)).
+			SetState(idempotencykey.StateCompleted).
+			ClearLockedUntil().
+			SetStatusCode(status).
+			SetHeaders(headers).
+			SetBody(append([]byte(nil), body...)).
+			Exec(saveCtx)
+		if err != nil {
+			logger.Ctx(ctx).Error("保存幂等响应失败", zap.String("key", key), zap.Error(err))
+		}
+
+		return nil
+	}
+}
+
+// idempotencyScope 返回幂等键的作用域，不同用户或租户使用相同的键互不影响，
+// 匿名请求按IP隔离，避免一个客户端的幂等键重放给另一个客户端
+func idempotencyScope(c fiber.Ctx) string {
+	if id := auth.UserID(c); id != "" {
+		return "user:" + id
+	}
+	if id := auth.TenantID(c); id != "" {
+		return "tenant:" + id
+	}
+	return "ip:" + c.IP()
+}
+
+// renewIdempotencyKey 在后台每隔三分之一租约时长续期处理中的幂等键，返回停止续期的函数，可多次调用
+func renewIdempotencyKey(ctx context.Context, scope, key string, lease time.Duration) func() {
+	var once sync.Once
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(lease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				renewCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), idempotencySaveTimeout)
+				_, err := conn.Writer().IdempotencyKey.Update().
+					Where(
+						idempotencykey.Scope(scope),
+						idempotencykey.Key(key),
+						idempotencykey.StateEQ(idempotencykey.StateProcessing),
+					).
+					SetLockedUntil(now.Add(lease)).
+					Save(renewCtx)
+				cancel()
+				if err != nil {
+					logger.Ctx(ctx).Warn("续期幂等键失败", zap.String("key", key), zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	return func() {
+		once.Do(func() { close(done) })
+		<-stopped
+	}
+}
+
+// acquireIdempotencyKey 尝试占用幂等键
+// 占用成功时返回nil记录；键已存在时返回已有记录，处理中的记录租约已到期且请求相同时由本次请求接管
+func acquireIdempotencyKey(ctx context.Context, scope, key, method, path, fingerprint string, cfg config.IdempotencyConfig) (*ent.IdempotencyKey, error) {
+	client := conn.Writer()
+
+	for attempt := 0; attempt < 2; attempt++ {
+		now := time.Now()
+		existing, err := client.IdempotencyKey.Query().
+			Where(idempotencykey.Scope(scope), idempotencykey.Key(key)).
+			Only(ctx)
+		switch {
+		case err == nil && !existing.ExpiresAt.After(now):
+			// 已过期的键可以被重新使用
+			if _, err := client.IdempotencyKey.Delete().
+				Where(idempotencykey.ID(existing.ID)).
+				Exec(ctx); err != nil {
+				return nil, errors.Wrap(err, "删除过期幂等键失败")
+			}
+		case err == nil && existing.Fingerprint == fingerprint && lockExpired(existing, now):
+			// 处理该请求的进程已崩溃或处理函数panic，按租约条件更新，并发的重试请求只有一个能接管
+			n, err := client.IdempotencyKey.Update().
+				Where(
+					idempotencykey.ID(existing.ID),
+					idempotencykey.StateEQ(idempotencykey.StateProcessing),
+					idempotencykey.Or(idempotencykey.LockedUntilIsNil(), idempotencykey.LockedUntilLT(now)),
+				).
+				SetLockedUntil(now.Add(cfg.LockTimeout)).
+				SetExpiresAt(now.Add(cfg.TTL)).
+				Save(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "接管幂等键失败")
+			}
+			if n == 1 {
+				return nil, nil
+			}
+			continue
+		case err == nil:
+			return existing, nil
+		case !ent.IsNotFound(err):
+			return nil, errors.Wrap(err, "查询幂等键失败")
+		}
+
+		err = client.IdempotencyKey.Create().
+			SetScope(scope).
+			SetKey(key).
+			SetMethod(method).
+			SetPath(path).
+			SetFingerprint(fingerprint).
+			SetLockedUntil(now.Add(cfg.LockTimeout)).
+			SetExpiresAt(now.Add(cfg.TTL)).
+			Exec(ctx)
+		if err == nil {
+			return nil, nil
+		}
+		if !ent.IsConstraintError(err) {
+			return nil, errors.Wrap(err, "保存幂等键失败")
+		}
+		// 并发请求抢先写入了同一个键，重新查询
+	}
+
+	return nil, fiber.NewError(fiber.StatusConflict, "相同 Idempotency-Key 的请求正在处理中")
+}
+
+// lockExpired 判断处理中的记录租约是否已到期，升级前遗留的没有租约的记录视为已到期
+func lockExpired(record *ent.IdempotencyKey, now time.Time) bool {
+	if record.State != idempotencykey.StateProcessing {
+		return false
+	}
+	return record.LockedUntil == nil || record.LockedUntil.Before(now)
+}
+
+// releaseIdempotencyKey 释放处理失败的幂等键
+func releaseIdempotencyKey(ctx context.Context, scope, key string) {
+	if _, err := conn.Writer().IdempotencyKey.Delete().
+		Where(
+			idempotencykey.Scope(scope),
+			idempotencykey.Key(key),
+			idempotencykey.StateEQ(idempotencykey.StateProcessing),
+		).
+		Exec(ctx); err != nil {
+		logger.Ctx(ctx).Error("释放幂等键失败", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// replayIdempotentResponse 重放已保存的响应
+func replayIdempotentResponse(c fiber.Ctx, record *ent.IdempotencyKey) error {
+	for k, values := range record.Headers {
+		c.Response().Header.Del(k)
+		for _, v := range values {
+			c.Response().Header.Add(k, v)
+		}
+	}
+	c.Set(HeaderIdempotentReplayed, "true")
+	return c.Status(record.StatusCode).Send(record.Body)
+}
+
+// requestFingerprint 计算请求指纹
+// 流式请求体的路由组（如大文件上传）不读取请求体，只使用请求体长度和类型，避免将整个请求体读入内存
+func requestFingerprint(c fiber.Ctx) string {
+	h := sha256.New()
+	h.Write([]byte(c.Method()))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(c.Path()))
+	h.Write([]byte{'\n'})
+	if c.Locals(localsBodyStream) != nil {
+		h.Write([]byte(c.Get(fiber.HeaderContentType)))
+		h.Write([]byte{'\n'})
+		h.Write([]byte(strconv.Itoa(c.Request().Header.ContentLength())))
+	} else {
+		h.Write(c.Body())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// PurgeExpiredIdempotencyKeys 清理已过期的幂等键，返回删除数量
+func PurgeExpiredIdempotencyKeys(ctx context.Context) (int, error) {
+	n, err := conn.Writer().IdempotencyKey.Delete().
+		Where(idempotencykey.ExpiresAtLT(time.Now())).
+		Exec(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "清理过期幂等键失败")
+	}
+	return n, nil
+}
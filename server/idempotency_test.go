@@ -0,0 +1,214 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"doghole/config"
+	"doghole/domain/conn"
+	"doghole/ent"
+	"doghole/ent/enttest"
+	"doghole/ent/idempotencykey"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/gofiber/fiber/v3"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openWriter 打开测试数据库并设置为全局写入连接
+func openWriter(t *testing.T) *ent.Client {
+	t.Helper()
+
+	drv, err := entsql.Open(dialect.SQLite, fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", strings.ReplaceAll(t.Name(), "/", "_")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 共享缓存的内存数据库不支持并发写入，测试中的并发请求共用一个连接
+	drv.DB().SetMaxOpenConns(1)
+	client := enttest.NewClient(t, enttest.WithOptions(ent.Driver(drv)))
+
+	conn.SetWriteConn(client)
+	t.Cleanup(func() {
+		conn.SetWriteConn(nil)
+		client.Close()
+	})
+	return client
+}
+
+// idempotencyApp 创建挂载幂等键中间件的测试应用，认证方式与 testAuth 相同，X-Forwarded-For 作为客户端IP
+func idempotencyApp(cfg config.IdempotencyConfig, routes []config.RouteConfig, handler fiber.Handler) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ProxyHeader:       fiber.HeaderXForwardedFor,
+		BodyLimit:         4 * 1024,
+		StreamRequestBody: true,
+	})
+	app.Use(RoutePolicies(routes, 4*1024), testAuth, Idempotency(cfg))
+	app.Post("/*", handler)
+	return app
+}
+
+// post 发送携带幂等键的POST请求，headers 为成对的请求头名称和值
+func post(t *testing.T, app *fiber.App, path, key, body string, headers ...string) (int, string, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(HeaderIdempotencyKey, key)
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, err := app.Test(req, fiber.TestConfig{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(data), resp.Header.Get(HeaderIdempotentReplayed)
+}
+
+func TestIdempotencyReplay(t *testing.T) {
+	openWriter(t)
+
+	var calls atomic.Int32
+	app := idempotencyApp(config.IdempotencyConfig{Enabled: true}, nil, func(c fiber.Ctx) error {
+		n := calls.Add(1)
+		return c.Status(fiber.StatusCreated).SendString(fmt.Sprintf("order-%d", n))
+	})
+
+	status, body, replayed := post(t, app, "/orders", "k1", `{"sku":1}`)
+	if status != fiber.StatusCreated || body != "order-1" || replayed != "" {
+		t.Fatalf("首次请求: %d %q %q", status, body, replayed)
+	}
+
+	status, body, replayed = post(t, app, "/orders", "k1", `{"sku":1}`)
+	if status != fiber.StatusCreated || body != "order-1" || replayed != "true" {
+		t.Fatalf("重试应重放首次响应: %d %q %q", status, body, replayed)
+	}
+
+	if status, _, _ := post(t, app, "/orders", "k1", `{"sku":2}`); status != fiber.StatusUnprocessableEntity {
+		t.Fatalf("相同的键用于不同请求体 status = %d", status)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("处理函数执行了 %d 次", n)
+	}
+}
+
+func TestIdempotencyScope(t *testing.T) {
+	openWriter(t)
+
+	var calls atomic.Int32
+	app := idempotencyApp(config.IdempotencyConfig{Enabled: true}, nil, func(c fiber.Ctx) error {
+		calls.Add(1)
+		return c.SendString("ok")
+	})
+
+	tests := []struct {
+		name    string
+		headers []string
+	}{
+		{"匿名客户端A", []string{fiber.HeaderXForwardedFor, "198.51.100.1"}},
+		{"匿名客户端B", []string{fiber.HeaderXForwardedFor, "198.51.100.2"}},
+		{"用户alice", []string{fiber.HeaderXForwardedFor, "198.51.100.1", "X-Test-User", "alice"}},
+		{"用户bob", []string{fiber.HeaderXForwardedFor, "198.51.100.1", "X-Test-User", "bob"}},
+	}
+	// 不同调用方使用相同的键互不影响，都不会重放其他调用方的响应
+	for _, tt := range tests {
+		if _, _, replayed := post(t, app, "/orders", "shared", "{}", tt.headers...); replayed != "" {
+			t.Errorf("%s 重放了其他调用方的响应", tt.name)
+		}
+	}
+	if n := calls.Load(); n != int32(len(tests)) {
+		t.Fatalf("处理函数执行了 %d 次，期望 %d", n, len(tests))
+	}
+
+	// 同一调用方重试时重放
+	if _, _, replayed := post(t, app, "/orders", "shared", "{}", tests[1].headers...); replayed != "true" {
+		t.Error("同一匿名客户端的重试应重放")
+	}
+}
+
+func TestIdempotencyLeaseRenewal(t *testing.T) {
+	openWriter(t)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	app := idempotencyApp(config.IdempotencyConfig{Enabled: true, LockTimeout: 60 * time.Millisecond}, nil, func(c fiber.Ctx) error {
+		started <- struct{}{}
+		<-release
+		return c.SendString("done")
+	})
+
+	first := make(chan int, 1)
+	go func() {
+		status, _, _ := post(t, app, "/exports", "slow", "{}")
+		first <- status
+	}()
+	<-started
+
+	// 处理时间超过租约时长，租约被续期，重试请求不能接管
+	time.Sleep(200 * time.Millisecond)
+	if status, _, _ := post(t, app, "/exports", "slow", "{}"); status != fiber.StatusConflict {
+		t.Fatalf("处理中的请求被接管: status = %d", status)
+	}
+
+	close(release)
+	if status := <-first; status != fiber.StatusOK {
+		t.Fatalf("首次请求 status = %d", status)
+	}
+	if _, body, replayed := post(t, app, "/exports", "slow", "{}"); replayed != "true" || body != "done" {
+		t.Fatalf("完成后应重放: %q %q", body, replayed)
+	}
+}
+
+func TestIdempotencyRouteTimeout(t *testing.T) {
+	client := openWriter(t)
+
+	routes := []config.RouteConfig{{Group: "/exports", Timeout: 30 * time.Millisecond}}
+	app := idempotencyApp(config.IdempotencyConfig{Enabled: true}, routes, func(c fiber.Ctx) error {
+		<-c.Context().Done()
+		return c.Context().Err()
+	})
+
+	if status, _, _ := post(t, app, "/exports", "timeout", "{}"); status != fiber.StatusServiceUnavailable {
+		t.Fatalf("status = %d", status)
+	}
+
+	// 请求的context已取消，幂等键仍应被释放而不是停留在处理中
+	n, err := client.IdempotencyKey.Query().Where(idempotencykey.Key("timeout")).Count(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Fatal("超时的请求没有释放幂等键")
+	}
+}
+
+func TestIdempotencyStreamBody(t *testing.T) {
+	openWriter(t)
+
+	routes := []config.RouteConfig{{Group: "/files", StreamBody: true, BodyLimit: 4 << 20}}
+	app := idempotencyApp(config.IdempotencyConfig{Enabled: true}, routes, func(c fiber.Ctx) error {
+		n, err := io.Copy(io.Discard, RequestBodyStream(c))
+		if err != nil {
+			return err
+		}
+		return c.SendString(fmt.Sprint(n))
+	})
+
+	// 流式路由组的请求体不应被幂等键中间件读入内存，处理函数仍能读取完整的请求体
+	body := string(bytes.Repeat([]byte("a"), 1<<20))
+	if status, got, _ := post(t, app, "/files", "upload", body); status != fiber.StatusOK || got != fmt.Sprint(1<<20) {
+		t.Fatalf("status = %d，处理函数读取了 %s 字节", status, got)
+	}
+	if _, got, replayed := post(t, app, "/files", "upload", body); replayed != "true" || got != fmt.Sprint(1<<20) {
+		t.Fatalf("重试应重放: %q %q", got, replayed)
+	}
+}
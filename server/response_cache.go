@@ -0,0 +1,81 @@
+package server
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// responseEntry 响应缓存项
+type responseEntry struct {
+	key       string
+	resp      *cachedResponse
+	expiresAt time.Time
+}
+
+// responseCache 进程内LRU响应缓存，超过容量时淘汰最久未使用的响应
+// 缓存项直接保存响应结构，读取时无需反序列化；缓存的响应只读，不能修改
+type responseCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+// newResponseCache 创建响应缓存，maxEntries 为最大缓存项数
+func newResponseCache(maxEntries int) *responseCache {
+	if maxEntries <= 0 {
+		maxEntries = 10000
+	}
+	return &responseCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// get 读取未过期的响应
+func (rc *responseCache) get(key string) (*cachedResponse, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	el, ok := rc.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*responseEntry)
+	if time.Now().After(entry.expiresAt) {
+		rc.removeElement(el)
+		return nil, false
+	}
+
+	rc.ll.MoveToFront(el)
+	return entry.resp, true
+}
+
+// set 写入响应，已存在时覆盖
+func (rc *responseCache) set(key string, resp *cachedResponse, ttl time.Duration) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if el, ok := rc.items[key]; ok {
+		entry := el.Value.(*responseEntry)
+		entry.resp = resp
+		entry.expiresAt = expiresAt
+		rc.ll.MoveToFront(el)
+		return
+	}
+
+	rc.items[key] = rc.ll.PushFront(&responseEntry{key: key, resp: resp, expiresAt: expiresAt})
+	for rc.ll.Len() > rc.maxEntries {
+		rc.removeElement(rc.ll.Back())
+	}
+}
+
+// removeElement 删除缓存项，调用方需持有锁
+func (rc *responseCache) removeElement(el *list.Element) {
+	rc.ll.Remove(el)
+	delete(rc.items, el.Value.(*responseEntry).key)
+}
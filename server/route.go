@@ -3,10 +3,22 @@ package server
 import (
 	"time"
 
+	"doghole/auth"
+	"doghole/changefeed"
+	"doghole/config"
+	"doghole/domain/avatar"
+	"doghole/domain/file"
+	"doghole/domain/notification"
+	"doghole/domain/webhook"
+	"doghole/health"
+	applogger "doghole/logger"
+	"doghole/metrics"
+	"doghole/ratelimit"
+	"doghole/tracing"
 	"github.com/gofiber/fiber/v3"
-	"github.com/gofiber/fiber/v3/middleware/cors"
 	"github.com/gofiber/fiber/v3/middleware/logger"
 	"github.com/gofiber/fiber/v3/middleware/requestid"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
@@ -20,9 +32,10 @@ func CustomLogger() fiber.Handler {
 	return func(ctx fiber.Ctx) error {
 		start := time.Now()
 		requestID := ctx.Locals("requestid")
+		log := applogger.Ctx(ctx.Context())
 
 		// 记录请求信息
-		zap.L().Debug("请求接收",
+		log.Debug("请求接收",
 			zap.String("method", ctx.Method()),
 			zap.String("path", ctx.Path()),
 			zap.String("ip", ctx.IP()),
@@ -37,12 +50,12 @@ func CustomLogger() fiber.Handler {
 		latency := time.Since(start)
 		status := ctx.Response().StatusCode()
 
-		logFunc := zap.L().Debug
+		logFunc := log.Debug
 		if status >= 400 {
-			logFunc = zap.L().Warn
+			logFunc = log.Warn
 		}
 		if status >= 500 {
-			logFunc = zap.L().Error
+			logFunc = log.Error
 		}
 
 		logFunc("响应发送",
@@ -57,8 +70,36 @@ func CustomLogger() fiber.Handler {
 	}
 }
 
-// RegisterRoutes 注册所有路由和中间件
-func RegisterRoutes(app *fiber.App) {
+// RegisterRoutes 注册所有路由和中间件，中间件配置错误时返回错误
+// authn 为认证中间件，挂载在事件上下文、限流、响应缓存和幂等键中间件之前，为nil时所有请求均为匿名请求
+func RegisterRoutes(app *fiber.App, authn fiber.Handler) error {
+	conf := config.GetGlobalConfig()
+
+	// 指标中间件，最先挂载以统计其他中间件的耗时和拦截的请求
+	if conf.Metrics.Enabled {
+		app.Use(metrics.HTTPMiddleware())
+	}
+
+	// 链路追踪中间件，需在日志中间件之前挂载，以便请求日志带有追踪ID
+	if conf.Tracing.Enabled {
+		app.Use(tracing.Middleware())
+	}
+
+	// CORS策略，配置文件变更后重新加载，新配置无效时保留原有策略
+	corsPolicies, err := NewCORS(conf.CORS)
+	if err != nil {
+		return err
+	}
+	config.OnValidate(func(c *config.Config) error {
+		_, err := NewCORS(c.CORS)
+		return err
+	})
+	config.OnChange(func(c *config.Config) {
+		if err := corsPolicies.Reload(c.CORS); err != nil {
+			zap.L().Error("CORS配置无效，保留原有策略", zap.Error(err))
+		}
+	})
+
 	// 全局中间件
 	app.Use(
 		requestid.New(), // 请求ID中间件
@@ -67,27 +108,36 @@ func RegisterRoutes(app *fiber.App) {
 			TimeFormat: "2006-01-02 15:04:05",
 			TimeZone:   "Asia/Shanghai",
 		}),
-		CustomLogger(), // 自定义日志中间件
-		cors.New(cors.Config{ // CORS中间件
-			AllowOrigins:     []string{"*"},
-			AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-			AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-			AllowCredentials: true,
-			MaxAge:           300,
-		}),
+		CustomLogger(),         // 自定义日志中间件
+		corsPolicies.Handler(), // CORS中间件
 	)
 
+	// 认证中间件，之后的中间件和路由按 Locals 中的用户ID和租户ID识别调用方
+	if authn != nil {
+		app.Use(authn)
+	} else {
+		zap.L().Warn("未配置认证中间件，所有请求均为匿名请求，需要登录的接口将返回401")
+	}
+	app.Use(EventContext()) // 事件上下文中间件，操作者取自认证中间件设置的用户ID
+
+	// 限流中间件，需在路由注册前挂载
+	if err := registerRateLimits(app, conf.RateLimit, conf.DB.Dialect(), authn != nil); err != nil {
+		return err
+	}
+
+	// 条件请求与响应缓存中间件
+	if conf.HTTPCache.Enabled {
+		app.Use(HTTPCache(conf.HTTPCache))
+	}
+
 	// API版本控制
 	api := app.Group("/api")
 	v1 := api.Group("/v1")
+	v1.Use(Idempotency(conf.Idempotency)) // 幂等键中间件
 
-	// 健康检查路由
-	app.Get("/health", func(c fiber.Ctx) error {
-		return c.JSON(fiber.Map{
-			"status": "ok",
-			"time":   time.Now().Format(time.RFC3339),
-		})
-	})
+	// 健康检查路由，公开端口只返回整体状态，每个检查的结果在管理端口查看
+	app.Get("/health", health.Handler(health.Default(), health.Readiness, false))
+	health.RegisterRoutes(app, health.Default(), false)
 
 	// 根路由
 	app.Get("/", func(c fiber.Ctx) error {
@@ -95,11 +145,47 @@ func RegisterRoutes(app *fiber.App) {
 	})
 
 	// 注册API路由
-	registerV1Routes(v1)
+	registerV1Routes(v1, conf)
+	return nil
+}
+
+// registerRateLimits 按路由组挂载限流策略，authenticated 表示是否配置了认证中间件
+func registerRateLimits(app *fiber.App, conf config.RateLimitConfig, dialect string, authenticated bool) error {
+	if !conf.Enabled || len(conf.Policies) == 0 {
+		return nil
+	}
+
+	// 没有认证中间件时按用户或租户限流会全部退化为按IP限流
+	if !authenticated {
+		for _, p := range conf.Policies {
+			if p.KeyBy == ratelimit.KeyByUser || p.KeyBy == ratelimit.KeyByTenant {
+				return errors.Errorf("限流策略 %s 按 %s 限流，但未配置认证中间件", p.Name, p.KeyBy)
+			}
+		}
+	}
+
+	store, err := ratelimit.NewStore(conf.Store, dialect)
+	if err != nil {
+		return errors.Wrap(err, "创建限流存储失败")
+	}
+
+	for _, p := range conf.Policies {
+		limiter, err := ratelimit.NewLimiter(ratelimit.PolicyFromConfig(p), store)
+		if err != nil {
+			return errors.Wrapf(err, "限流策略配置错误: %s", p.Group)
+		}
+
+		group := p.Group
+		if group == "" {
+			group = "/"
+		}
+		app.Use(group, ratelimit.New(limiter))
+	}
+	return nil
 }
 
 // registerV1Routes 注册V1版本的API路由
-func registerV1Routes(router fiber.Router) {
+func registerV1Routes(router fiber.Router, conf *config.Config) {
 	// 用户相关路由
 	userGroup := router.Group("/users")
 	userGroup.Get("/", func(c fiber.Ctx) error {
@@ -118,5 +204,41 @@ func registerV1Routes(router fiber.Router) {
 		return c.JSON(fiber.Map{"message": "删除用户", "id": c.Params("id")})
 	})
 
+	// 变更事件推送路由
+	if conf.ChangeFeed.Enabled {
+		broker := changefeed.Default()
+		authorizer := changefeed.OwnerAuthorizer()
+		if conf.ChangeFeed.AdminRole != "" {
+			authorizer = changefeed.RoleAuthorizer(conf.ChangeFeed.AdminRole, authorizer)
+		}
+		options := []changefeed.HandlerOption{
+			changefeed.WithAuthorizer(authorizer),
+			changefeed.WithHeartbeat(conf.ChangeFeed.Heartbeat),
+		}
+		eventGroup := router.Group("/events", auth.Required())
+		eventGroup.Get("/", changefeed.SSEHandler(broker, options...))
+		eventGroup.Get("/ws", changefeed.WebSocketHandler(broker, options...))
+	}
+
+	// Webhook订阅管理路由
+	if conf.Webhook.Enabled {
+		webhook.RegisterRoutes(router.Group("/webhooks"))
+	}
+
+	// 文件上传下载路由，大文件上传需在 server.routes 中为该路由组开启 stream_body
+	if conf.Storage.Enabled {
+		file.RegisterRoutes(router.Group("/files"), file.Default(), RequestBodyStream)
+	}
+
+	// 头像上传与访问路由
+	if conf.Avatar.Enabled {
+		avatar.RegisterRoutes(router.Group("/avatars"), avatar.Default(), RequestBodyStream)
+	}
+
+	// 当前用户的通知收件箱和偏好设置路由
+	if conf.Notification.Enabled {
+		notification.RegisterRoutes(router.Group("/notifications"), notification.Default())
+	}
+
 	// 这里可以继续添加其他路由组
 }
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+
+	"doghole/config"
+	"github.com/gofiber/fiber/v3"
+)
+
+// localsBodyStream 保存带大小限制的请求体流的 Locals 键
+const localsBodyStream = "bodystream"
+
+// errBodyTooLarge 请求体超过路由组限制
+var errBodyTooLarge = fiber.NewError(fiber.StatusRequestEntityTooLarge, "请求体过大")
+
+// routeBodySettings 根据路由组配置计算Fiber的全局请求体上限和是否启用流式请求体
+// 启用流式请求体时，超过默认上限的请求体以流的形式交给路由组中间件按各自上限处理；
+// 未启用时，全局上限需放宽到所有路由组中的最大值
+func routeBodySettings(conf ServerConfig) (int, bool) {
+	limit := conf.BodyLimit
+	stream := false
+	maxLimit := limit
+
+	for _, r := range conf.Routes {
+		if r.StreamBody {
+			stream = true
+		}
+		if r.BodyLimit > maxLimit {
+			maxLimit = r.BodyLimit
+		}
+	}
+
+	if stream {
+		return limit, true
+	}
+	return maxLimit, false
+}
+
+// RoutePolicies 路由组级别的超时和请求体限制中间件
+// 按最长前缀匹配路由组配置，未匹配的请求使用默认请求体上限
+func RoutePolicies(routes []config.RouteConfig, defaultBodyLimit int) fiber.Handler {
+	policies := make([]config.RouteConfig, 0, len(routes))
+	for _, r := range routes {
+		r.Group = strings.TrimSuffix(r.Group, "/")
+		policies = append(policies, r)
+	}
+	sort.SliceStable(policies, func(i, j int) bool {
+		return len(policies[i].Group) > len(policies[j].Group)
+	})
+
+	return func(c fiber.Ctx) error {
+		policy := config.RouteConfig{BodyLimit: defaultBodyLimit}
+		path := c.Path()
+		for _, p := range policies {
+			if path == p.Group || strings.HasPrefix(path, p.Group+"/") || p.Group == "" {
+				policy = p
+				if policy.BodyLimit <= 0 {
+					policy.BodyLimit = defaultBodyLimit
+				}
+				break
+			}
+		}
+
+		if err := limitRequestBody(c, policy); err != nil {
+			return err
+		}
+
+		if policy.Timeout <= 0 {
+			return c.Next()
+		}
+
+		// 超时后取消处理函数的context，使用该context的数据库查询随之中断
+		ctx, cancel := context.WithTimeout(c.Context(), policy.Timeout)
+		defer cancel()
+		c.SetContext(ctx)
+
+		err := c.Next()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && (err != nil || c.Response().StatusCode() >= fiber.StatusInternalServerError) {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "请求处理超时")
+		}
+
+		return err
+	}
+}
+
+// limitRequestBody 检查请求体大小
+// 流式路由组保留请求体流并在读取超限时返回错误，其余路由组一次性读入内存
+func limitRequestBody(c fiber.Ctx, policy config.RouteConfig) error {
+	limit := policy.BodyLimit
+	if limit <= 0 {
+		return nil
+	}
+
+	req := c.Request()
+	if req.Header.ContentLength() > limit {
+		return errBodyTooLarge
+	}
+
+	if !req.IsBodyStream() {
+		if len(req.Body()) > limit {
+			return errBodyTooLarge
+		}
+		return nil
+	}
+
+	stream := &limitedReader{r: req.BodyStream(), n: int64(limit)}
+	if policy.StreamBody {
+		c.Locals(localsBodyStream, stream)
+		return nil
+	}
+
+	body, err := io.ReadAll(stream)
+	if err != nil {
+		if errors.Is(err, errBodyTooLarge) {
+			return errBodyTooLarge
+		}
+		return fiber.NewError(fiber.StatusBadRequest, "读取请求体失败")
+	}
+	req.SetBodyRaw(body)
+
+	return nil
+}
+
+// RequestBodyStream 返回请求体的读取流
+// 在启用了 stream_body 的路由组中，请求体不会被完整读入内存，上传处理应使用此函数读取
+func RequestBodyStream(c fiber.Ctx) io.Reader {
+	if stream, ok := c.Locals(localsBodyStream).(io.Reader); ok {
+		return stream
+	}
+	if c.Request().IsBodyStream() {
+		return c.Request().BodyStream()
+	}
+	return bytes.NewReader(c.Body())
+}
+
+// limitedReader 超过上限时返回 errBodyTooLarge 的读取器
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read 实现 io.Reader 接口
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, errBodyTooLarge
+	}
+	// 多读一个字节用于判断是否超限
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n, errBodyTooLarge
+	}
+	return n, err
+}
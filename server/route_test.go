@@ -0,0 +1,82 @@
+package server
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"doghole/auth"
+	"doghole/config"
+	"doghole/eventbus"
+	"github.com/gofiber/fiber/v3"
+)
+
+// testAuth 测试用认证中间件，从 X-Test-User 请求头读取用户ID
+func testAuth(c fiber.Ctx) error {
+	if id := c.Get("X-Test-User"); id != "" {
+		c.Locals(auth.LocalsUserID, id)
+	}
+	return c.Next()
+}
+
+// withConfig 在测试期间替换全局配置
+func withConfig(t *testing.T, conf *config.Config) {
+	t.Helper()
+	prev := config.GetGlobalConfig()
+	config.SetGlobalConfig(conf)
+	t.Cleanup(func() { config.SetGlobalConfig(prev) })
+}
+
+func TestRegisterRoutesAuth(t *testing.T) {
+	conf := config.NewConfig()
+	conf.RateLimit = config.RateLimitConfig{
+		Enabled: true,
+		Store:   "memory",
+		Policies: []config.RateLimitPolicy{
+			{Name: "per-user", Group: "/probe", Limit: 1, Window: time.Minute, KeyBy: "user"},
+		},
+	}
+	withConfig(t, conf)
+
+	// 按用户限流必须配置认证中间件
+	err := RegisterRoutes(fiber.New(), nil)
+	if err == nil || !strings.Contains(err.Error(), "未配置认证中间件") {
+		t.Fatalf("err = %v", err)
+	}
+
+	app := fiber.New()
+	if err := RegisterRoutes(app, testAuth); err != nil {
+		t.Fatal(err)
+	}
+	app.Get("/probe", func(c fiber.Ctx) error {
+		return c.SendString(eventbus.MetadataFrom(c.Context()).Actor)
+	})
+
+	do := func(user string) (int, string) {
+		req := httptest.NewRequest(fiber.MethodGet, "/probe", nil)
+		req.Header.Set("X-Test-User", user)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, string(body)
+	}
+
+	// 事件上下文在认证之后，操作者为当前用户
+	if status, actor := do("alice"); status != fiber.StatusOK || actor != "alice" {
+		t.Fatalf("status = %d，actor = %q", status, actor)
+	}
+	// 限流在认证之后，按用户计数
+	if status, _ := do("alice"); status != fiber.StatusTooManyRequests {
+		t.Fatalf("同一用户超过限额 status = %d", status)
+	}
+	if status, _ := do("bob"); status != fiber.StatusOK {
+		t.Fatalf("其他用户 status = %d", status)
+	}
+}
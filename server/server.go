@@ -2,13 +2,16 @@ package server
 
 import (
 	"context"
-	"os"
-	"os/signal"
-	"syscall"
+	"crypto/tls"
+	"net"
 	"time"
 
+	"doghole/config"
+	"doghole/listener"
+	"doghole/prefork"
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/recover"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
@@ -19,7 +22,12 @@ type ServerConfig struct {
 	IdleTimeout       time.Duration
 	ShutdownTimeout   time.Duration
 	EnableCompression bool
+	Compression       config.CompressionConfig
 	EnablePrefork     bool
+	BodyLimit         int
+	Routes            []config.RouteConfig
+	UnixSocket        config.UnixSocketConfig
+	TLS               config.TLSConfig
 }
 
 // DefaultConfig 返回默认服务器配置
@@ -31,6 +39,7 @@ func DefaultConfig() ServerConfig {
 		ShutdownTimeout:   5 * time.Second,
 		EnableCompression: true,
 		EnablePrefork:     false,
+		BodyLimit:         4 * 1024 * 1024,
 	}
 }
 
@@ -39,10 +48,11 @@ type Server struct {
 	app    *fiber.App
 	config ServerConfig
 	logger *zap.Logger
+	auth   fiber.Handler
 }
 
-// NewServer 创建一个新的服务器实例
-func NewServer(options ...func(*Server)) *Server {
+// NewServer 创建一个新的服务器实例，中间件配置错误时返回错误
+func NewServer(options ...func(*Server)) (*Server, error) {
 	// 创建带有默认配置的服务器
 	s := &Server{
 		config: DefaultConfig(),
@@ -55,21 +65,30 @@ func NewServer(options ...func(*Server)) *Server {
 	}
 
 	// 配置Fiber应用
+	bodyLimit, streamBody := routeBodySettings(s.config)
 	fiberConfig := fiber.Config{
-		ReadTimeout:  s.config.ReadTimeout,
-		WriteTimeout: s.config.WriteTimeout,
-		IdleTimeout:  s.config.IdleTimeout,
+		ReadTimeout:       s.config.ReadTimeout,
+		WriteTimeout:      s.config.WriteTimeout,
+		IdleTimeout:       s.config.IdleTimeout,
+		BodyLimit:         bodyLimit,
+		StreamRequestBody: streamBody,
 	}
 
 	s.app = fiber.New(fiberConfig)
 
 	// 添加全局中间件
 	s.app.Use(recover.New())
+	if s.config.EnableCompression {
+		s.app.Use(Compression(s.config.Compression)) // 响应压缩
+	}
+	s.app.Use(RoutePolicies(s.config.Routes, s.config.BodyLimit)) // 路由组超时与请求体限制
 
 	// 注册路由
-	RegisterRoutes(s.app)
+	if err := RegisterRoutes(s.app, s.auth); err != nil {
+		return nil, err
+	}
 
-	return s
+	return s, nil
 }
 
 // WithConfig 设置服务器配置
@@ -86,31 +105,76 @@ func WithLogger(logger *zap.Logger) func(*Server) {
 	}
 }
 
-// Start 启动HTTP服务器
-func (s *Server) Start(addr string) error {
-	// 设置优雅关闭
-	go s.gracefulShutdown()
-
-	s.logger.Info("服务器启动", zap.String("地址", addr))
-	return s.app.Listen(addr)
+// WithAuth 设置认证中间件，认证通过时将用户ID和租户ID写入 auth.LocalsUserID 和 auth.LocalsTenantID
+// 未携带凭据的请求应继续处理（作为匿名请求），凭据无效时可直接返回401
+// 未设置时所有请求均为匿名请求，需要登录的接口始终返回401
+func WithAuth(handler fiber.Handler) func(*Server) {
+	return func(s *Server) {
+		s.auth = handler
+	}
 }
 
-// gracefulShutdown 优雅关闭服务器
-func (s *Server) gracefulShutdown() {
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+// Start 启动HTTP服务器，可同时监听多个地址，启用TLS时所有地址都以HTTPS提供服务
+// 地址格式见 listener.Listen，支持TCP、Unix域套接字和systemd套接字激活
+// 预分叉的子进程忽略 addrs，使用主进程传入的监听
+// 阻塞直到 Shutdown 被调用或监听出错，由调用方负责处理退出信号
+func (s *Server) Start(addrs ...string) error {
+	if s.config.EnablePrefork && prefork.IsChild() {
+		lns, err := prefork.Listeners()
+		if err != nil {
+			return err
+		}
+		return s.Serve(lns...)
+	}
 
-	<-quit
-	s.logger.Info("正在关闭服务器...")
+	if len(addrs) == 0 {
+		return errors.New("未配置监听地址")
+	}
+	mode, err := listener.ParseMode(s.config.UnixSocket.Mode)
+	if err != nil {
+		return err
+	}
+	lns, err := listener.ListenAll(addrs, listener.UnixOptions{Mode: mode, Group: s.config.UnixSocket.Group})
+	if err != nil {
+		return err
+	}
+	return s.Serve(lns...)
+}
+
+// Serve 在已创建的监听上提供服务，启用TLS时所有监听都以HTTPS提供服务，返回时关闭所有监听
+func (s *Server) Serve(lns ...net.Listener) error {
+	ln := listener.Multi(lns...)
 
-	ctx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	if err := s.app.ShutdownWithContext(ctx); err != nil {
-		s.logger.Fatal("服务器强制关闭", zap.Error(err))
+	var tlsConfig *tls.Config
+	if s.config.TLS.Enabled {
+		var err error
+		if tlsConfig, err = s.tlsConfig(ctx); err != nil {
+			ln.Close()
+			return err
+		}
 	}
 
+	if tlsConfig != nil {
+		ln = tls.NewListener(ln, tlsConfig)
+		s.logger.Info("HTTPS服务器启动", zap.String("地址", ln.Addr().String()), zap.String("客户端证书", s.config.TLS.ClientAuth))
+	} else {
+		s.logger.Info("服务器启动", zap.String("地址", ln.Addr().String()))
+	}
+	// 预分叉时只由第一个子进程输出启动信息
+	return s.app.Listener(ln, fiber.ListenConfig{DisableStartupMessage: prefork.ChildID() > 0})
+}
+
+// Shutdown 停止接受新连接并等待处理中的请求完成，ctx 超时后返回错误
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.logger.Info("正在关闭服务器...")
+	if err := s.app.ShutdownWithContext(ctx); err != nil {
+		return errors.Wrap(err, "服务器强制关闭")
+	}
 	s.logger.Info("服务器已优雅关闭")
+	return nil
 }
 
 // App 返回底层的Fiber应用实例
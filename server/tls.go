@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+
+	"doghole/certs"
+	"doghole/domain/conn"
+	"github.com/gofiber/fiber/v3"
+	"github.com/pkg/errors"
+)
+
+// tlsConfig 创建HTTPS配置，证书和客户端CA文件变更后自动重新加载，直到ctx取消
+// 启用ACME时证书由ACME服务器签发，cert_file 和 key_file 被忽略
+func (s *Server) tlsConfig(ctx context.Context) (*tls.Config, error) {
+	conf := s.config.TLS
+	if conf.ACME.Enabled {
+		return s.acmeTLSConfig(ctx)
+	}
+	if conf.CertFile == "" || conf.KeyFile == "" {
+		return nil, errors.New("启用TLS时必须配置 cert_file 和 key_file")
+	}
+
+	reloader, err := certs.NewReloader(conf.CertFile, conf.KeyFile, conf.ClientCAFile,
+		certs.WithInterval(conf.ReloadInterval),
+		certs.WithLogger(s.logger),
+	)
+	if err != nil {
+		return nil, err
+	}
+	go reloader.Run(ctx)
+
+	return certs.ServerConfig(conf, reloader.GetCertificate, reloader.ClientCAs)
+}
+
+// acmeTLSConfig 创建使用ACME证书的HTTPS配置
+func (s *Server) acmeTLSConfig(ctx context.Context) (*tls.Config, error) {
+	conf := s.config.TLS
+	// 验证服务器不会提供客户端证书，强制双向TLS时只能通过 HTTP-01 验证
+	if conf.ClientAuth == certs.ClientAuthRequired && conf.ACME.HTTPAddr == "" {
+		return nil, errors.New("client_auth 为 required 时 TLS-ALPN-01 验证无法完成，必须配置 acme.http_addr")
+	}
+
+	cache, err := certs.NewCache(conf.ACME, conn.Writer())
+	if err != nil {
+		return nil, err
+	}
+	manager, err := certs.NewACME(conf.ACME, cache, s.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCAs := func() *x509.CertPool { return nil }
+	if conf.ClientCAFile != "" {
+		reloader, err := certs.NewReloader("", "", conf.ClientCAFile,
+			certs.WithInterval(conf.ReloadInterval),
+			certs.WithLogger(s.logger),
+		)
+		if err != nil {
+			return nil, err
+		}
+		go reloader.Run(ctx)
+		clientCAs = reloader.ClientCAs
+	}
+
+	tlsConfig, err := certs.ServerConfig(conf, manager.GetCertificate, clientCAs)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.NextProtos = append(tlsConfig.NextProtos, certs.ALPNProto)
+
+	if err := manager.Start(ctx); err != nil {
+		return nil, err
+	}
+	return tlsConfig, nil
+}
+
+// ClientCertificate 返回客户端在TLS握手中提供并通过校验的证书，未提供时返回nil
+func ClientCertificate(c fiber.Ctx) *x509.Certificate {
+	state := c.RequestCtx().TLSConnectionState()
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return state.VerifiedChains[0][0]
+}
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// LocalStorage 本地文件系统存储
+// 写入先落到同目录的临时文件，完成后原子重命名，读取方不会看到写了一半的文件
+type LocalStorage struct {
+	root string
+}
+
+// NewLocalStorage 创建本地文件系统存储，根目录不存在时自动创建
+func NewLocalStorage(root string) (*LocalStorage, error) {
+	if root == "" {
+		return nil, errors.New("本地存储根目录不能为空")
+	}
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, errors.Wrap(err, "解析本地存储根目录失败")
+	}
+	if err := os.MkdirAll(abs, 0o755); err != nil {
+		return nil, errors.Wrap(err, "创建本地存储根目录失败")
+	}
+	return &LocalStorage{root: abs}, nil
+}
+
+// path 将对象键转换为文件路径
+func (s *LocalStorage) path(key string) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// Put 写入对象
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrap(err, "创建目录失败")
+	}
+
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return errors.Wrap(err, "创建临时文件失败")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, &contextReader{ctx: ctx, r: r}); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "写入文件失败")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "写入文件失败")
+	}
+
+	return errors.Wrap(os.Rename(tmp.Name(), name), "保存文件失败")
+}
+
+// Get 打开对象
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ObjectInfo{}, ErrNotFound
+		}
+		return nil, ObjectInfo{}, errors.Wrap(err, "打开文件失败")
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, ObjectInfo{}, errors.Wrap(err, "读取文件信息失败")
+	}
+
+	return f, ObjectInfo{Key: key, Size: fi.Size(), ModTime: fi.ModTime()}, nil
+}
+
+// Stat 获取对象元数据
+func (s *LocalStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	fi, err := os.Stat(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ObjectInfo{}, ErrNotFound
+		}
+		return ObjectInfo{}, errors.Wrap(err, "读取文件信息失败")
+	}
+	return ObjectInfo{Key: key, Size: fi.Size(), ModTime: fi.ModTime()}, nil
+}
+
+// Delete 删除对象
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "删除文件失败")
+	}
+	return nil
+}
+
+// contextReader 在ctx取消后停止读取的读取器
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read 实现 io.Reader 接口
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"doghole/config"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/pkg/errors"
+)
+
+// s3PartSize 长度未知时分片上传的分片大小
+// 不设置时客户端按对象最大长度计算分片，每个分片会在内存中缓冲数百MB
+const s3PartSize = 16 << 20
+
+// S3Storage S3兼容的对象存储，支持AWS S3、MinIO、Ceph等
+type S3Storage struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Storage 创建S3兼容存储
+func NewS3Storage(conf config.S3StorageConfig) (*S3Storage, error) {
+	if conf.Endpoint == "" || conf.Bucket == "" {
+		return nil, errors.New("S3存储的 endpoint 和 bucket 不能为空")
+	}
+
+	lookup := minio.BucketLookupAuto
+	if conf.PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+
+	client, err := minio.New(conf.Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(conf.AccessKey, conf.SecretKey, ""),
+		Secure:       conf.UseSSL,
+		Region:       conf.Region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "创建S3客户端失败")
+	}
+
+	prefix := strings.Trim(conf.Prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	return &S3Storage{client: client, bucket: conf.Bucket, prefix: prefix}, nil
+}
+
+// objectName 将对象键转换为带前缀的对象名
+func (s *S3Storage) objectName(key string) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+	return s.prefix + key, nil
+}
+
+// Put 写入对象
+// 长度未知时使用分片上传，读取出错时中止上传，不会留下不完整的对象
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	name, err := s.objectName(key)
+	if err != nil {
+		return err
+	}
+
+	opts := minio.PutObjectOptions{ContentType: contentType}
+	if size < 0 {
+		opts.PartSize = s3PartSize
+	}
+
+	if _, err := s.client.PutObject(ctx, s.bucket, name, r, size, opts); err != nil {
+		return errors.Wrap(err, "上传对象失败")
+	}
+	return nil
+}
+
+// Get 打开对象
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	name, err := s.objectName(key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	obj, err := s.client.GetObject(ctx, s.bucket, name, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, ObjectInfo{}, s.wrapError(err, "读取对象失败")
+	}
+
+	// GetObject 不会立即发出请求，通过 Stat 确认对象存在
+	info, err := obj.Stat()
+	if err != nil {
+		obj.Close()
+		return nil, ObjectInfo{}, s.wrapError(err, "读取对象失败")
+	}
+
+	return obj, s.objectInfo(key, info), nil
+}
+
+// Stat 获取对象元数据
+func (s *S3Storage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	name, err := s.objectName(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	info, err := s.client.StatObject(ctx, s.bucket, name, minio.StatObjectOptions{})
+	if err != nil {
+		return ObjectInfo{}, s.wrapError(err, "读取对象信息失败")
+	}
+	return s.objectInfo(key, info), nil
+}
+
+// Delete 删除对象
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	name, err := s.objectName(key)
+	if err != nil {
+		return err
+	}
+	if err := s.client.RemoveObject(ctx, s.bucket, name, minio.RemoveObjectOptions{}); err != nil {
+		if isNoSuchKey(err) {
+			return nil
+		}
+		return errors.Wrap(err, "删除对象失败")
+	}
+	return nil
+}
+
+// objectInfo 转换对象元数据
+func (s *S3Storage) objectInfo(key string, info minio.ObjectInfo) ObjectInfo {
+	return ObjectInfo{
+		Key:         key,
+		Size:        info.Size,
+		ContentType: info.ContentType,
+		ModTime:     info.LastModified,
+	}
+}
+
+// wrapError 将对象不存在的错误转换为 ErrNotFound
+func (s *S3Storage) wrapError(err error, message string) error {
+	if isNoSuchKey(err) {
+		return ErrNotFound
+	}
+	return errors.Wrap(err, message)
+}
+
+// isNoSuchKey 判断是否为对象不存在的错误
+func isNoSuchKey(err error) bool {
+	code := minio.ToErrorResponse(err).Code
+	return code == "NoSuchKey" || code == "NotFound"
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"doghole/config"
+	"github.com/pkg/errors"
+)
+
+// ErrNotFound 对象不存在
+var ErrNotFound = errors.New("对象不存在")
+
+// ErrInvalidKey 对象键无效
+var ErrInvalidKey = errors.New("无效的对象键")
+
+// ObjectInfo 对象元数据
+type ObjectInfo struct {
+	Key         string    // 对象键
+	Size        int64     // 字节数
+	ContentType string    // MIME类型，本地存储不保存该信息
+	ModTime     time.Time // 最后修改时间
+}
+
+// Storage 对象存储接口
+// 对象键使用 "/" 分隔的相对路径，如 2024/01/02/<uuid>
+type Storage interface {
+	// Put 以流的方式写入对象，size 为 -1 表示长度未知
+	// 读取r出错时写入失败，不会留下不完整的对象
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get 打开对象读取流，调用方负责关闭
+	Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error)
+	// Stat 获取对象元数据
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// Delete 删除对象，对象不存在时不返回错误
+	Delete(ctx context.Context, key string) error
+}
+
+// New 根据配置创建存储后端
+func New(conf config.StorageConfig) (Storage, error) {
+	switch conf.Backend {
+	case "", "local":
+		return NewLocalStorage(conf.Local.Root)
+	case "s3":
+		return NewS3Storage(conf.S3)
+	default:
+		return nil, errors.Errorf("不支持的存储后端: %s", conf.Backend)
+	}
+}
+
+// ValidateKey 校验对象键，拒绝绝对路径和包含 ".." 的键，防止越出存储根目录
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key {
+		return errors.Wrap(ErrInvalidKey, key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == ".." || part == "." {
+			return errors.Wrap(ErrInvalidKey, key)
+		}
+	}
+	return nil
+}
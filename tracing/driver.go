@@ -0,0 +1,155 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"doghole/metrics"
+	"entgo.io/ent/dialect"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// dbSystems ent方言对应的 db.system 属性值
+var dbSystems = map[string]string{
+	dialect.MySQL:    "mysql",
+	dialect.Postgres: "postgresql",
+	dialect.SQLite:   "sqlite",
+}
+
+// Driver 包装ent驱动，为每条SQL语句和每个事务创建span，name 用于区分读写连接，签名与 conn.DriverWrapper 相同
+func Driver(name string, drv dialect.Driver) dialect.Driver {
+	system, ok := dbSystems[drv.Dialect()]
+	if !ok {
+		system = drv.Dialect()
+	}
+	return &driver{
+		Driver: drv,
+		attrs: []attribute.KeyValue{
+			semconv.DBSystemKey.String(system),
+			attribute.String("db.connection", name),
+		},
+	}
+}
+
+// driver 为语句和事务创建span的ent驱动
+type driver struct {
+	dialect.Driver
+	attrs []attribute.KeyValue
+}
+
+// Exec 在span中执行语句
+func (d *driver) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuery(ctx, query, d.attrs)
+	err := d.Driver.Exec(ctx, query, args, v)
+	endSpan(span, err)
+	return err
+}
+
+// Query 在span中执行查询
+func (d *driver) Query(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuery(ctx, query, d.attrs)
+	err := d.Driver.Query(ctx, query, args, v)
+	endSpan(span, err)
+	return err
+}
+
+// Tx 开启事务，span 持续到提交或回滚，事务中的语句作为其子span
+func (d *driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	ctx, span := Tracer().Start(ctx, "db.transaction",
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(d.attrs...))
+	t, err := d.Driver.Tx(ctx)
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+	return &tx{Tx: t, span: span, attrs: d.attrs}, nil
+}
+
+// BeginTx 按选项开启事务，底层驱动不支持时返回错误
+func (d *driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, errors.New("底层驱动不支持 BeginTx")
+	}
+	ctx, span := Tracer().Start(ctx, "db.transaction",
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(d.attrs...))
+	t, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+	return &tx{Tx: t, span: span, attrs: d.attrs}, nil
+}
+
+// tx 为事务中的语句创建span的事务
+type tx struct {
+	dialect.Tx
+	span  trace.Span
+	attrs []attribute.KeyValue
+}
+
+// Exec 在事务span下执行语句
+func (t *tx) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuery(trace.ContextWithSpan(ctx, t.span), query, t.attrs)
+	err := t.Tx.Exec(ctx, query, args, v)
+	endSpan(span, err)
+	return err
+}
+
+// Query 在事务span下执行查询
+func (t *tx) Query(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuery(trace.ContextWithSpan(ctx, t.span), query, t.attrs)
+	err := t.Tx.Query(ctx, query, args, v)
+	endSpan(span, err)
+	return err
+}
+
+// Commit 提交事务并结束事务span
+func (t *tx) Commit() error {
+	err := t.Tx.Commit()
+	t.span.SetAttributes(attribute.String("db.transaction.outcome", "commit"))
+	endSpan(t.span, err)
+	return err
+}
+
+// Rollback 回滚事务并结束事务span
+func (t *tx) Rollback() error {
+	err := t.Tx.Rollback()
+	t.span.SetAttributes(attribute.String("db.transaction.outcome", "rollback"))
+	endSpan(t.span, err)
+	return err
+}
+
+// startQuery 为语句创建span，名称为操作和表名，如 "SELECT users"
+func startQuery(ctx context.Context, query string, attrs []attribute.KeyValue) (context.Context, trace.Span) {
+	op, table := metrics.Statement(query)
+	name := strings.ToUpper(op)
+	if table != "" {
+		name += " " + table
+	}
+	return Tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(
+			semconv.DBOperationName(op),
+			semconv.DBCollectionName(table),
+			semconv.DBQueryText(query),
+		),
+	)
+}
+
+// endSpan 结束span，执行失败时记录错误
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
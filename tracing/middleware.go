@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"fmt"
+	"strings"
+
+	"doghole/metrics"
+	"github.com/gofiber/fiber/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// headerCarrier 从请求头读取、向响应头写入传播字段
+type headerCarrier struct {
+	c fiber.Ctx
+}
+
+var _ propagation.TextMapCarrier = headerCarrier{}
+
+// Get 读取请求头
+func (h headerCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+// Set 写入响应头
+func (h headerCarrier) Set(key, value string) {
+	h.c.Set(key, value)
+}
+
+// Keys 返回所有请求头名称
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0)
+	for k := range h.c.GetReqHeaders() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Middleware 为每个请求创建服务端span，从请求头的 traceparent 延续上游的追踪，并在响应头中写回 traceparent
+// span 存放在 c.Context() 中，处理函数使用 c.Context() 执行的数据库查询会成为该span的子span
+func Middleware() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		propagator := otel.GetTextMapPropagator()
+		carrier := headerCarrier{c: c}
+		ctx := propagator.Extract(c.Context(), carrier)
+
+		// fiber 返回的字符串引用请求缓冲区，请求结束后会被复用，span 导出前需要复制
+		ctx, span := Tracer().Start(ctx, c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				semconv.URLPath(strings.Clone(c.Path())),
+				semconv.URLScheme(c.Scheme()),
+				semconv.ClientAddress(strings.Clone(c.IP())),
+				semconv.UserAgentOriginal(strings.Clone(c.Get(fiber.HeaderUserAgent))),
+			),
+		)
+		defer span.End()
+
+		c.SetContext(ctx)
+		propagator.Inject(ctx, carrier)
+
+		err := c.Next()
+
+		// 路由在匹配后才能确定，使用路由模板命名span，避免原始路径导致span名称过多
+		if route := metrics.Route(c); route != "" {
+			span.SetName(fmt.Sprintf("%s %s", c.Method(), route))
+			span.SetAttributes(semconv.HTTPRoute(route))
+		}
+
+		status := metrics.ResponseStatus(c, err)
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if err != nil {
+			span.RecordError(err)
+		}
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", status))
+		}
+		return err
+	}
+}
@@ -0,0 +1,128 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"doghole/config"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 导出器类型
+const (
+	ExporterOTLP   = "otlp"   // 通过OTLP/HTTP发送到收集器
+	ExporterStdout = "stdout" // 输出到标准输出，用于本地调试
+	ExporterNone   = "none"   // 不导出，仍然生成和传递追踪ID，便于关联日志
+)
+
+// instrumentationName 本项目埋点使用的 Tracer 名称
+const instrumentationName = "doghole"
+
+// options 追踪选项
+type options struct {
+	version  string
+	exporter sdktrace.SpanExporter
+}
+
+// Option 追踪选项函数
+type Option func(*options)
+
+// WithServiceVersion 设置上报的服务版本
+func WithServiceVersion(version string) Option {
+	return func(o *options) {
+		o.version = version
+	}
+}
+
+// WithExporter 使用指定的导出器，忽略配置中的导出器类型，测试时可传入 tracetest.NewInMemoryExporter()
+func WithExporter(exporter sdktrace.SpanExporter) Option {
+	return func(o *options) {
+		o.exporter = exporter
+	}
+}
+
+// Setup 按配置创建 TracerProvider，并设置为全局的 TracerProvider 和 W3C traceparent 传播器
+// 返回的 TracerProvider 需要在退出时调用 Shutdown，以便导出缓冲中的span
+func Setup(ctx context.Context, conf config.TracingConfig, opts ...Option) (*sdktrace.TracerProvider, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	exporter := o.exporter
+	if exporter == nil {
+		var err error
+		if exporter, err = newExporter(ctx, conf); err != nil {
+			return nil, err
+		}
+	}
+
+	attrs := []attribute.KeyValue{semconv.ServiceName(conf.ServiceName)}
+	if o.version != "" {
+		attrs = append(attrs, semconv.ServiceVersion(o.version))
+	}
+	if host, err := os.Hostname(); err == nil {
+		attrs = append(attrs, semconv.HostName(host))
+	}
+	res, err := resource.New(ctx, resource.WithAttributes(attrs...), resource.WithTelemetrySDK())
+	if err != nil {
+		return nil, errors.Wrap(err, "创建追踪资源失败")
+	}
+
+	providerOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SampleRatio))),
+	}
+	if exporter != nil {
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exporter))
+	}
+	provider := sdktrace.NewTracerProvider(providerOpts...)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider, nil
+}
+
+// newExporter 按配置创建导出器，类型为 none 时返回nil
+func newExporter(ctx context.Context, conf config.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch strings.ToLower(conf.Exporter) {
+	case "", ExporterOTLP:
+		var opts []otlptracehttp.Option
+		if conf.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(conf.Endpoint))
+		}
+		if len(conf.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(conf.Headers))
+		}
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return nil, errors.Wrap(err, "创建OTLP导出器失败")
+		}
+		return exporter, nil
+	case ExporterStdout:
+		exporter, err := stdouttrace.New()
+		if err != nil {
+			return nil, errors.Wrap(err, "创建标准输出导出器失败")
+		}
+		return exporter, nil
+	case ExporterNone:
+		return nil, nil
+	default:
+		return nil, errors.Errorf("链路追踪配置错误: 不支持的导出器 %s，可选值: otlp, stdout, none", conf.Exporter)
+	}
+}
+
+// Tracer 返回本项目埋点使用的 Tracer，未调用 Setup 时不产生span
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
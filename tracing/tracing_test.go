@@ -0,0 +1,230 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"doghole/config"
+	"doghole/ent"
+	"doghole/logger"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/gofiber/fiber/v3"
+	_ "github.com/mattn/go-sqlite3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// setupTest 使用内存导出器初始化追踪，返回导出已结束span的函数
+func setupTest(t *testing.T) func() tracetest.SpanStubs {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider, err := Setup(context.Background(), config.TracingConfig{
+		Enabled:     true,
+		ServiceName: "doghole-test",
+		SampleRatio: 1,
+	}, WithExporter(exporter))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	return func() tracetest.SpanStubs {
+		t.Helper()
+		if err := provider.ForceFlush(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		spans := exporter.GetSpans()
+		exporter.Reset()
+		return spans
+	}
+}
+
+// findSpan 按名称查找span
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, s := range spans {
+		if s.Name == name {
+			return s
+		}
+	}
+	names := make([]string, len(spans))
+	for i, s := range spans {
+		names[i] = s.Name
+	}
+	t.Fatalf("未找到span %q，实际: %v", name, names)
+	return tracetest.SpanStub{}
+}
+
+// attr 读取span的属性
+func attr(s tracetest.SpanStub, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range s.Attributes {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestMiddleware(t *testing.T) {
+	flush := setupTest(t)
+
+	core, logs := observer.New(zap.InfoLevel)
+	defer zap.ReplaceGlobals(zap.New(core))()
+
+	app := fiber.New()
+	app.Use(Middleware())
+	app.Get("/users/:id", func(c fiber.Ctx) error {
+		logger.Ctx(c.Context()).Info("处理请求")
+		return c.SendString("ok")
+	})
+	app.Get("/fail", func(c fiber.Ctx) error {
+		return fiber.ErrServiceUnavailable
+	})
+
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+	req := httptest.NewRequest(fiber.MethodGet, "/users/42", nil)
+	req.Header.Set("traceparent", fmt.Sprintf("00-%s-%s-01", traceID, spanID))
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := findSpan(t, flush(), "GET /users/:id")
+	if s.SpanKind != trace.SpanKindServer {
+		t.Errorf("SpanKind = %v", s.SpanKind)
+	}
+	if got := s.SpanContext.TraceID().String(); got != traceID {
+		t.Errorf("应延续上游追踪: trace_id = %s", got)
+	}
+	if got := s.Parent.SpanID().String(); got != spanID {
+		t.Errorf("父span = %s，期望 %s", got, spanID)
+	}
+	if v, ok := attr(s, semconv.HTTPRouteKey); !ok || v.AsString() != "/users/:id" {
+		t.Errorf("http.route = %v", v.AsString())
+	}
+	if v, ok := attr(s, semconv.HTTPResponseStatusCodeKey); !ok || v.AsInt64() != fiber.StatusOK {
+		t.Errorf("http.response.status_code = %v", v.AsInt64())
+	}
+
+	want := fmt.Sprintf("00-%s-%s-01", traceID, s.SpanContext.SpanID())
+	if got := resp.Header.Get("traceparent"); got != want {
+		t.Errorf("响应头 traceparent = %q，期望 %q", got, want)
+	}
+
+	entries := logs.FilterMessage("处理请求").All()
+	if len(entries) != 1 {
+		t.Fatalf("日志条数 = %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["trace_id"] != traceID || fields["span_id"] != s.SpanContext.SpanID().String() {
+		t.Errorf("日志中的追踪字段 = %v", fields)
+	}
+
+	// 5xx 响应标记为错误
+	if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/fail", nil)); err != nil {
+		t.Fatal(err)
+	}
+	s = findSpan(t, flush(), "GET /fail")
+	if s.Status.Code != codes.Error {
+		t.Errorf("5xx响应的span状态 = %v", s.Status.Code)
+	}
+	if s.Parent.IsValid() {
+		t.Error("没有 traceparent 时应开始新的追踪")
+	}
+}
+
+func TestDriver(t *testing.T) {
+	flush := setupTest(t)
+	ctx := context.Background()
+
+	drv, err := entsql.Open("sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := ent.NewClient(ent.Driver(Driver("writer", drv)))
+	defer client.Close()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+	flush()
+
+	ctx, parent := Tracer().Start(ctx, "parent")
+	if _, err := client.FeatureFlag.Query().All(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.FeatureFlag.Create().SetKey("a").Exec(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	spans := flush()
+	root := findSpan(t, spans, "parent")
+
+	query := findSpan(t, spans, "SELECT feature_flags")
+	if query.Parent.SpanID() != root.SpanContext.SpanID() {
+		t.Error("查询span应为请求span的子span")
+	}
+	if v, _ := attr(query, semconv.DBSystemKey); v.AsString() != "sqlite" {
+		t.Errorf("db.system = %q", v.AsString())
+	}
+	if v, _ := attr(query, "db.connection"); v.AsString() != "writer" {
+		t.Errorf("db.connection = %q", v.AsString())
+	}
+
+	txSpan := findSpan(t, spans, "db.transaction")
+	if txSpan.Parent.SpanID() != root.SpanContext.SpanID() {
+		t.Error("事务span应为请求span的子span")
+	}
+	if v, _ := attr(txSpan, "db.transaction.outcome"); v.AsString() != "commit" {
+		t.Errorf("db.transaction.outcome = %q", v.AsString())
+	}
+	insert := findSpan(t, spans, "INSERT feature_flags")
+	if insert.Parent.SpanID() != txSpan.SpanContext.SpanID() {
+		t.Error("事务中的语句应为事务span的子span")
+	}
+}
+
+func TestSampleRatio(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider, err := Setup(context.Background(), config.TracingConfig{ServiceName: "doghole-test"}, WithExporter(exporter))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown(context.Background())
+
+	// 采样比例为0时不导出新的追踪，但仍生成追踪ID用于关联日志
+	ctx, span := Tracer().Start(context.Background(), "unsampled")
+	span.End()
+	if !trace.SpanContextFromContext(ctx).TraceID().IsValid() {
+		t.Error("未采样的span也应有追踪ID")
+	}
+	if len(logger.TraceFields(ctx)) != 2 {
+		t.Error("未采样的span也应在日志中记录追踪ID")
+	}
+
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(exporter.GetSpans()); n != 0 {
+		t.Errorf("采样比例为0时导出了 %d 个span", n)
+	}
+}